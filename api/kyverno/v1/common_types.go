@@ -71,6 +71,10 @@ type ContextEntry struct {
 
 	// Variable defines an arbitrary JMESPath context variable that can be defined inline.
 	Variable *Variable `json:"variable,omitempty" yaml:"variable,omitempty"`
+
+	// Timeout bounds the time spent loading the context entry.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 }
 
 // Variable defines an arbitrary JMESPath context variable that can be defined inline.
@@ -173,7 +177,7 @@ type Condition struct {
 	// Operator is the conditional operation to perform. Valid operators are:
 	// Equals, NotEquals, In, AnyIn, AllIn, NotIn, AnyNotIn, AllNotIn, GreaterThanOrEquals,
 	// GreaterThan, LessThanOrEquals, LessThan, DurationGreaterThanOrEquals, DurationGreaterThan,
-	// DurationLessThanOrEquals, DurationLessThan
+	// DurationLessThanOrEquals, DurationLessThan, CIDRContains, AnyCIDROverlaps
 	Operator ConditionOperator `json:"operator,omitempty" yaml:"operator,omitempty"`
 
 	// Value is the conditional value, or set of values. The values can be fixed set
@@ -199,7 +203,7 @@ func (c *Condition) SetValue(in apiextensions.JSON) {
 }
 
 // ConditionOperator is the operation performed on condition key and value.
-// +kubebuilder:validation:Enum=Equals;NotEquals;In;AnyIn;AllIn;NotIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan
+// +kubebuilder:validation:Enum=Equals;NotEquals;In;AnyIn;AllIn;NotIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan;CIDRContains;AnyCIDROverlaps
 type ConditionOperator string
 
 // ConditionOperators stores all the valid ConditionOperator types as key-value pairs.
@@ -222,6 +226,8 @@ type ConditionOperator string
 // "DurationGreaterThan" evaluates if the key (duration) is greater than the value (duration)
 // "DurationLessThanOrEquals" evaluates if the key (duration) is less than or equal to the value (duration)
 // "DurationLessThan" evaluates if the key (duration) is greater than the value (duration)
+// "CIDRContains" evaluates if the key (CIDRs) contains all the values (IP addresses or CIDRs)
+// "AnyCIDROverlaps" evaluates if any of the keys (CIDRs) overlaps any of the values (CIDRs)
 var ConditionOperators = map[string]ConditionOperator{
 	"Equal":                       ConditionOperator("Equal"),
 	"Equals":                      ConditionOperator("Equals"),
@@ -241,6 +247,8 @@ var ConditionOperators = map[string]ConditionOperator{
 	"DurationGreaterThan":         ConditionOperator("DurationGreaterThan"),
 	"DurationLessThanOrEquals":    ConditionOperator("DurationLessThanOrEquals"),
 	"DurationLessThan":            ConditionOperator("DurationLessThan"),
+	"CIDRContains":                ConditionOperator("CIDRContains"),
+	"AnyCIDROverlaps":             ConditionOperator("AnyCIDROverlaps"),
 }
 
 // ResourceFilters is a slice of ResourceFilter
@@ -274,6 +282,8 @@ type Mutation struct {
 
 	// PatchesJSON6902 is a list of RFC 6902 JSON Patch declarations used to modify resources.
 	// See https://tools.ietf.org/html/rfc6902 and https://kubectl.docs.kubernetes.io/references/kustomize/patchesjson6902/.
+	// Operations can declare an `if` JMESPath condition, they are applied only if it evaluates to true, and a `foreach`
+	// JMESPath expression evaluating to a list, an operation is generated for each element of the list.
 	// +optional
 	PatchesJSON6902 string `json:"patchesJson6902,omitempty" yaml:"patchesJson6902,omitempty"`
 
@@ -315,6 +325,8 @@ type ForEachMutation struct {
 
 	// PatchesJSON6902 is a list of RFC 6902 JSON Patch declarations used to modify resources.
 	// See https://tools.ietf.org/html/rfc6902 and https://kubectl.docs.kubernetes.io/references/kustomize/patchesjson6902/.
+	// Operations can declare an `if` JMESPath condition, they are applied only if it evaluates to true, and a `foreach`
+	// JMESPath expression evaluating to a list, an operation is generated for each element of the list.
 	// +optional
 	PatchesJSON6902 string `json:"patchesJson6902,omitempty" yaml:"patchesJson6902,omitempty"`
 
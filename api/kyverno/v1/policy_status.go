@@ -33,6 +33,9 @@ type PolicyStatus struct {
 	// RuleCount describes total number of rules in a policy
 	// +optional
 	RuleCount RuleCountStatus `json:"rulecount" yaml:"rulecount"`
+	// Dependencies contains the resources read, matched and generated by the policy rules
+	// +optional
+	Dependencies DependenciesStatus `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 }
 
 // RuleCountStatus contains four variables which describes counts for
@@ -48,6 +51,32 @@ type RuleCountStatus struct {
 	VerifyImages int `json:"verifyimages" yaml:"verifyimages"`
 }
 
+// DependenciesStatus contains the resources read, matched and generated by the rules of a policy,
+// rules generated for pod controllers included. Values depending on variables are not resolved.
+type DependenciesStatus struct {
+	// ConfigMaps are the ConfigMaps read by context entries, given as namespace/name
+	// +optional
+	ConfigMaps []string `json:"configMaps,omitempty" yaml:"configMaps,omitempty"`
+	// APICalls are the URL paths and service URLs called by API call context entries
+	// +optional
+	APICalls []string `json:"apiCalls,omitempty" yaml:"apiCalls,omitempty"`
+	// ImageRegistries are the image registries read by context entries and image verification rules
+	// +optional
+	ImageRegistries []string `json:"imageRegistries,omitempty" yaml:"imageRegistries,omitempty"`
+	// Kinds are the kinds of the resources matched by the policy
+	// +optional
+	Kinds []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+	// Namespaces are the namespaces of the resources matched by the policy
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+	// Selectors are the label selectors of the resources and namespaces matched by the policy
+	// +optional
+	Selectors []string `json:"selectors,omitempty" yaml:"selectors,omitempty"`
+	// GeneratedKinds are the kinds of the resources generated by the policy
+	// +optional
+	GeneratedKinds []string `json:"generatedKinds,omitempty" yaml:"generatedKinds,omitempty"`
+}
+
 func (status *PolicyStatus) SetReady(ready bool) {
 	condition := metav1.Condition{
 		Type: PolicyConditionReady,
@@ -49,3 +49,28 @@ func Test_Validate_UniqueRuleName(t *testing.T) {
 	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
 	assert.Equal(t, errs[0].Detail, "Duplicate rule name: 'deny-privileged-disallowpriviligedescalation'")
 }
+
+func Test_Validate_ShadowOf(t *testing.T) {
+	subject := Spec{
+		ShadowOf: "live",
+		Rules: []Rule{{
+			Name: "validate",
+			Validation: Validation{
+				Message: "message",
+			},
+		}, {
+			Name: "mutate",
+			Mutation: Mutation{
+				RawPatchStrategicMerge: &apiextv1.JSON{Raw: []byte(`{"metadata":{"labels":{"app":"test"}}}`)},
+			},
+		}},
+	}
+	path := field.NewPath("spec")
+	errs := subject.ValidateShadowOf(path, "shadow")
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "spec.rules[1]")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeForbidden)
+	errs = subject.ValidateShadowOf(path, "live")
+	assert.Equal(t, len(errs), 2)
+	assert.Equal(t, errs[0].Field, "spec.shadowOf")
+}
@@ -106,6 +106,33 @@ type Spec struct {
 	// Defaults to "false" if not specified.
 	// +optional
 	GenerateExistingOnPolicyUpdate bool `json:"generateExistingOnPolicyUpdate,omitempty" yaml:"generateExistingOnPolicyUpdate,omitempty"`
+
+	// ShadowOf makes the policy a shadow of the policy with the given name, in the same namespace for a Policy.
+	// A shadow policy is evaluated alongside the live policy on every admission request but never blocks,
+	// disagreements between the shadow and live policies are recorded in metrics and reports.
+	// Shadow policies can only contain validate rules.
+	// +optional
+	ShadowOf string `json:"shadowOf,omitempty" yaml:"shadowOf,omitempty"`
+
+	// Functions are JMESPath functions defined by the policy, they can be called from any variable
+	// substitution of the policy rules and from the other functions of the policy.
+	// +optional
+	Functions []Function `json:"functions,omitempty" yaml:"functions,omitempty"`
+}
+
+// Function is a JMESPath function defined by a JMESPath expression.
+type Function struct {
+	// Name is the name of the function, it must not conflict with the name of a built-in function.
+	Name string `json:"name" yaml:"name"`
+
+	// Parameters are the names of the function arguments, arguments are available to the expression
+	// as fields of the current node, e.g. the expression `join('-', [first, second])` with parameters
+	// `first` and `second`.
+	// +optional
+	Parameters []string `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+
+	// Expression is the JMESPath expression evaluated when the function is called.
+	Expression string `json:"expression" yaml:"expression"`
 }
 
 func (s *Spec) SetRules(rules []Rule) {
@@ -235,6 +262,11 @@ func (s *Spec) GetApplyRules() ApplyRulesType {
 	return *s.ApplyRules
 }
 
+// IsShadow checks if the policy is a shadow of another policy
+func (s *Spec) IsShadow() bool {
+	return s.ShadowOf != ""
+}
+
 func (s *Spec) ValidateSchema() bool {
 	if s.SchemaValidation != nil {
 		return *s.SchemaValidation
@@ -255,6 +287,22 @@ func (s *Spec) ValidateRuleNames(path *field.Path) (errs field.ErrorList) {
 	return errs
 }
 
+// ValidateShadowOf checks that a shadow policy only contains validate rules and doesn't shadow itself
+func (s *Spec) ValidateShadowOf(path *field.Path, policyName string) (errs field.ErrorList) {
+	if !s.IsShadow() {
+		return nil
+	}
+	if s.ShadowOf == policyName {
+		errs = append(errs, field.Invalid(path.Child("shadowOf"), s.ShadowOf, "A policy can not be a shadow of itself"))
+	}
+	for i, rule := range s.Rules {
+		if !rule.HasValidate() || rule.HasMutate() || rule.HasGenerate() || rule.HasVerifyImages() {
+			errs = append(errs, field.Forbidden(path.Child("rules").Index(i), "Shadow policies can only contain validate rules"))
+		}
+	}
+	return errs
+}
+
 // ValidateRules implements programmatic validation of Rules
 func (s *Spec) ValidateRules(path *field.Path, namespaced bool, policyNamespace string, clusterResources sets.Set[string]) (errs field.ErrorList) {
 	errs = append(errs, s.ValidateRuleNames(path)...)
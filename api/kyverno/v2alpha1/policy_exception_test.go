@@ -0,0 +1,165 @@
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func newPolicyException(exceptions ...Exception) PolicyException {
+	return PolicyException{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-exception",
+			Namespace: "test",
+		},
+		Spec: PolicyExceptionSpec{
+			Match: kyvernov2beta1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{
+						Kinds: []string{"Pod"},
+					},
+				}},
+			},
+			Exceptions: exceptions,
+		},
+	}
+}
+
+func Test_PolicyException_ValidityWindow(t *testing.T) {
+	now := time.Now()
+	subject := newPolicyException(Exception{PolicyName: "policy", RuleNames: []string{"rule"}})
+	subject.Spec.NotBefore = &metav1.Time{Time: now}
+	subject.Spec.ExpiresAt = &metav1.Time{Time: now.Add(-time.Hour)}
+	errs := subject.Validate()
+	assert.Assert(t, len(errs) == 1)
+	assert.Equal(t, errs[0].Field, "spec.expiresAt")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
+	assert.Equal(t, errs[0].Detail, "expiresAt must be after notBefore")
+}
+
+func Test_PolicyException_ImageReferences_Empty(t *testing.T) {
+	subject := newPolicyException(Exception{PolicyName: "policy", RuleNames: []string{"rule"}, ImageReferences: []string{"ghcr.io/*", ""}})
+	errs := subject.Validate()
+	assert.Assert(t, len(errs) == 1)
+	assert.Equal(t, errs[0].Field, "spec.exceptions[0].imageReferences[1]")
+	assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
+}
+
+func Test_PolicyException_IsActive(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		notBefore *metav1.Time
+		expiresAt *metav1.Time
+		want      bool
+	}{{
+		name: "no window",
+		want: true,
+	}, {
+		name:      "not yet valid",
+		notBefore: &metav1.Time{Time: now.Add(time.Hour)},
+		want:      false,
+	}, {
+		name:      "started",
+		notBefore: &metav1.Time{Time: now.Add(-time.Hour)},
+		want:      true,
+	}, {
+		name:      "expired",
+		expiresAt: &metav1.Time{Time: now.Add(-time.Hour)},
+		want:      false,
+	}, {
+		name:      "expires exactly now",
+		expiresAt: &metav1.Time{Time: now},
+		want:      false,
+	}, {
+		name:      "within window",
+		notBefore: &metav1.Time{Time: now.Add(-time.Hour)},
+		expiresAt: &metav1.Time{Time: now.Add(time.Hour)},
+		want:      true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject := newPolicyException()
+			subject.Spec.NotBefore = tt.notBefore
+			subject.Spec.ExpiresAt = tt.expiresAt
+			assert.Equal(t, subject.IsActive(now), tt.want)
+		})
+	}
+}
+
+func Test_PolicyException_ImageReferences(t *testing.T) {
+	subject := newPolicyException(
+		Exception{PolicyName: "policy", RuleNames: []string{"scoped"}, ImageReferences: []string{"ghcr.io/*"}},
+		Exception{PolicyName: "policy", RuleNames: []string{"scoped"}, ImageReferences: []string{"docker.io/*"}},
+		Exception{PolicyName: "policy", RuleNames: []string{"mixed"}, ImageReferences: []string{"ghcr.io/*"}},
+		Exception{PolicyName: "policy", RuleNames: []string{"mixed", "unscoped"}},
+	)
+	assert.DeepEqual(t, subject.ImageReferences("policy", "scoped"), []string{"ghcr.io/*", "docker.io/*"})
+	assert.Assert(t, subject.ImageReferences("policy", "mixed") == nil)
+	assert.Assert(t, subject.ImageReferences("policy", "unscoped") == nil)
+	assert.Assert(t, subject.ImageReferences("other", "scoped") == nil)
+}
+
+func Test_PolicyExceptionStatus_SetReady(t *testing.T) {
+	var status PolicyExceptionStatus
+	assert.Assert(t, !status.IsReady())
+	status.SetReady(true, PolicyExceptionReasonActive, "")
+	assert.Assert(t, status.IsReady())
+	status.SetReady(false, PolicyExceptionReasonExpired, "policy exception expired")
+	assert.Assert(t, !status.IsReady())
+	assert.Equal(t, len(status.Conditions), 1)
+	assert.Equal(t, status.Conditions[0].Reason, PolicyExceptionReasonExpired)
+}
+
+func Test_PolicyException_IsApproved(t *testing.T) {
+	polex := PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Status: PolicyExceptionStatus{
+			Approvals: []Approval{
+				{User: "alice", Generation: 2},
+				{User: "alice", Generation: 2},
+				{User: "bob", Generation: 1},
+			},
+		},
+	}
+	assert.Equal(t, polex.IsApproved(0), true)
+	assert.Equal(t, polex.IsApproved(1), true)
+	assert.Equal(t, polex.IsApproved(2), false)
+	polex.Status.Approvals = append(polex.Status.Approvals, Approval{User: "bob", Generation: 2})
+	assert.Equal(t, polex.IsApproved(2), true)
+	// approvals of the author are ignored
+	polex.Annotations = map[string]string{AnnotationPolicyExceptionAuthor: "bob"}
+	assert.Equal(t, polex.IsApproved(2), false)
+	assert.Equal(t, polex.IsApproved(1), true)
+}
+
+func Test_PolicyExceptionStatus_RecordMatches(t *testing.T) {
+	now := time.Now()
+	var status PolicyExceptionStatus
+	status.RecordMatches(2, now)
+	assert.Equal(t, status.MatchCount, int64(2))
+	assert.Equal(t, status.LastMatchTime.Time, now)
+	// older matches don't move the last match time backwards
+	status.RecordMatches(3, now.Add(-time.Hour))
+	assert.Equal(t, status.MatchCount, int64(5))
+	assert.Equal(t, status.LastMatchTime.Time, now)
+}
+
+func Test_PolicyException_IsStale(t *testing.T) {
+	now := time.Now()
+	polex := PolicyException{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-48 * time.Hour))},
+	}
+	// never matched
+	assert.Equal(t, polex.IsStale(now.Add(-24*time.Hour)), true)
+	assert.Equal(t, polex.IsStale(now.Add(-72*time.Hour)), false)
+	// matched recently
+	polex.Status.LastMatchTime = &metav1.Time{Time: now.Add(-time.Hour)}
+	assert.Equal(t, polex.IsStale(now.Add(-24*time.Hour)), false)
+	assert.Equal(t, polex.IsStale(now), true)
+}
@@ -16,18 +16,48 @@ limitations under the License.
 package v2alpha1
 
 import (
+	"time"
+
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+const (
+	// PolicyExceptionConditionReady means that the policy exception is in effect
+	PolicyExceptionConditionReady = "Ready"
+)
+
+const (
+	// AnnotationPolicyExceptionAuthor records the user who created or last changed the spec of a policy exception,
+	// approvals granted by this user are not taken into account
+	AnnotationPolicyExceptionAuthor = "kyverno.io/author"
+)
+
+const (
+	// PolicyExceptionReasonActive is the reason set when the policy exception is in effect
+	PolicyExceptionReasonActive = "Active"
+	// PolicyExceptionReasonNotYetValid is the reason set when the policy exception validity window has not started yet
+	PolicyExceptionReasonNotYetValid = "NotYetValid"
+	// PolicyExceptionReasonExpired is the reason set when the policy exception validity window has ended
+	PolicyExceptionReasonExpired = "Expired"
+	// PolicyExceptionReasonPending is the reason set when the policy exception is waiting for approvals
+	PolicyExceptionReasonPending = "Pending"
+)
+
 // +genclient
 // +kubebuilder:object:root=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // +kubebuilder:resource:shortName=polex,categories=kyverno
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type == "Ready")].status`
+// +kubebuilder:printcolumn:name="Expires",type="date",JSONPath=".spec.expiresAt"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // PolicyException declares resources to be excluded from specified policies.
 type PolicyException struct {
@@ -36,6 +66,10 @@ type PolicyException struct {
 
 	// Spec declares policy exception behaviors.
 	Spec PolicyExceptionSpec `json:"spec"`
+
+	// Status contains policy exception runtime data.
+	// +optional
+	Status PolicyExceptionStatus `json:"status,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -49,11 +83,62 @@ func (p *PolicyException) Contains(policy string, rule string) bool {
 	return p.Spec.Contains(policy, rule)
 }
 
+// ImageReferences returns the image patterns the exception is scoped to for the given policy/rule pair
+func (p *PolicyException) ImageReferences(policy string, rule string) []string {
+	return p.Spec.ImageReferences(policy, rule)
+}
+
+// IsActive returns true if the exception validity window contains the given time
+func (p *PolicyException) IsActive(now time.Time) bool {
+	return p.Spec.IsActive(now)
+}
+
+// GetAuthor returns the user who created or last changed the spec of the policy exception
+func (p *PolicyException) GetAuthor() string {
+	return p.GetAnnotations()[AnnotationPolicyExceptionAuthor]
+}
+
+// IsApproved returns true if the exception has been approved by at least the given number of distinct users.
+// Only approvals recorded for the current generation of the exception by users other than its author are
+// taken into account.
+func (p *PolicyException) IsApproved(required int) bool {
+	return p.Status.CountApprovals(p.Generation, p.GetAuthor()) >= required
+}
+
+// IsStale returns true if the policy exception didn't match since the given time.
+// Exceptions that never matched are considered stale if they were created before the given time.
+func (p *PolicyException) IsStale(since time.Time) bool {
+	if p.Status.LastMatchTime != nil {
+		return p.Status.LastMatchTime.Time.Before(since)
+	}
+	return p.CreationTimestamp.Time.Before(since)
+}
+
+// GetStatus returns the policy exception status
+func (p *PolicyException) GetStatus() *PolicyExceptionStatus {
+	return &p.Status
+}
+
 // PolicyExceptionSpec stores policy exception spec
 type PolicyExceptionSpec struct {
 	// Match defines match clause used to check if a resource applies to the exception
 	Match kyvernov2beta1.MatchResources `json:"match"`
 
+	// Conditions are used to determine if a resource applies to the exception by evaluating a
+	// set of conditions against the admission request. The declaration can contain nested `any` or `all` statements.
+	// +optional
+	Conditions *kyvernov2beta1.AnyAllConditions `json:"conditions,omitempty"`
+
+	// NotBefore is the time from which the exception applies.
+	// If not set, the exception applies as soon as it is created.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// ExpiresAt is the time after which the exception no longer applies.
+	// If not set, the exception never expires.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
 	// Exceptions is a list policy/rules to be excluded
 	Exceptions []Exception `json:"exceptions"`
 }
@@ -61,6 +146,9 @@ type PolicyExceptionSpec struct {
 // Validate implements programmatic validation
 func (p *PolicyExceptionSpec) Validate(path *field.Path) (errs field.ErrorList) {
 	errs = append(errs, p.Match.Validate(path.Child("match"), false, nil)...)
+	if p.NotBefore != nil && p.ExpiresAt != nil && !p.ExpiresAt.After(p.NotBefore.Time) {
+		errs = append(errs, field.Invalid(path.Child("expiresAt"), p.ExpiresAt, "expiresAt must be after notBefore"))
+	}
 	exceptionsPath := path.Child("exceptions")
 	for i, e := range p.Exceptions {
 		errs = append(errs, e.Validate(exceptionsPath.Index(i))...)
@@ -78,6 +166,32 @@ func (p *PolicyExceptionSpec) Contains(policy string, rule string) bool {
 	return false
 }
 
+// ImageReferences returns the image patterns the exception is scoped to for the given policy/rule pair.
+// An empty result means the exception applies to the rule regardless of images.
+func (p *PolicyExceptionSpec) ImageReferences(policy string, rule string) []string {
+	var imageReferences []string
+	for _, exception := range p.Exceptions {
+		if exception.Contains(policy, rule) {
+			if len(exception.ImageReferences) == 0 {
+				return nil
+			}
+			imageReferences = append(imageReferences, exception.ImageReferences...)
+		}
+	}
+	return imageReferences
+}
+
+// IsActive returns true if the exception validity window contains the given time
+func (p *PolicyExceptionSpec) IsActive(now time.Time) bool {
+	if p.NotBefore != nil && now.Before(p.NotBefore.Time) {
+		return false
+	}
+	if p.ExpiresAt != nil && !now.Before(p.ExpiresAt.Time) {
+		return false
+	}
+	return true
+}
+
 // Exception stores infos about a policy and rules
 type Exception struct {
 	// PolicyName identifies the policy to which the exception is applied.
@@ -87,6 +201,13 @@ type Exception struct {
 
 	// RuleNames identifies the rules to which the exception is applied.
 	RuleNames []string `json:"ruleNames"`
+
+	// ImageReferences is a list of image patterns the exception is scoped to.
+	// It only applies to verifyImages rules, only images matching one of the patterns
+	// are excluded from verification. Wildcards ('*' and '?') are allowed.
+	// If not set, the exception applies to all images.
+	// +optional
+	ImageReferences []string `json:"imageReferences,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -94,6 +215,11 @@ func (p *Exception) Validate(path *field.Path) (errs field.ErrorList) {
 	if p.PolicyName == "" {
 		errs = append(errs, field.Required(path.Child("policyName"), "An exception requires a policy name"))
 	}
+	for i, imageReference := range p.ImageReferences {
+		if imageReference == "" {
+			errs = append(errs, field.Invalid(path.Child("imageReferences").Index(i), imageReference, "An image reference cannot be empty"))
+		}
+	}
 	return errs
 }
 
@@ -102,6 +228,82 @@ func (p *Exception) Contains(policy string, rule string) bool {
 	return p.PolicyName == policy && slices.Contains(p.RuleNames, rule)
 }
 
+// PolicyExceptionStatus stores the status of the policy exception.
+type PolicyExceptionStatus struct {
+	// Conditions is a list of conditions that apply to the policy exception
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// Approvals is the list of approvals granted to the policy exception.
+	// Approvals are recorded through the status subresource and are bound to the
+	// generation of the exception they were granted for.
+	// +optional
+	Approvals []Approval `json:"approvals,omitempty"`
+
+	// LastMatchTime is the last time the policy exception caused a policy rule to be skipped.
+	// +optional
+	LastMatchTime *metav1.Time `json:"lastMatchTime,omitempty"`
+
+	// MatchCount is the number of times the policy exception caused a policy rule to be skipped for a resource.
+	// +optional
+	MatchCount int64 `json:"matchCount,omitempty"`
+}
+
+// RecordMatches adds the given number of matches to the match count of the policy exception.
+// The last match time is only updated if the given time is more recent than the recorded one.
+func (status *PolicyExceptionStatus) RecordMatches(matches int64, lastMatchTime time.Time) {
+	status.MatchCount += matches
+	if status.LastMatchTime == nil || status.LastMatchTime.Time.Before(lastMatchTime) {
+		status.LastMatchTime = &metav1.Time{Time: lastMatchTime}
+	}
+}
+
+// CountApprovals returns the number of distinct users who approved the given generation, approvals granted by
+// the author of the generation are ignored
+func (status *PolicyExceptionStatus) CountApprovals(generation int64, author string) int {
+	users := sets.NewString()
+	for _, approval := range status.Approvals {
+		if approval.Generation == generation && (author == "" || approval.User != author) {
+			users.Insert(approval.User)
+		}
+	}
+	return users.Len()
+}
+
+// SetReady sets the ready condition of the policy exception
+func (status *PolicyExceptionStatus) SetReady(ready bool, reason string, message string) {
+	condition := metav1.Condition{
+		Type:    PolicyExceptionConditionReady,
+		Reason:  reason,
+		Message: message,
+	}
+	if ready {
+		condition.Status = metav1.ConditionTrue
+	} else {
+		condition.Status = metav1.ConditionFalse
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
+
+// IsReady indicates if the policy exception is in effect
+func (status *PolicyExceptionStatus) IsReady() bool {
+	condition := meta.FindStatusCondition(status.Conditions, PolicyExceptionConditionReady)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+// Approval stores infos about a policy exception approval
+type Approval struct {
+	// User is the name of the user who granted the approval.
+	User string `json:"user"`
+
+	// Generation is the generation of the policy exception the approval was granted for.
+	Generation int64 `json:"generation"`
+
+	// Time is the time the approval was granted.
+	// +optional
+	Time metav1.Time `json:"time,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -40,7 +40,7 @@ type Validation struct {
 }
 
 // ConditionOperator is the operation performed on condition key and value.
-// +kubebuilder:validation:Enum=Equals;NotEquals;AnyIn;AllIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan
+// +kubebuilder:validation:Enum=Equals;NotEquals;AnyIn;AllIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan;CIDRContains;AnyCIDROverlaps
 type ConditionOperator string
 
 // ConditionOperators stores all the valid ConditionOperator types as key-value pairs.
@@ -58,6 +58,8 @@ type ConditionOperator string
 // "DurationGreaterThan" evaluates if the key (duration) is greater than the value (duration)
 // "DurationLessThanOrEquals" evaluates if the key (duration) is less than or equal to the value (duration)
 // "DurationLessThan" evaluates if the key (duration) is greater than the value (duration)
+// "CIDRContains" evaluates if the key (CIDRs) contains all the values (IP addresses or CIDRs)
+// "AnyCIDROverlaps" evaluates if any of the keys (CIDRs) overlaps any of the values (CIDRs)
 var ConditionOperators = map[string]ConditionOperator{
 	"Equals":                      ConditionOperator("Equals"),
 	"NotEquals":                   ConditionOperator("NotEquals"),
@@ -73,6 +75,8 @@ var ConditionOperators = map[string]ConditionOperator{
 	"DurationGreaterThan":         ConditionOperator("DurationGreaterThan"),
 	"DurationLessThanOrEquals":    ConditionOperator("DurationLessThanOrEquals"),
 	"DurationLessThan":            ConditionOperator("DurationLessThan"),
+	"CIDRContains":                ConditionOperator("CIDRContains"),
+	"AnyCIDROverlaps":             ConditionOperator("AnyCIDROverlaps"),
 }
 
 // Deny specifies a list of conditions used to pass or fail a validation rule.
@@ -90,7 +94,7 @@ type Condition struct {
 	// Operator is the conditional operation to perform. Valid operators are:
 	// Equals, NotEquals, In, AnyIn, AllIn, NotIn, AnyNotIn, AllNotIn, GreaterThanOrEquals,
 	// GreaterThan, LessThanOrEquals, LessThan, DurationGreaterThanOrEquals, DurationGreaterThan,
-	// DurationLessThanOrEquals, DurationLessThan
+	// DurationLessThanOrEquals, DurationLessThan, CIDRContains, AnyCIDROverlaps
 	Operator ConditionOperator `json:"operator,omitempty" yaml:"operator,omitempty"`
 
 	// Value is the conditional value, or set of values. The values can be fixed set
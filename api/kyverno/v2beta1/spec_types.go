@@ -68,6 +68,18 @@ type Spec struct {
 	// Defaults to "false" if not specified.
 	// +optional
 	GenerateExistingOnPolicyUpdate bool `json:"generateExistingOnPolicyUpdate,omitempty" yaml:"generateExistingOnPolicyUpdate,omitempty"`
+
+	// ShadowOf makes the policy a shadow of the policy with the given name, in the same namespace for a Policy.
+	// A shadow policy is evaluated alongside the live policy on every admission request but never blocks,
+	// disagreements between the shadow and live policies are recorded in metrics and reports.
+	// Shadow policies can only contain validate rules.
+	// +optional
+	ShadowOf string `json:"shadowOf,omitempty" yaml:"shadowOf,omitempty"`
+
+	// Functions are JMESPath functions defined by the policy, they can be called from any variable
+	// substitution of the policy rules and from the other functions of the policy.
+	// +optional
+	Functions []kyvernov1.Function `json:"functions,omitempty" yaml:"functions,omitempty"`
 }
 
 func (s *Spec) SetRules(rules []Rule) {
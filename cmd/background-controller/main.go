@@ -70,7 +70,7 @@ func createNonLeaderControllers(
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	kyvernoClient versioned.Interface,
 	dynamicClient dclient.Interface,
-	rclient registryclient.Client,
+	engine engineapi.Engine,
 	configuration config.Configuration,
 	eventGenerator event.Interface,
 	informerCacheResolvers engineapi.ConfigmapResolver,
@@ -78,7 +78,7 @@ func createNonLeaderControllers(
 	updateRequestController := background.NewController(
 		kyvernoClient,
 		dynamicClient,
-		engine.LegacyContextLoaderFactory(rclient),
+		engine,
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
 		kyvernoInformer.Kyverno().V1beta1().UpdateRequests(),
@@ -96,7 +96,7 @@ func createrLeaderControllers(
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	kyvernoClient versioned.Interface,
 	dynamicClient dclient.Interface,
-	rclient registryclient.Client,
+	engine engineapi.Engine,
 	configuration config.Configuration,
 	metricsConfig metrics.MetricsConfigManager,
 	eventGenerator event.Interface,
@@ -105,7 +105,7 @@ func createrLeaderControllers(
 	policyCtrl, err := policy.NewPolicyController(
 		kyvernoClient,
 		dynamicClient,
-		engine.LegacyContextLoaderFactory(rclient),
+		engine,
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
 		kyvernoInformer.Kyverno().V1beta1().UpdateRequests(),
@@ -127,12 +127,15 @@ func createrLeaderControllers(
 
 func main() {
 	var (
-		genWorkers                int
-		maxQueuedEvents           int
-		imagePullSecrets          string
-		imageSignatureRepository  string
-		allowInsecureRegistry     bool
-		leaderElectionRetryPeriod time.Duration
+		genWorkers                 int
+		maxQueuedEvents            int
+		imagePullSecrets           string
+		imageSignatureRepository   string
+		allowInsecureRegistry      bool
+		leaderElectionRetryPeriod  time.Duration
+		enablePolicyException      bool
+		exceptionNamespace         string
+		exceptionRequiredApprovals int
 	)
 	flagset := flag.NewFlagSet("updaterequest-controller", flag.ExitOnError)
 	flagset.IntVar(&genWorkers, "genWorkers", 10, "Workers for generate controller.")
@@ -141,12 +144,16 @@ func main() {
 	flagset.BoolVar(&allowInsecureRegistry, "allowInsecureRegistry", false, "Whether to allow insecure connections to registries. Don't use this for anything but testing.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
+	flagset.BoolVar(&enablePolicyException, "enablePolicyException", false, "Enable PolicyException feature.")
+	flagset.StringVar(&exceptionNamespace, "exceptionNamespace", "", "Configure the namespace to accept PolicyExceptions.")
+	flagset.IntVar(&exceptionRequiredApprovals, "exceptionRequiredApprovals", 0, "Number of approvals required for a PolicyException to take effect, approvals are not required if 0.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
 		internal.WithMetrics(),
 		internal.WithTracing(),
 		internal.WithKubeconfig(),
+		internal.WithLookupFunctions(),
 		internal.WithFlagSets(flagset),
 	)
 	// parse flags
@@ -206,6 +213,7 @@ func main() {
 		logger.Error(err, "failed to create config map resolver")
 		os.Exit(1)
 	}
+	resourceLister := internal.CreateResourceLister(signalCtx, logger, dClient, resyncPeriod)
 	configuration, err := config.NewConfiguration(kubeClient)
 	if err != nil {
 		logger.Error(err, "failed to initialize configuration")
@@ -224,6 +232,24 @@ func main() {
 		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 		kyvernoInformer.Kyverno().V1().Policies(),
 	)
+	var exceptionsLister engine.PolicyExceptionLister
+	if enablePolicyException {
+		lister := kyvernoInformer.Kyverno().V2alpha1().PolicyExceptions().Lister()
+		if exceptionNamespace != "" {
+			exceptionsLister = lister.PolicyExceptions(exceptionNamespace)
+		} else {
+			exceptionsLister = lister
+		}
+		exceptionsLister = engine.ApprovedExceptions(exceptionsLister, exceptionRequiredApprovals)
+	}
+	// create engine
+	eng := engine.NewEngine(
+		configuration,
+		rclient,
+		engine.LegacyContextLoaderFactory(rclient),
+		engine.NewExceptionSelector(exceptionsLister),
+		resourceLister,
+	)
 	// create non leader controllers
 	nonLeaderControllers := createNonLeaderControllers(
 		genWorkers,
@@ -232,7 +258,7 @@ func main() {
 		kyvernoInformer,
 		kyvernoClient,
 		dClient,
-		rclient,
+		eng,
 		configuration,
 		eventGenerator,
 		configMapResolver,
@@ -263,7 +289,7 @@ func main() {
 				kyvernoInformer,
 				kyvernoClient,
 				dClient,
-				rclient,
+				eng,
 				configuration,
 				metricsConfig,
 				eventGenerator,
@@ -0,0 +1,27 @@
+package exception
+
+import (
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception/stale"
+	"github.com/spf13/cobra"
+)
+
+var description = []string{
+	"Provides commands to inspect policy exceptions in a cluster.",
+	"For more information visit: https://kyverno.io/docs/writing-policies/exceptions/.",
+}
+
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "exception",
+		Aliases: []string{"exceptions", "polex"},
+		Short:   description[0],
+		Long:    strings.Join(description, "\n"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(stale.Command())
+	return cmd
+}
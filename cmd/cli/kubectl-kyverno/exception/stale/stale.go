@@ -0,0 +1,105 @@
+package stale
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+var description = []string{
+	"Lists policy exceptions that did not cause any policy rule to be skipped in the given number of days.",
+	"Usage is recorded in the policy exceptions status by the admission and reports controllers.",
+}
+
+var examples = []string{
+	"  # List exceptions that didn't match in the last 30 days\n  kyverno exception stale",
+	"  # List exceptions that didn't match in the last 7 days in a namespace\n  kyverno exception stale --days 7 --namespace kyverno",
+}
+
+type options struct {
+	kubeConfig string
+	context    string
+	namespace  string
+	days       int
+}
+
+func Command() *cobra.Command {
+	var opts options
+	cmd := &cobra.Command{
+		Use:          "stale",
+		Short:        description[0],
+		Long:         strings.Join(description, "\n"),
+		Example:      strings.Join(examples, "\n\n"),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.Context(), os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&opts.kubeConfig, "kubeconfig", "", "path to kubeconfig file with authorization and master location information")
+	cmd.Flags().StringVar(&opts.context, "context", "", "The name of the kubeconfig context to use")
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "", "Namespace to look for policy exceptions in, all namespaces if empty")
+	cmd.Flags().IntVar(&opts.days, "days", 30, "Number of days without match after which an exception is considered stale")
+	return cmd
+}
+
+func (o options) run(ctx context.Context, out io.Writer) error {
+	if o.days < 0 {
+		return fmt.Errorf("days must be positive, got %d", o.days)
+	}
+	restConfig, err := config.CreateClientConfigWithContext(o.kubeConfig, o.context)
+	if err != nil {
+		return err
+	}
+	client, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, err := client.KyvernoV2alpha1().PolicyExceptions(o.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	return printStale(out, staleExceptions(list.Items, now.AddDate(0, 0, -o.days)), now)
+}
+
+func staleExceptions(polexs []kyvernov2alpha1.PolicyException, since time.Time) []kyvernov2alpha1.PolicyException {
+	var stale []kyvernov2alpha1.PolicyException
+	for _, polex := range polexs {
+		if polex.IsStale(since) {
+			stale = append(stale, polex)
+		}
+	}
+	return stale
+}
+
+func printStale(out io.Writer, polexs []kyvernov2alpha1.PolicyException, now time.Time) error {
+	if len(polexs) == 0 {
+		_, err := fmt.Fprintln(out, "No stale policy exceptions found.")
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tLAST MATCH\tMATCHES\tAGE")
+	for _, polex := range polexs {
+		lastMatch := "<never>"
+		if polex.Status.LastMatchTime != nil {
+			lastMatch = duration.HumanDuration(now.Sub(polex.Status.LastMatchTime.Time))
+		}
+		age := duration.HumanDuration(now.Sub(polex.CreationTimestamp.Time))
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", polex.Namespace, polex.Name, lastMatch, polex.Status.MatchCount, age)
+	}
+	return w.Flush()
+}
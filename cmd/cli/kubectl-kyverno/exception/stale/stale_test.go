@@ -0,0 +1,45 @@
+package stale
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_staleExceptions(t *testing.T) {
+	now := time.Now()
+	newPolex := func(name string, age time.Duration, lastMatch *time.Duration, matches int64) kyvernov2alpha1.PolicyException {
+		polex := kyvernov2alpha1.PolicyException{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(now.Add(-age))},
+		}
+		if lastMatch != nil {
+			polex.Status.LastMatchTime = &metav1.Time{Time: now.Add(-*lastMatch)}
+			polex.Status.MatchCount = matches
+		}
+		return polex
+	}
+	day := 24 * time.Hour
+	old := 40 * day
+	recent := time.Hour
+	polexs := []kyvernov2alpha1.PolicyException{
+		newPolex("never-matched", 60*day, nil, 0),
+		newPolex("new", day, nil, 0),
+		newPolex("matched-recently", 60*day, &recent, 10),
+		newPolex("matched-long-ago", 60*day, &old, 2),
+	}
+	stale := staleExceptions(polexs, now.AddDate(0, 0, -30))
+	assert.Equal(t, len(stale), 2)
+	assert.Equal(t, stale[0].Name, "never-matched")
+	assert.Equal(t, stale[1].Name, "matched-long-ago")
+	var out bytes.Buffer
+	assert.NilError(t, printStale(&out, stale, now))
+	assert.Equal(t, out.String(), ""+
+		"NAMESPACE   NAME               LAST MATCH   MATCHES   AGE\n"+
+		"default     never-matched      <never>      0         60d\n"+
+		"default     matched-long-ago   40d          2         60d\n",
+	)
+}
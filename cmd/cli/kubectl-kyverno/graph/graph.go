@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	policygraph "github.com/kyverno/kyverno/pkg/policy/graph"
+	"github.com/spf13/cobra"
+)
+
+var description = []string{
+	"Builds the dependency graph of policies with a static analysis of their rules.",
+	"The graph links policies to the kinds, namespaces and selectors they match, the ConfigMaps, API calls and image registries read by their context entries and image verification rules, and the kinds of the resources they generate.",
+	"Rules generated for pod controllers are included. Values depending on variables are not resolved, they match any value in queries.",
+}
+
+var examples = []string{
+	"  # Print the graph of the policies of a directory in the DOT language\n  kyverno graph ./policies | dot -Tsvg > policies.svg",
+	"  # Print the graph in JSON\n  kyverno graph ./policies -o json",
+	"  # List the rules reading a ConfigMap\n  kyverno graph ./policies --configmap kyverno/allowed-registries",
+	"  # List the rules that would apply to a Job in a namespace\n  kyverno graph ./policies --kind Job --namespace team-a",
+}
+
+type options struct {
+	output    string
+	configMap string
+	apiCall   string
+	registry  string
+	generates string
+	kind      string
+	namespace string
+}
+
+func Command() *cobra.Command {
+	var opts options
+	cmd := &cobra.Command{
+		Use:          "graph <policy path>...",
+		Short:        description[0],
+		Long:         strings.Join(description, "\n"),
+		Example:      strings.Join(examples, "\n\n"),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.OutOrStdout(), args...)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Output format, one of dot or json, defaults to dot for graphs and to text for queries")
+	cmd.Flags().StringVar(&opts.configMap, "configmap", "", "List the rules reading a ConfigMap given as namespace/name")
+	cmd.Flags().StringVar(&opts.apiCall, "apicall", "", "List the rules calling an API path")
+	cmd.Flags().StringVar(&opts.registry, "registry", "", "List the rules reading images from a registry")
+	cmd.Flags().StringVar(&opts.generates, "generates", "", "List the rules generating resources of a kind")
+	cmd.Flags().StringVar(&opts.kind, "kind", "", "List the rules matching resources of a kind, use --namespace for namespaced resources")
+	cmd.Flags().StringVar(&opts.namespace, "namespace", "", "Namespace of the resources queried with --kind")
+	return cmd
+}
+
+func (o options) run(out io.Writer, paths ...string) error {
+	queries := 0
+	for _, query := range []string{o.configMap, o.apiCall, o.registry, o.generates, o.kind} {
+		if query != "" {
+			queries++
+		}
+	}
+	if queries > 1 {
+		return errors.New("only one of --configmap, --apicall, --registry, --generates and --kind can be used at a time")
+	}
+	if o.namespace != "" && o.kind == "" {
+		return errors.New("--namespace can only be used with --kind")
+	}
+	switch o.output {
+	case "", "json":
+	case "dot":
+		if queries > 0 {
+			return errors.New("query results can not be printed in the DOT language")
+		}
+	default:
+		return fmt.Errorf("invalid output format %s, expected one of dot or json", o.output)
+	}
+	policies, err := common.GetPoliciesFromPaths(nil, paths, false, "")
+	if err != nil {
+		return err
+	}
+	if o.kind != "" {
+		return printRules(out, o.output, policygraph.MatchingRules(o.kind, o.namespace, policies...))
+	}
+	g := policygraph.New(policies...)
+	var edges []policygraph.Edge
+	switch {
+	case o.configMap != "":
+		edges = g.Lookup(policygraph.NodeConfigMap, o.configMap)
+	case o.apiCall != "":
+		edges = g.Lookup(policygraph.NodeAPICall, o.apiCall)
+	case o.registry != "":
+		edges = g.Lookup(policygraph.NodeRegistry, o.registry)
+	case o.generates != "":
+		edges = g.Lookup(policygraph.NodeKind, o.generates, policygraph.RelationGenerates)
+	default:
+		if o.output == "json" {
+			return printJSON(out, g)
+		}
+		return g.WriteDOT(out)
+	}
+	var rules []policygraph.RuleRef
+	for _, edge := range edges {
+		rules = append(rules, policygraph.RuleRef{Policy: strings.TrimPrefix(edge.From, string(policygraph.NodePolicy)+":"), Rule: edge.Rule})
+	}
+	return printRules(out, o.output, rules)
+}
+
+func printRules(out io.Writer, output string, rules []policygraph.RuleRef) error {
+	if output == "json" {
+		if rules == nil {
+			rules = []policygraph.RuleRef{}
+		}
+		return printJSON(out, rules)
+	}
+	for _, rule := range rules {
+		if _, err := fmt.Fprintf(out, "%s %s\n", rule.Policy, rule.Rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printJSON(out io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
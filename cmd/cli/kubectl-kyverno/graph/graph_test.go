@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const policy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: allowed-registries
+spec:
+  rules:
+  - name: check-registries
+    match:
+      any:
+      - resources:
+          kinds: [Pod]
+    context:
+    - name: settings
+      configMap:
+        name: registries
+        namespace: kyverno
+    validate:
+      deny: {}
+`
+
+func Test_Run(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	assert.NilError(t, os.WriteFile(path, []byte(policy), 0o600))
+	testCases := []struct {
+		name     string
+		opts     options
+		expected string
+		err      string
+	}{
+		{
+			name:     "configmap",
+			opts:     options{configMap: "kyverno/registries"},
+			expected: "allowed-registries autogen-check-registries\nallowed-registries autogen-cronjob-check-registries\nallowed-registries check-registries\n",
+		},
+		{
+			name:     "configmap json",
+			opts:     options{configMap: "kyverno/other", output: "json"},
+			expected: "[]\n",
+		},
+		{
+			name:     "kind",
+			opts:     options{kind: "Deployment", namespace: "default"},
+			expected: "allowed-registries autogen-check-registries\n",
+		},
+		{
+			name: "multiple queries",
+			opts: options{kind: "Pod", registry: "ghcr.io"},
+			err:  "only one of --configmap, --apicall, --registry, --generates and --kind can be used at a time",
+		},
+		{
+			name: "namespace without kind",
+			opts: options{namespace: "default"},
+			err:  "--namespace can only be used with --kind",
+		},
+		{
+			name: "query in dot",
+			opts: options{registry: "ghcr.io", output: "dot"},
+			err:  "query results can not be printed in the DOT language",
+		},
+		{
+			name: "invalid output",
+			opts: options{output: "yaml"},
+			err:  "invalid output format yaml, expected one of dot or json",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := tc.opts.run(&out, path)
+			if tc.err != "" {
+				assert.Error(t, err, tc.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, out.String(), tc.expected)
+		})
+	}
+	var out bytes.Buffer
+	assert.NilError(t, options{}.run(&out, path))
+	assert.Assert(t, strings.Contains(out.String(), `"policy:allowed-registries" -> "configmap:kyverno/registries" [label="reads (check-registries)"];`))
+}
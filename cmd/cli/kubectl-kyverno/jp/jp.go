@@ -6,6 +6,7 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/jp/function"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/jp/parse"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/jp/query"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/jp/repl"
 	"github.com/spf13/cobra"
 )
 
@@ -26,5 +27,6 @@ func Command() *cobra.Command {
 	cmd.AddCommand(query.Command())
 	cmd.AddCommand(function.Command())
 	cmd.AddCommand(parse.Command())
+	cmd.AddCommand(repl.Command())
 	return cmd
 }
@@ -0,0 +1,358 @@
+package repl
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/pkg/config"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+var description = []string{
+	"Starts an interactive session to evaluate JMESPath expressions and variable substitutions against a simulated admission context",
+	"Lines containing {{ }} are substituted the same way variables are substituted in policies, other lines are evaluated as JMESPath expressions.",
+	"Function names are completed with the Tab key.",
+	"For more information visit: https://kyverno.io/docs/writing-policies/jmespath/ ",
+}
+
+var examples = []string{
+	"  # Start a session with a resource                  \n  kyverno jp repl -r pod.yaml",
+	"  # Simulate an update by a given user               \n  kyverno jp repl -r pod.yaml --old-resource old-pod.yaml --operation UPDATE --username alice --groups dev",
+	"  # Load a ConfigMap as the context entry 'settings' \n  kyverno jp repl -r pod.yaml --configmap settings=configmap.yaml",
+	"  # Load variables from a values file                \n  kyverno jp repl -r pod.yaml -f values.yaml",
+	"  # Evaluate expressions from a file                 \n  cat expressions | kyverno jp repl -r pod.yaml",
+}
+
+const prompt = "kyverno> "
+
+const help = `Enter a JMESPath expression (e.g. request.object.metadata.name) or a text with variables
+(e.g. {{ request.object.metadata.name }}-{{ request.operation }}) to evaluate it.
+
+Commands:
+  :context    prints the context
+  :functions  lists the available functions
+  :help       prints this help
+  :quit       ends the session
+`
+
+type options struct {
+	resource     string
+	oldResource  string
+	operation    string
+	username     string
+	groups       []string
+	roles        []string
+	clusterRoles []string
+	configMaps   []string
+	valuesFile   string
+}
+
+// Command returns jp repl command
+func Command() *cobra.Command {
+	var opts options
+	cmd := &cobra.Command{
+		Use:          "repl [-r resource] [-f values] [--configmap name=file]...",
+		Short:        description[0],
+		Long:         strings.Join(description, "\n"),
+		Example:      strings.Join(examples, "\n\n"),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := newContext(opts)
+			if err != nil {
+				return err
+			}
+			if file, ok := cmd.InOrStdin().(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+				return runTerminal(ctx, file, cmd.OutOrStdout())
+			}
+			return run(ctx, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVarP(&opts.resource, "resource", "r", "", "Path to the resource loaded in request.object")
+	cmd.Flags().StringVar(&opts.oldResource, "old-resource", "", "Path to the resource loaded in request.oldObject")
+	cmd.Flags().StringVarP(&opts.operation, "operation", "o", "CREATE", "Operation of the admission request (CREATE, UPDATE, DELETE or CONNECT)")
+	cmd.Flags().StringVar(&opts.username, "username", "", "Name of the user sending the admission request")
+	cmd.Flags().StringSliceVar(&opts.groups, "groups", nil, "Groups of the user sending the admission request")
+	cmd.Flags().StringSliceVar(&opts.roles, "roles", nil, "Roles bound to the user sending the admission request (namespace:name)")
+	cmd.Flags().StringSliceVar(&opts.clusterRoles, "cluster-roles", nil, "Cluster roles bound to the user sending the admission request")
+	cmd.Flags().StringArrayVar(&opts.configMaps, "configmap", nil, "ConfigMap context entry given as name=file, the ConfigMap is loaded the same way configMap context entries are loaded")
+	cmd.Flags().StringVarP(&opts.valuesFile, "values-file", "f", "", "Path to a values file, global values are loaded as variables")
+	return cmd
+}
+
+func newContext(opts options) (enginecontext.Interface, error) {
+	ctx := enginecontext.NewContext()
+	if opts.resource != "" {
+		resource, err := loadResource(opts.resource)
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.AddResource(resource.Object); err != nil {
+			return nil, err
+		}
+		if err := ctx.AddNamespace(resource.GetNamespace()); err != nil {
+			return nil, err
+		}
+		if err := ctx.AddImageInfos(resource, config.NewDefaultConfiguration()); err != nil {
+			return nil, fmt.Errorf("failed to load images of %s: %w", opts.resource, err)
+		}
+	}
+	if opts.oldResource != "" {
+		resource, err := loadResource(opts.oldResource)
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.AddOldResource(resource.Object); err != nil {
+			return nil, err
+		}
+	}
+	if err := ctx.AddOperation(opts.operation); err != nil {
+		return nil, err
+	}
+	userInfo := kyvernov1beta1.RequestInfo{
+		Roles:        opts.roles,
+		ClusterRoles: opts.clusterRoles,
+		AdmissionUserInfo: authenticationv1.UserInfo{
+			Username: opts.username,
+			Groups:   opts.groups,
+		},
+	}
+	if err := ctx.AddUserInfo(userInfo); err != nil {
+		return nil, err
+	}
+	if err := ctx.AddServiceAccount(opts.username); err != nil {
+		return nil, err
+	}
+	for _, configMap := range opts.configMaps {
+		name, file, ok := strings.Cut(configMap, "=")
+		if !ok || name == "" || file == "" {
+			return nil, fmt.Errorf("invalid configmap %q, expected name=file", configMap)
+		}
+		data, err := loadConfigMap(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.AddContextEntry(name, data); err != nil {
+			return nil, fmt.Errorf("failed to add context entry %s: %w", name, err)
+		}
+	}
+	if opts.valuesFile != "" {
+		values, err := loadValues(opts.valuesFile)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range values.GlobalValues {
+			if err := ctx.AddVariable(key, value); err != nil {
+				return nil, fmt.Errorf("failed to add variable %s: %w", key, err)
+			}
+		}
+	}
+	return ctx, nil
+}
+
+func loadFile(file string) ([]byte, error) {
+	// We accept the risk of including a user provided file here.
+	data, err := os.ReadFile(filepath.Clean(file)) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %v", file, err)
+	}
+	return data, nil
+}
+
+func loadResource(file string) (*unstructured.Unstructured, error) {
+	data, err := loadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := yaml.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("failed to parse resource %s: %v", file, err)
+	}
+	return &unstructured.Unstructured{Object: object}, nil
+}
+
+// loadConfigMap returns the context entry data of a ConfigMap, it holds the data and the metadata of the ConfigMap
+func loadConfigMap(file string) ([]byte, error) {
+	data, err := loadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var configMap corev1.ConfigMap
+	if err := yaml.Unmarshal(data, &configMap); err != nil {
+		return nil, fmt.Errorf("failed to parse configmap %s: %v", file, err)
+	}
+	return json.Marshal(map[string]interface{}{
+		"data":     configMap.Data,
+		"metadata": configMap.ObjectMeta,
+	})
+}
+
+func loadValues(file string) (*common.Values, error) {
+	data, err := loadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var values common.Values
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse values file %s: %v", file, err)
+	}
+	return &values, nil
+}
+
+func runTerminal(ctx enginecontext.Interface, in *os.File, out io.Writer) error {
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return run(ctx, in, out)
+	}
+	defer func() {
+		if err := term.Restore(int(in.Fd()), state); err != nil {
+			fmt.Printf("Error restoring terminal: %s\n", err)
+		}
+	}()
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{in, out}, prompt)
+	terminal.AutoCompleteCallback = complete
+	fmt.Fprintln(terminal, "Type :help for help, :quit or Ctrl+D to exit.")
+	for {
+		line, err := terminal.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if !evaluate(ctx, terminal, line) {
+			return nil
+		}
+	}
+}
+
+func run(ctx enginecontext.Interface, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if !evaluate(ctx, out, scanner.Text()) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// evaluate evaluates a line and prints the result, it returns false when the session ends
+func evaluate(ctx enginecontext.Interface, out io.Writer, line string) bool {
+	line = strings.TrimSpace(line)
+	switch line {
+	case "":
+		return true
+	case ":quit", ":q", "exit":
+		return false
+	case ":help":
+		fmt.Fprint(out, help)
+		return true
+	case ":functions":
+		for _, name := range functionNames() {
+			fmt.Fprintln(out, name)
+		}
+		return true
+	case ":context":
+		raw, err := ctx.Query("@")
+		printResult(out, raw, err)
+		return true
+	}
+	if strings.HasPrefix(line, ":") {
+		fmt.Fprintf(out, "Error: unknown command %s, type :help for help\n", line)
+		return true
+	}
+	var result interface{}
+	var err error
+	if variables.RegexVariables.MatchString(line) {
+		result, err = variables.SubstituteAll(log.Log, ctx, line)
+	} else {
+		result, err = ctx.Query(line)
+	}
+	printResult(out, result, err)
+	return true
+}
+
+func printResult(out io.Writer, result interface{}, err error) {
+	if err != nil {
+		fmt.Fprintf(out, "Error: %s\n", err)
+		return
+	}
+	if str, ok := result.(string); ok {
+		fmt.Fprintln(out, str)
+		return
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		fmt.Fprintf(out, "Error: %s\n", err)
+		return
+	}
+	fmt.Fprintln(out, string(data))
+}
+
+func functionNames() []string {
+	names := jmespath.FunctionNames()
+	sort.Strings(names)
+	return names
+}
+
+// complete completes the function name being typed when the Tab key is pressed, the name is completed
+// up to the longest prefix shared by the matching functions
+func complete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+	start := pos
+	for start > 0 && isIdentifierChar(line[start-1]) {
+		start--
+	}
+	prefix := line[start:pos]
+	if prefix == "" {
+		return "", 0, false
+	}
+	var matches []string
+	for _, name := range functionNames() {
+		if strings.HasPrefix(name, prefix) {
+			matches = append(matches, name)
+		}
+	}
+	if len(matches) == 0 {
+		return "", 0, false
+	}
+	completion := matches[0]
+	for _, match := range matches[1:] {
+		for !strings.HasPrefix(match, completion) {
+			completion = completion[:len(completion)-1]
+		}
+	}
+	if len(matches) == 1 {
+		completion += "("
+	}
+	if completion == prefix {
+		return "", 0, false
+	}
+	return line[:start] + completion + line[pos:], start + len(completion), true
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
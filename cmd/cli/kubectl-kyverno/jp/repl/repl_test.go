@@ -0,0 +1,116 @@
+package repl
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const pod = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  namespace: apps
+  labels:
+    app: web
+spec:
+  containers:
+  - name: nginx
+    image: ghcr.io/kyverno/nginx:1.23
+`
+
+const configMap = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: kyverno
+data:
+  allowedRegistry: ghcr.io
+`
+
+const values = `
+globalValues:
+  team: platform
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	assert.NilError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func Test_Run(t *testing.T) {
+	dir := t.TempDir()
+	opts := options{
+		resource:   writeFile(t, dir, "pod.yaml", pod),
+		operation:  "UPDATE",
+		username:   "system:serviceaccount:apps:deployer",
+		groups:     []string{"system:serviceaccounts"},
+		configMaps: []string{"settings=" + writeFile(t, dir, "configmap.yaml", configMap)},
+		valuesFile: writeFile(t, dir, "values.yaml", values),
+	}
+	ctx, err := newContext(opts)
+	assert.NilError(t, err)
+	input := strings.Join([]string{
+		"request.object.metadata.name",
+		"{{ request.operation }} by {{ request.userInfo.username }}",
+		"{{ serviceAccountName }}/{{ request.namespace }}",
+		"images.containers.nginx.registry == settings.data.allowedRegistry",
+		"{{ team }}",
+		"length(request.object.spec.containers)",
+		"unknown_function(@)",
+		":unknown",
+		":quit",
+		"request.object.kind",
+	}, "\n")
+	var out bytes.Buffer
+	assert.NilError(t, run(ctx, strings.NewReader(input), &out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, len(lines), 8)
+	assert.Equal(t, lines[0], "web")
+	assert.Equal(t, lines[1], "UPDATE by system:serviceaccount:apps:deployer")
+	assert.Equal(t, lines[2], "deployer/apps")
+	assert.Equal(t, lines[3], "true")
+	assert.Equal(t, lines[4], "platform")
+	assert.Equal(t, lines[5], "1")
+	assert.Assert(t, strings.HasPrefix(lines[6], "Error: "))
+	assert.Assert(t, strings.HasPrefix(lines[7], "Error: unknown command"))
+}
+
+func Test_NewContextErrors(t *testing.T) {
+	_, err := newContext(options{configMaps: []string{"settings"}})
+	assert.ErrorContains(t, err, "expected name=file")
+	_, err = newContext(options{resource: filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.ErrorContains(t, err, "failed to read file")
+}
+
+func Test_Complete(t *testing.T) {
+	testCases := []struct {
+		line     string
+		pos      int
+		key      rune
+		expected string
+		ok       bool
+	}{
+		{line: "to_up", pos: 5, key: '\t', expected: "to_upper(", ok: true},
+		{line: "base64_e", pos: 8, key: '\t', expected: "base64_encode(", ok: true},
+		{line: "time_", pos: 5, key: '\t', expected: "", ok: false},
+		{line: "time_n", pos: 6, key: '\t', expected: "time_now", ok: true},
+		{line: "length(to_l) == `1`", pos: 11, key: '\t', expected: "length(to_lower() == `1`", ok: true},
+		{line: "foo", pos: 3, key: '\t', expected: "", ok: false},
+		{line: "to_up", pos: 5, key: 'a', expected: "", ok: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.line, func(t *testing.T) {
+			line, _, ok := complete(tc.line, tc.pos, tc.key)
+			assert.Equal(t, ok, tc.ok)
+			assert.Equal(t, line, tc.expected)
+		})
+	}
+}
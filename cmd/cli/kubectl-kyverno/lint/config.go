@@ -0,0 +1,75 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+// config configures the lint rules, rules are enabled with their default severity unless configured otherwise
+type config struct {
+	Rules map[string]ruleConfig `json:"rules,omitempty"`
+}
+
+type ruleConfig struct {
+	Enabled  *bool    `json:"enabled,omitempty"`
+	Severity severity `json:"severity,omitempty"`
+}
+
+func loadConfig(file string) (*config, error) {
+	var cfg config
+	if file == "" {
+		return &cfg, nil
+	}
+	// We accept the risk of including a user provided file here.
+	data, err := os.ReadFile(filepath.Clean(file)) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %v", file, err)
+	}
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %v", file, err)
+	}
+	return &cfg, nil
+}
+
+func (c *config) disable(ids ...string) {
+	if c.Rules == nil {
+		c.Rules = map[string]ruleConfig{}
+	}
+	disabled := false
+	for _, id := range ids {
+		ruleConfig := c.Rules[id]
+		ruleConfig.Enabled = &disabled
+		c.Rules[id] = ruleConfig
+	}
+}
+
+// apply returns the enabled rules with their configured severity
+func (c *config) apply(rules []rule) ([]rule, error) {
+	known := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		known[rule.id] = true
+	}
+	for id, ruleConfig := range c.Rules {
+		if !known[id] {
+			return nil, fmt.Errorf("unknown lint rule %s", id)
+		}
+		if ruleConfig.Severity != "" && !ruleConfig.Severity.valid() {
+			return nil, fmt.Errorf("invalid severity %s for lint rule %s, expected one of error, warning or info", ruleConfig.Severity, id)
+		}
+	}
+	var enabled []rule
+	for _, rule := range rules {
+		ruleConfig := c.Rules[rule.id]
+		if ruleConfig.Enabled != nil && !*ruleConfig.Enabled {
+			continue
+		}
+		if ruleConfig.Severity != "" {
+			rule.severity = ruleConfig.Severity
+		}
+		enabled = append(enabled, rule)
+	}
+	return enabled, nil
+}
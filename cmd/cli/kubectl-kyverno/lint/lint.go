@@ -0,0 +1,284 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var description = []string{
+	"Checks policies against best practices and suggests fixes.",
+	"Policies are not validated, use 'kyverno apply' or 'kyverno test' to find invalid policies.",
+	"The command fails when findings with the error severity are reported.",
+}
+
+var examples = []string{
+	"  # Lint the policies of a directory\n  kyverno lint ./policies",
+	"  # Lint policies without checking annotations\n  kyverno lint policy.yaml --disable missing-annotations",
+	"  # Lint policies with a configuration file and write a SARIF report\n  kyverno lint ./policies --config lint.yaml --output sarif > kyverno.sarif",
+}
+
+const configExample = `
+Rules can be disabled and their severity changed with a configuration file:
+
+  rules:
+    foreach-without-preconditions:
+      enabled: false
+    wildcard-kinds:
+      severity: error
+`
+
+type options struct {
+	config   string
+	disabled []string
+	output   string
+}
+
+func Command() *cobra.Command {
+	var opts options
+	cmd := &cobra.Command{
+		Use:          "lint <policy path>...",
+		Short:        description[0],
+		Long:         strings.Join(description, "\n") + "\n\n" + rulesDescription() + configExample,
+		Example:      strings.Join(examples, "\n\n"),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.OutOrStdout(), args...)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.config, "config", "c", "", "Path to the lint configuration file")
+	cmd.Flags().StringSliceVar(&opts.disabled, "disable", nil, "Lint rules to disable")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "text", "Output format, one of text, json or sarif")
+	return cmd
+}
+
+func rulesDescription() string {
+	lines := []string{"Rules:"}
+	for _, rule := range rules {
+		lines = append(lines, fmt.Sprintf("  %-30s %-8s %s", rule.id, rule.severity, rule.description))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (o options) run(out io.Writer, paths ...string) error {
+	if o.output != "text" && o.output != "json" && o.output != "sarif" {
+		return fmt.Errorf("invalid output format %s, expected one of text, json or sarif", o.output)
+	}
+	cfg, err := loadConfig(o.config)
+	if err != nil {
+		return err
+	}
+	cfg.disable(o.disabled...)
+	enabled, err := cfg.apply(rules)
+	if err != nil {
+		return err
+	}
+	files, err := listFiles(paths...)
+	if err != nil {
+		return err
+	}
+	findings := []finding{}
+	for _, file := range files {
+		documents, err := loadPolicies(file)
+		if err != nil {
+			return err
+		}
+		for _, document := range documents {
+			for _, finding := range lint(enabled, file, document.policy) {
+				finding.Line = document.line(finding.Path)
+				findings = append(findings, finding)
+			}
+		}
+	}
+	switch o.output {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(findings); err != nil {
+			return err
+		}
+	case "sarif":
+		if err := printSarif(out, enabled, findings); err != nil {
+			return err
+		}
+	default:
+		printText(out, findings)
+	}
+	errors := 0
+	for _, finding := range findings {
+		if finding.Severity == severityError {
+			errors++
+		}
+	}
+	if errors > 0 {
+		return fmt.Errorf("%d lint error(s) found", errors)
+	}
+	return nil
+}
+
+func printText(out io.Writer, findings []finding) {
+	counts := map[severity]int{}
+	for _, finding := range findings {
+		counts[finding.Severity]++
+		location := finding.File
+		if finding.Line > 0 {
+			location += ":" + strconv.Itoa(finding.Line)
+		}
+		fmt.Fprintf(out, "%s: %s: %s: %s: %s [%s]\n", location, finding.Policy, finding.Path, finding.Severity, finding.Message, finding.Rule)
+		if finding.Fix != "" {
+			fmt.Fprintf(out, "  fix: %s\n", finding.Fix)
+		}
+	}
+	fmt.Fprintf(out, "\n%d error(s), %d warning(s), %d info(s)\n", counts[severityError], counts[severityWarning], counts[severityInfo])
+}
+
+// listFiles returns the YAML files of the given paths, directories are walked recursively
+func listFiles(paths ...string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		err := filepath.WalkDir(filepath.Clean(path), func(path string, entry os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" || ext == ".json" {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list policies in %s: %v", path, err)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// document is a policy loaded from a file with the YAML nodes of its document, used to find the lines of findings
+type document struct {
+	policy kyvernov1.PolicyInterface
+	node   *yamlv3.Node
+	// offset is the number of lines of the file preceding the document
+	offset int
+}
+
+var pathSegment = regexp.MustCompile(`^([^\[]+)((?:\[\d+\])*)$`)
+
+var pathIndex = regexp.MustCompile(`\[(\d+)\]`)
+
+// line returns the line of the file where the element at the given path of the policy is defined, the line of
+// the closest parent found is returned if the path is not found and 0 if the document can't be located
+func (d document) line(path string) int {
+	if d.node == nil || len(d.node.Content) == 0 {
+		return 0
+	}
+	node := d.node.Content[0]
+	line := node.Line
+	for _, segment := range strings.Split(path, ".") {
+		match := pathSegment.FindStringSubmatch(segment)
+		if match == nil {
+			break
+		}
+		node = mappingValue(node, match[1])
+		if node == nil {
+			break
+		}
+		line = node.Line
+		for _, index := range pathIndex.FindAllStringSubmatch(match[2], -1) {
+			i, _ := strconv.Atoi(index[1])
+			if node.Kind != yamlv3.SequenceNode || i >= len(node.Content) {
+				node = nil
+				break
+			}
+			node = node.Content[i]
+			line = node.Line
+		}
+		if node == nil {
+			break
+		}
+	}
+	return d.offset + line
+}
+
+// mappingValue returns the value of a key of a mapping node, the key node is returned for collections so that
+// the line of the key is reported
+func mappingValue(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			value := node.Content[i+1]
+			if value.Kind == yamlv3.ScalarNode || value.Kind == yamlv3.AliasNode {
+				return value
+			}
+			// the line of collections is the line of their key, their value can start on the next line
+			located := *value
+			located.Line = node.Content[i].Line
+			return &located
+		}
+	}
+	return nil
+}
+
+// loadPolicies loads the policies of a file, other resources are skipped
+func loadPolicies(file string) ([]document, error) {
+	// We accept the risk of including a user provided file here.
+	data, err := os.ReadFile(file) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %v", file, err)
+	}
+	raws, err := yamlutils.SplitDocuments(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load policies from %s: %v", file, err)
+	}
+	var documents []document
+	position := 0
+	for _, raw := range raws {
+		// documents are located in the file to report the lines of findings
+		offset := -1
+		if index := bytes.Index(data[position:], raw); index >= 0 {
+			offset = bytes.Count(data[:position+index], []byte("\n"))
+			position += index + len(raw)
+		}
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(raw, &typeMeta); err != nil {
+			return nil, fmt.Errorf("failed to load policies from %s: %v", file, err)
+		}
+		if typeMeta.Kind != "ClusterPolicy" && typeMeta.Kind != "Policy" {
+			continue
+		}
+		loaded, err := yamlutils.GetPolicy(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load policies from %s: %v", file, err)
+		}
+		var node *yamlv3.Node
+		if offset >= 0 {
+			node = &yamlv3.Node{}
+			if err := yamlv3.Unmarshal(raw, node); err != nil {
+				node = nil
+			}
+		}
+		for _, policy := range loaded {
+			documents = append(documents, document{policy: policy, node: node, offset: offset})
+		}
+	}
+	return documents, nil
+}
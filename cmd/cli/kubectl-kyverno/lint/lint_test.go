@@ -0,0 +1,360 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+)
+
+const annotations = `
+  annotations:
+    policies.kyverno.io/severity: medium
+    policies.kyverno.io/category: Best Practices
+`
+
+func loadPolicy(t *testing.T, raw string) kyvernov1.PolicyInterface {
+	policies, err := yamlutils.GetPolicy([]byte(raw))
+	assert.NilError(t, err)
+	assert.Equal(t, len(policies), 1)
+	return policies[0]
+}
+
+// results returns the rules and paths of the findings, sorted
+func results(findings []finding) []string {
+	var out []string
+	for _, finding := range findings {
+		out = append(out, finding.Rule+" "+finding.Path)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func Test_Rules(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   string
+		expected []string
+	}{
+		{
+			name: "clean policy",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels` + annotations + `
+spec:
+  validationFailureAction: Enforce
+  rules:
+  - name: check-labels
+    match:
+      any:
+      - resources:
+          kinds: [Pod]
+    validate:
+      pattern:
+        metadata:
+          labels:
+            app: "?*"
+`,
+		},
+		{
+			name: "user info in background",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: add-owner` + annotations + `
+spec:
+  rules:
+  - name: owner
+    match:
+      any:
+      - resources:
+          kinds: [ConfigMap]
+        subjects:
+        - kind: User
+          name: alice
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            owner: "{{ request.userInfo.username }}"
+            sa: "{{ request.object.spec.serviceAccountName }}"
+`,
+			expected: []string{
+				"background-userinfo spec.rules[0]",
+				"background-userinfo spec.rules[0].match.any[0]",
+			},
+		},
+		{
+			name: "user info without background",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: add-owner` + annotations + `
+spec:
+  background: false
+  rules:
+  - name: owner
+    match:
+      any:
+      - resources:
+          kinds: [ConfigMap]
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            owner: "{{ request.userInfo.username }}"
+`,
+		},
+		{
+			name: "api call and foreach",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: check-images
+  namespace: apps` + annotations + `
+spec:
+  rules:
+  - name: images
+    match:
+      any:
+      - resources:
+          kinds: [Service]
+    context:
+    - name: pods
+      apiCall:
+        urlPath: /api/v1/namespaces/apps/pods
+    - name: count
+      apiCall:
+        urlPath: /api/v1/namespaces/apps/pods
+        jmesPath: length(items)
+    validate:
+      foreach:
+      - list: request.object.spec.ports
+        context:
+        - name: endpoints
+          apiCall:
+            urlPath: /api/v1/namespaces/apps/endpoints
+        deny: {}
+      - list: request.object.spec.ports
+        preconditions:
+          all:
+          - key: "{{ element.port }}"
+            operator: GreaterThan
+            value: 1024
+        deny: {}
+`,
+			expected: []string{
+				"apicall-without-jmespath spec.rules[0].context[0].apiCall",
+				"apicall-without-jmespath spec.rules[0].validate.foreach[0].context[0].apiCall",
+				"foreach-without-preconditions spec.rules[0].validate.foreach[0]",
+			},
+		},
+		{
+			name: "wildcard kinds",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-team
+spec:
+  validationFailureAction: Enforce
+  rules:
+  - name: team
+    match:
+      any:
+      - resources:
+          kinds: ["*"]
+    exclude:
+      any:
+      - resources:
+          kinds: ["*"]
+          namespaces: [kube-system]
+    validate:
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+  - name: add-team
+    match:
+      resources:
+        kinds: ["*/*"]
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            +(team): none
+`,
+			expected: []string{
+				"missing-annotations metadata.annotations",
+				"missing-annotations metadata.annotations",
+				"wildcard-enforce spec.rules[0].match.any[0].resources.kinds",
+				"wildcard-kinds spec.rules[0].match.any[0].resources.kinds",
+				"wildcard-kinds spec.rules[1].match.resources.kinds",
+			},
+		},
+		{
+			name: "autogen duplication",
+			policy: `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: disallow-latest` + annotations + `
+spec:
+  rules:
+  - name: pods
+    match:
+      any:
+      - resources:
+          kinds: [Pod]
+    validate:
+      pattern:
+        spec:
+          containers:
+          - image: "!*:latest"
+  - name: deployments
+    match:
+      any:
+      - resources:
+          kinds: [Deployment]
+    validate:
+      pattern:
+        spec:
+          template:
+            spec:
+              containers:
+              - image: "!*:latest"
+`,
+			expected: []string{
+				"autogen-duplication spec.rules[0]",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := loadPolicy(t, tc.policy)
+			assert.DeepEqual(t, results(lint(rules, "policy.yaml", policy)), tc.expected)
+		})
+	}
+}
+
+const policy = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-team
+spec:
+  validationFailureAction: Enforce
+  rules:
+  - name: team
+    match:
+      any:
+      - resources:
+          kinds: ["*"]
+    validate:
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-policy
+`
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
+func Test_Run(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"policy.yaml": policy,
+		"lint.yaml":   "rules:\n  wildcard-enforce:\n    severity: warning\n  missing-annotations:\n    enabled: false\n",
+		"bad.yaml":    "rules:\n  unknown-rule:\n    enabled: false\n",
+		"README.md":   "not a policy",
+	})
+	policyPath := filepath.Join(dir, "policy.yaml")
+
+	var out bytes.Buffer
+	err := options{output: "json"}.run(&out, dir)
+	assert.ErrorContains(t, err, "1 lint error(s) found")
+	var findings []finding
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &findings))
+	assert.Equal(t, len(findings), 4)
+	for _, finding := range findings {
+		assert.Equal(t, finding.File, policyPath)
+		assert.Equal(t, finding.Policy, "require-team")
+	}
+
+	out.Reset()
+	err = options{output: "text", config: filepath.Join(dir, "lint.yaml"), disabled: []string{"wildcard-kinds"}}.run(&out, policyPath)
+	assert.NilError(t, err)
+	assert.Equal(t, out.String(), policyPath+":13: require-team: spec.rules[0].match.any[0].resources.kinds: warning: rule team enforces validation on all kinds [wildcard-enforce]\n"+
+		"  fix: set spec.validationFailureAction to Audit or list the kinds the rule applies to\n"+
+		"\n0 error(s), 1 warning(s), 0 info(s)\n")
+
+	err = options{output: "text", config: filepath.Join(dir, "bad.yaml")}.run(&out, policyPath)
+	assert.ErrorContains(t, err, "unknown lint rule unknown-rule")
+	err = options{output: "xml"}.run(&out, policyPath)
+	assert.ErrorContains(t, err, "invalid output format xml")
+}
+
+func Test_loadPolicies_lines(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"policies.yaml": "# policies\n---" + policy + "---\n" + strings.TrimPrefix(policy, "\n")})
+	documents, err := loadPolicies(filepath.Join(dir, "policies.yaml"))
+	assert.NilError(t, err)
+	assert.Equal(t, len(documents), 2)
+	testCases := []struct {
+		document int
+		path     string
+		expected int
+	}{
+		{document: 0, path: "spec.rules[0].match.any[0].resources.kinds", expected: 14},
+		{document: 0, path: "spec.rules[0]", expected: 10},
+		{document: 0, path: "spec.validationFailureAction", expected: 8},
+		// missing elements are located at their closest parent
+		{document: 0, path: "metadata.annotations", expected: 5},
+		{document: 0, path: "spec.rules[3]", expected: 9},
+		{document: 1, path: "spec.rules[0].match.any[0].resources.kinds", expected: 37},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, documents[tc.document].line(tc.path), tc.expected, tc.path)
+	}
+}
+
+func Test_Sarif(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"policy.yaml": policy})
+	var out bytes.Buffer
+	err := options{output: "sarif", disabled: []string{"missing-annotations"}}.run(&out, dir)
+	assert.ErrorContains(t, err, "1 lint error(s) found")
+	var log sarifLog
+	assert.NilError(t, json.Unmarshal(out.Bytes(), &log))
+	assert.Equal(t, log.Version, "2.1.0")
+	assert.Equal(t, len(log.Runs), 1)
+	assert.Equal(t, len(log.Runs[0].Tool.Driver.Rules), len(rules)-1)
+	assert.Equal(t, len(log.Runs[0].Results), 2)
+	result := log.Runs[0].Results[0]
+	assert.Equal(t, result.RuleID, "wildcard-kinds")
+	assert.Equal(t, result.Level, "warning")
+	assert.Equal(t, result.Locations[0].PhysicalLocation.ArtifactLocation.URI, filepath.Join(dir, "policy.yaml"))
+	assert.Equal(t, result.Locations[0].PhysicalLocation.Region.StartLine, 13)
+	assert.Equal(t, result.Locations[0].LogicalLocations[0].FullyQualifiedName, "require-team/spec.rules[0].match.any[0].resources.kinds")
+	assert.Equal(t, result.Properties["fix"], "list the kinds the rule applies to")
+	assert.Equal(t, log.Runs[0].Results[1].Level, "error")
+}
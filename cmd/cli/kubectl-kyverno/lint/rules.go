@@ -0,0 +1,353 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+)
+
+type severity string
+
+const (
+	severityError   severity = "error"
+	severityWarning severity = "warning"
+	severityInfo    severity = "info"
+)
+
+func (s severity) valid() bool {
+	return s == severityError || s == severityWarning || s == severityInfo
+}
+
+// rule is a lint rule, check returns the findings of the rule for a policy, rule and severity of the findings
+// are set by the caller
+type rule struct {
+	id          string
+	description string
+	severity    severity
+	check       func(kyvernov1.PolicyInterface) []finding
+}
+
+type finding struct {
+	Rule     string   `json:"rule"`
+	Severity severity `json:"severity"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Policy   string   `json:"policy"`
+	Path     string   `json:"path"`
+	Message  string   `json:"message"`
+	Fix      string   `json:"fix,omitempty"`
+}
+
+var rules = []rule{
+	{
+		id:          "background-userinfo",
+		description: "Rules using request.userInfo, roles or service account information must not be processed in background as this information is not available in background scans",
+		severity:    severityError,
+		check:       checkBackgroundUserInfo,
+	},
+	{
+		id:          "apicall-without-jmespath",
+		description: "API calls should filter the response with a jmesPath to keep only the data used by the rule",
+		severity:    severityWarning,
+		check:       checkAPICallWithoutJMESPath,
+	},
+	{
+		id:          "foreach-without-preconditions",
+		description: "Foreach declarations should use preconditions to skip the elements the rule does not apply to",
+		severity:    severityInfo,
+		check:       checkForeachWithoutPreconditions,
+	},
+	{
+		id:          "wildcard-kinds",
+		description: "Rules should list the kinds they apply to instead of matching all kinds",
+		severity:    severityWarning,
+		check:       checkWildcardKinds,
+	},
+	{
+		id:          "wildcard-enforce",
+		description: "Validate rules matching all kinds should not be enforced as they can block requests for any resource",
+		severity:    severityError,
+		check:       checkWildcardEnforce,
+	},
+	{
+		id:          "missing-annotations",
+		description: "Policies should declare their severity and category annotations, they are used in policy reports",
+		severity:    severityWarning,
+		check:       checkMissingAnnotations,
+	},
+	{
+		id:          "autogen-duplication",
+		description: "Rules generated for pod controllers should not match kinds already matched by other rules of the policy",
+		severity:    severityWarning,
+		check:       checkAutogenDuplication,
+	},
+}
+
+var userInfoVariable = regexp.MustCompile(`(^|[^.\w])(request\.userInfo|request\.roles|request\.clusterRoles|serviceAccountName|serviceAccountNamespace)\b`)
+
+func rulePath(index int) string {
+	return fmt.Sprintf("spec.rules[%d]", index)
+}
+
+func checkBackgroundUserInfo(policy kyvernov1.PolicyInterface) []finding {
+	spec := policy.GetSpec()
+	if !spec.BackgroundProcessingEnabled() {
+		return nil
+	}
+	var findings []finding
+	for i, rule := range spec.Rules {
+		if rule.IsMutateExisting() {
+			continue
+		}
+		for _, filter := range matchFilters(rule) {
+			if !reflect.DeepEqual(filter.userInfo, kyvernov1.UserInfo{}) {
+				findings = append(findings, finding{
+					Path:    fmt.Sprintf("%s.%s", rulePath(i), filter.path),
+					Message: fmt.Sprintf("rule %s filters on user information while background processing is enabled", rule.Name),
+					Fix:     "set spec.background to false",
+				})
+			}
+		}
+		if variable := findUserInfoVariable(rule); variable != "" {
+			findings = append(findings, finding{
+				Path:    rulePath(i),
+				Message: fmt.Sprintf("rule %s uses the variable %s while background processing is enabled", rule.Name, variable),
+				Fix:     "set spec.background to false",
+			})
+		}
+	}
+	return findings
+}
+
+func findUserInfoVariable(rule kyvernov1.Rule) string {
+	// exclude blocks are checked with the match filters
+	rule.MatchResources = kyvernov1.MatchResources{}
+	rule.ExcludeResources = kyvernov1.MatchResources{}
+	raw, err := json.Marshal(rule)
+	if err != nil {
+		return ""
+	}
+	for _, variable := range variables.RegexVariables.FindAllString(string(raw), -1) {
+		if userInfoVariable.MatchString(strings.TrimSpace(variable)) {
+			return strings.TrimSpace(variable)
+		}
+	}
+	return ""
+}
+
+func checkAPICallWithoutJMESPath(policy kyvernov1.PolicyInterface) []finding {
+	var findings []finding
+	check := func(rule kyvernov1.Rule, path string, entries []kyvernov1.ContextEntry) {
+		for j, entry := range entries {
+			if entry.APICall != nil && entry.APICall.JMESPath == "" {
+				findings = append(findings, finding{
+					Path:    fmt.Sprintf("%s.context[%d].apiCall", path, j),
+					Message: fmt.Sprintf("context entry %s of rule %s stores the whole API call response", entry.Name, rule.Name),
+					Fix:     "add a jmesPath to the API call to keep only the data used by the rule",
+				})
+			}
+		}
+	}
+	for i, rule := range policy.GetSpec().Rules {
+		check(rule, rulePath(i), rule.Context)
+		for j, foreach := range rule.Validation.ForEachValidation {
+			check(rule, fmt.Sprintf("%s.validate.foreach[%d]", rulePath(i), j), foreach.Context)
+		}
+		for j, foreach := range rule.Mutation.ForEachMutation {
+			check(rule, fmt.Sprintf("%s.mutate.foreach[%d]", rulePath(i), j), foreach.Context)
+		}
+	}
+	return findings
+}
+
+func checkForeachWithoutPreconditions(policy kyvernov1.PolicyInterface) []finding {
+	var findings []finding
+	for i, rule := range policy.GetSpec().Rules {
+		for j, foreach := range rule.Validation.ForEachValidation {
+			if foreach.AnyAllConditions == nil {
+				findings = append(findings, finding{
+					Path:    fmt.Sprintf("%s.validate.foreach[%d]", rulePath(i), j),
+					Message: fmt.Sprintf("foreach of rule %s has no preconditions and is evaluated for every element of %s", rule.Name, foreach.List),
+					Fix:     "add preconditions to skip the elements the rule does not apply to",
+				})
+			}
+		}
+		for j, foreach := range rule.Mutation.ForEachMutation {
+			if foreach.AnyAllConditions == nil {
+				findings = append(findings, finding{
+					Path:    fmt.Sprintf("%s.mutate.foreach[%d]", rulePath(i), j),
+					Message: fmt.Sprintf("foreach of rule %s has no preconditions and is evaluated for every element of %s", rule.Name, foreach.List),
+					Fix:     "add preconditions to skip the elements the rule does not apply to",
+				})
+			}
+		}
+	}
+	return findings
+}
+
+type matchFilter struct {
+	path        string
+	userInfo    kyvernov1.UserInfo
+	description kyvernov1.ResourceDescription
+}
+
+// matchFilters returns the filters of the match and exclude blocks of a rule with their paths
+func matchFilters(rule kyvernov1.Rule) []matchFilter {
+	var filters []matchFilter
+	add := func(path string, resources kyvernov1.MatchResources) {
+		filters = append(filters, matchFilter{path: path, userInfo: resources.UserInfo, description: resources.ResourceDescription})
+		for i, filter := range resources.Any {
+			filters = append(filters, matchFilter{path: fmt.Sprintf("%s.any[%d]", path, i), userInfo: filter.UserInfo, description: filter.ResourceDescription})
+		}
+		for i, filter := range resources.All {
+			filters = append(filters, matchFilter{path: fmt.Sprintf("%s.all[%d]", path, i), userInfo: filter.UserInfo, description: filter.ResourceDescription})
+		}
+	}
+	add("match", rule.MatchResources)
+	add("exclude", rule.ExcludeResources)
+	return filters
+}
+
+func isWildcardKind(kind string) bool {
+	return kind == "*" || strings.HasSuffix(kind, "/*")
+}
+
+// wildcardKindPaths returns the paths of the match filters of a rule matching all kinds
+func wildcardKindPaths(index int, rule kyvernov1.Rule) []string {
+	var paths []string
+	for _, filter := range matchFilters(rule) {
+		if !strings.HasPrefix(filter.path, "match") {
+			continue
+		}
+		for _, kind := range filter.description.Kinds {
+			if isWildcardKind(kind) {
+				paths = append(paths, fmt.Sprintf("%s.%s.resources.kinds", rulePath(index), filter.path))
+				break
+			}
+		}
+	}
+	return paths
+}
+
+func checkWildcardKinds(policy kyvernov1.PolicyInterface) []finding {
+	var findings []finding
+	for i, rule := range policy.GetSpec().Rules {
+		for _, path := range wildcardKindPaths(i, rule) {
+			findings = append(findings, finding{
+				Path:    path,
+				Message: fmt.Sprintf("rule %s matches all kinds", rule.Name),
+				Fix:     "list the kinds the rule applies to",
+			})
+		}
+	}
+	return findings
+}
+
+func checkWildcardEnforce(policy kyvernov1.PolicyInterface) []finding {
+	spec := policy.GetSpec()
+	if !spec.ValidationFailureAction.Enforce() {
+		return nil
+	}
+	var findings []finding
+	for i, rule := range spec.Rules {
+		if !rule.HasValidate() {
+			continue
+		}
+		for _, path := range wildcardKindPaths(i, rule) {
+			findings = append(findings, finding{
+				Path:    path,
+				Message: fmt.Sprintf("rule %s enforces validation on all kinds", rule.Name),
+				Fix:     "set spec.validationFailureAction to Audit or list the kinds the rule applies to",
+			})
+		}
+	}
+	return findings
+}
+
+func checkMissingAnnotations(policy kyvernov1.PolicyInterface) []finding {
+	var findings []finding
+	annotations := policy.GetAnnotations()
+	if annotations[kyvernov1.AnnotationPolicySeverity] == "" {
+		findings = append(findings, finding{
+			Path:    "metadata.annotations",
+			Message: fmt.Sprintf("policy has no %s annotation", kyvernov1.AnnotationPolicySeverity),
+			Fix:     fmt.Sprintf("add the %s annotation with one of low, medium or high", kyvernov1.AnnotationPolicySeverity),
+		})
+	}
+	if annotations[kyvernov1.AnnotationPolicyCategory] == "" {
+		findings = append(findings, finding{
+			Path:    "metadata.annotations",
+			Message: fmt.Sprintf("policy has no %s annotation", kyvernov1.AnnotationPolicyCategory),
+			Fix:     fmt.Sprintf("add the %s annotation", kyvernov1.AnnotationPolicyCategory),
+		})
+	}
+	return findings
+}
+
+func checkAutogenDuplication(policy kyvernov1.PolicyInterface) []finding {
+	spec := policy.GetSpec()
+	index := make(map[string]int, len(spec.Rules))
+	matched := map[string]string{}
+	for i, rule := range spec.Rules {
+		index[rule.Name] = i
+		for _, kind := range rule.MatchResources.GetKinds() {
+			if _, ok := matched[kindName(kind)]; !ok {
+				matched[kindName(kind)] = rule.Name
+			}
+		}
+	}
+	var findings []finding
+	for _, rule := range autogen.ComputeRules(policy) {
+		if _, ok := index[rule.Name]; ok {
+			continue
+		}
+		source := strings.TrimPrefix(strings.TrimPrefix(rule.Name, "autogen-cronjob-"), "autogen-")
+		i, ok := index[source]
+		if !ok {
+			continue
+		}
+		for _, kind := range rule.MatchResources.GetKinds() {
+			if other, ok := matched[kindName(kind)]; ok {
+				findings = append(findings, finding{
+					Path:    rulePath(i),
+					Message: fmt.Sprintf("rule %s is generated for %s which is already matched by rule %s", rule.Name, kindName(kind), other),
+					Fix:     fmt.Sprintf("set the %s annotation to the pod controllers not matched by other rules or to none", kyvernov1.PodControllersAnnotation),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// kindName returns the kind of a kind given as group/version/kind
+func kindName(kind string) string {
+	return kind[strings.LastIndex(kind, "/")+1:]
+}
+
+func policyName(policy kyvernov1.PolicyInterface) string {
+	if policy.IsNamespaced() {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
+
+// lint runs the rules on a policy and returns the findings
+func lint(rules []rule, file string, policy kyvernov1.PolicyInterface) []finding {
+	var findings []finding
+	for _, rule := range rules {
+		for _, finding := range rule.check(policy) {
+			finding.Rule = rule.id
+			finding.Severity = rule.severity
+			finding.File = file
+			finding.Policy = policyName(policy)
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
@@ -0,0 +1,334 @@
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/utils"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	"github.com/spf13/cobra"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var description = []string{
+	"Replays admission reviews dumped by the admission controller against local policies and shows the differences between the recorded and replayed responses.",
+	"Admission reviews are written by the admission controller when the --dumpPayloadPath flag is set, reviews of the mutating webhook are read from files prefixed with 'mutate', other files are considered reviews of the validating webhook.",
+	"Only mutate and validate rules are replayed, image verification and generate rules are not evaluated.",
+	"Roles and cluster roles of the requesting user are not recorded and are considered empty.",
+}
+
+var examples = []string{
+	"  # Replay all reviews in a directory against a policy\n  kyverno replay ./dumps --policy policy.yaml",
+	"  # Replay reviews from a JSON lines file against the policies in a directory\n  kyverno replay validate.jsonl --policy ./policies",
+}
+
+type options struct {
+	policies []string
+}
+
+func Command() *cobra.Command {
+	var opts options
+	cmd := &cobra.Command{
+		Use:          "replay <dump path>...",
+		Short:        description[0],
+		Long:         strings.Join(description, "\n"),
+		Example:      strings.Join(examples, "\n\n"),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.run(cmd.Context(), os.Stdout, args...)
+		},
+	}
+	cmd.Flags().StringSliceVarP(&opts.policies, "policy", "p", nil, "Path to the policies to replay admission reviews against, can be a file or a directory")
+	_ = cmd.MarkFlagRequired("policy")
+	return cmd
+}
+
+func (o options) run(ctx context.Context, out io.Writer, paths ...string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	policies, err := common.GetPoliciesFromPaths(nil, o.policies, false, "")
+	if err != nil {
+		return err
+	}
+	payloads, err := loadPayloads(paths...)
+	if err != nil {
+		return err
+	}
+	rclient := registryclient.NewOrDie()
+	eng := engine.NewEngine(
+		config.NewDefaultConfiguration(),
+		rclient,
+		engine.LegacyContextLoaderFactory(rclient),
+		nil,
+		nil,
+	)
+	differ := 0
+	for _, payload := range payloads {
+		replayed := replay(ctx, eng, policies, payload.webhook, payload.review.Request)
+		differences := compare(payload.review.Response, replayed)
+		if len(differences) != 0 {
+			differ++
+		}
+		printResult(out, payload, differences)
+	}
+	fmt.Fprintf(out, "\n%d admission reviews replayed, %d with a different response\n", len(payloads), differ)
+	if differ != 0 {
+		return fmt.Errorf("%d responses differ", differ)
+	}
+	return nil
+}
+
+// payload is an admission review read from a dump
+type payload struct {
+	source  string
+	webhook string
+	review  admissionv1.AdmissionReview
+}
+
+// loadPayloads reads admission reviews from files and directories, files are expected to contain
+// a single admission review or to be JSON lines files (.jsonl) with one admission review per line
+func loadPayloads(paths ...string) ([]payload, error) {
+	var payloads []payload
+	for _, path := range paths {
+		err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			ext := filepath.Ext(file)
+			if ext != ".json" && ext != ".jsonl" {
+				return nil
+			}
+			webhook := "validate"
+			if strings.HasPrefix(filepath.Base(file), "mutate") {
+				webhook = "mutate"
+			}
+			if ext == ".json" {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					return err
+				}
+				p, err := newPayload(file, webhook, data)
+				if err != nil {
+					return err
+				}
+				payloads = append(payloads, p)
+				return nil
+			}
+			f, err := os.Open(file)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			scanner := bufio.NewScanner(f)
+			scanner.Buffer(nil, 16*1024*1024)
+			for line := 1; scanner.Scan(); line++ {
+				if len(strings.TrimSpace(scanner.Text())) == 0 {
+					continue
+				}
+				p, err := newPayload(fmt.Sprintf("%s:%d", file, line), webhook, scanner.Bytes())
+				if err != nil {
+					return err
+				}
+				payloads = append(payloads, p)
+			}
+			return scanner.Err()
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return payloads, nil
+}
+
+func newPayload(source, webhook string, data []byte) (payload, error) {
+	p := payload{source: source, webhook: webhook}
+	if err := json.Unmarshal(data, &p.review); err != nil {
+		return p, fmt.Errorf("failed to decode admission review %s: %w", source, err)
+	}
+	if p.review.Request == nil {
+		return p, fmt.Errorf("admission review %s has no request", source)
+	}
+	// the api server always sets the requested kind and resource, hand written reviews may not
+	if p.review.Request.RequestKind == nil {
+		p.review.Request.RequestKind = p.review.Request.Kind.DeepCopy()
+	}
+	if p.review.Request.RequestResource == nil {
+		p.review.Request.RequestResource = p.review.Request.Resource.DeepCopy()
+	}
+	if p.review.Response == nil {
+		p.review.Response = &admissionv1.AdmissionResponse{UID: p.review.Request.UID, Allowed: true}
+	}
+	return p, nil
+}
+
+// replay computes the response the admission controller would return for the request with the given policies
+func replay(
+	ctx context.Context,
+	eng engineapi.Engine,
+	policies []kyvernov1.PolicyInterface,
+	webhook string,
+	request *admissionv1.AdmissionRequest,
+) *admissionv1.AdmissionResponse {
+	policyContext, err := engine.NewPolicyContextFromAdmissionRequest(
+		request,
+		kyvernov1beta1.RequestInfo{AdmissionUserInfo: *request.UserInfo.DeepCopy()},
+		config.NewDefaultConfiguration(),
+		nil,
+		nil,
+	)
+	if err != nil {
+		return admissionutils.Response(request.UID, err)
+	}
+	if webhook == "mutate" {
+		return mutate(ctx, eng, policies, request, policyContext)
+	}
+	return validate(ctx, eng, policies, request, policyContext)
+}
+
+func mutate(
+	ctx context.Context,
+	eng engineapi.Engine,
+	policies []kyvernov1.PolicyInterface,
+	request *admissionv1.AdmissionRequest,
+	policyContext *engine.PolicyContext,
+) *admissionv1.AdmissionResponse {
+	if err := enginecontext.MutateResourceWithImageInfo(request.Object.Raw, policyContext.JSONContext()); err != nil {
+		log.Log.V(3).Info("failed to patch images info to resource", "error", err.Error())
+	}
+	var patches [][]byte
+	var engineResponses []*engineapi.EngineResponse
+	for _, policy := range policies {
+		if !policy.GetSpec().HasMutate() {
+			continue
+		}
+		currentContext := policyContext.WithPolicy(policy)
+		engineResponse := eng.Mutate(ctx, currentContext)
+		if !engineResponse.IsSuccessful() {
+			err := fmt.Errorf("failed to apply policy %s rules %v", policy.GetName(), engineResponse.GetFailedRules())
+			return admissionutils.Response(request.UID, fmt.Errorf("mutation policy %s error: %v", policy.GetName(), err))
+		}
+		patches = append(patches, engineResponse.GetPatches()...)
+		policyContext = currentContext.WithNewResource(engineResponse.PatchedResource)
+		engineResponses = append(engineResponses, engineResponse)
+	}
+	if annPatches := utils.GenerateAnnotationPatches(engineResponses, log.Log); annPatches != nil {
+		patches = append(patches, annPatches...)
+	}
+	return admissionutils.MutationResponse(request.UID, jsonutils.JoinPatches(patches...))
+}
+
+func validate(
+	ctx context.Context,
+	eng engineapi.Engine,
+	policies []kyvernov1.PolicyInterface,
+	request *admissionv1.AdmissionRequest,
+	policyContext *engine.PolicyContext,
+) *admissionv1.AdmissionResponse {
+	failurePolicy := kyvernov1.Ignore
+	var engineResponses []*engineapi.EngineResponse
+	for _, policy := range policies {
+		if !policy.GetSpec().HasValidate() {
+			continue
+		}
+		if policy.GetSpec().GetFailurePolicy() == kyvernov1.Fail {
+			failurePolicy = kyvernov1.Fail
+		}
+		engineResponse := eng.Validate(ctx, policyContext.WithPolicy(policy))
+		if engineResponse.IsNil() {
+			continue
+		}
+		engineResponses = append(engineResponses, engineResponse)
+	}
+	if webhookutils.BlockRequest(engineResponses, failurePolicy, log.Log) {
+		return admissionutils.ResponseWithCauses(request.UID, errors.New(webhookutils.GetBlockedMessages(engineResponses)), webhookutils.GetBlockedCauses(engineResponses))
+	}
+	return admissionutils.ResponseSuccess(request.UID, webhookutils.GetWarningMessages(engineResponses)...)
+}
+
+// difference describes a field of the admission response that changed when replayed
+type difference struct {
+	field    string
+	recorded string
+	replayed string
+}
+
+func compare(recorded, replayed *admissionv1.AdmissionResponse) []difference {
+	var differences []difference
+	if recorded.Allowed != replayed.Allowed {
+		differences = append(differences, difference{"allowed", fmt.Sprint(recorded.Allowed), fmt.Sprint(replayed.Allowed)})
+	}
+	if recordedMessage, replayedMessage := message(recorded), message(replayed); recordedMessage != replayedMessage {
+		differences = append(differences, difference{"message", recordedMessage, replayedMessage})
+	}
+	if len(recorded.Warnings) != 0 || len(replayed.Warnings) != 0 {
+		if !reflect.DeepEqual(recorded.Warnings, replayed.Warnings) {
+			differences = append(differences, difference{"warnings", strings.Join(recorded.Warnings, "; "), strings.Join(replayed.Warnings, "; ")})
+		}
+	}
+	if !samePatch(recorded.Patch, replayed.Patch) {
+		differences = append(differences, difference{"patch", string(recorded.Patch), string(replayed.Patch)})
+	}
+	return differences
+}
+
+func message(response *admissionv1.AdmissionResponse) string {
+	if response.Result == nil {
+		return ""
+	}
+	return response.Result.Message
+}
+
+// samePatch compares patches semantically, ignoring formatting differences
+func samePatch(recorded, replayed []byte) bool {
+	if len(recorded) == 0 || len(replayed) == 0 {
+		return len(recorded) == len(replayed)
+	}
+	var recordedPatch, replayedPatch interface{}
+	if err := json.Unmarshal(recorded, &recordedPatch); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(replayed, &replayedPatch); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(recordedPatch, replayedPatch)
+}
+
+func printResult(out io.Writer, payload payload, differences []difference) {
+	request := payload.review.Request
+	status := "PASS"
+	if len(differences) != 0 {
+		status = "DIFF"
+	}
+	name := request.Name
+	if request.Namespace != "" {
+		name = request.Namespace + "/" + name
+	}
+	fmt.Fprintf(out, "%s %s %s %s %s (%s)\n", status, strings.ToUpper(payload.webhook), request.Operation, request.Kind.Kind, name, payload.source)
+	for _, d := range differences {
+		fmt.Fprintf(out, "  %s:\n    recorded: %s\n    replayed: %s\n", d.field, d.recorded, d.replayed)
+	}
+}
@@ -0,0 +1,104 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var policy = []byte(`
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-team
+spec:
+  validationFailureAction: Enforce
+  rules:
+  - name: check-team
+    match:
+      any:
+      - resources:
+          kinds:
+          - ConfigMap
+    validate:
+      message: "label team is required"
+      pattern:
+        metadata:
+          labels:
+            team: "?*"
+`)
+
+func newReview(uid, name, labels string, allowed bool) *admissionv1.AdmissionReview {
+	return &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			UID:       types.UID("uid-" + uid),
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+			Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+			Name:      name,
+			Namespace: "default",
+			Operation: admissionv1.Create,
+			Object: runtime.RawExtension{
+				Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"` + name + `","namespace":"default","labels":` + labels + `}}`),
+			},
+		},
+		Response: &admissionv1.AdmissionResponse{UID: types.UID("uid-" + uid), Allowed: allowed},
+	}
+}
+
+func Test_Replay(t *testing.T) {
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "policy.yaml")
+	assert.NilError(t, os.WriteFile(policyPath, policy, 0o600))
+	dumps := filepath.Join(dir, "dumps")
+	assert.NilError(t, os.Mkdir(dumps, 0o700))
+	writer := handlers.NewFileWriter(dumps, handlers.DumpFormatJSONLines, "validate")
+	// recorded before the policy was created, both requests were allowed
+	assert.NilError(t, writer.Write(newReview("1", "labelled", `{"team":"a"}`, true)))
+	assert.NilError(t, writer.Write(newReview("2", "unlabelled", `{}`, true)))
+
+	payloads, err := loadPayloads(dumps)
+	assert.NilError(t, err)
+	assert.Equal(t, len(payloads), 2)
+	assert.Equal(t, payloads[0].webhook, "validate")
+
+	var out bytes.Buffer
+	opts := options{policies: []string{policyPath}}
+	err = opts.run(context.TODO(), &out, dumps)
+	assert.Error(t, err, "1 responses differ")
+	lines := strings.Split(out.String(), "\n")
+	assert.Assert(t, strings.HasPrefix(lines[0], "PASS VALIDATE CREATE ConfigMap default/labelled"))
+	assert.Assert(t, strings.HasPrefix(lines[1], "DIFF VALIDATE CREATE ConfigMap default/unlabelled"))
+	assert.Equal(t, lines[2], "  allowed:")
+	assert.Equal(t, lines[3], "    recorded: true")
+	assert.Equal(t, lines[4], "    replayed: false")
+	assert.Assert(t, strings.Contains(out.String(), "label team is required"))
+	assert.Assert(t, strings.Contains(out.String(), "2 admission reviews replayed, 1 with a different response"))
+}
+
+func Test_compare(t *testing.T) {
+	patchType := admissionv1.PatchTypeJSONPatch
+	recorded := &admissionv1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     []byte(`[{"op":"add","path":"/metadata/labels/team","value":"a"}]`),
+		PatchType: &patchType,
+	}
+	replayed := recorded.DeepCopy()
+	replayed.Patch = []byte(`[ {"path":"/metadata/labels/team", "op":"add", "value":"a"} ]`)
+	assert.Equal(t, len(compare(recorded, replayed)), 0)
+	replayed.Patch = nil
+	replayed.Warnings = []string{"warning"}
+	differences := compare(recorded, replayed)
+	assert.Equal(t, len(differences), 2)
+	assert.Equal(t, differences[0].field, "warnings")
+	assert.Equal(t, differences[1].field, "patch")
+}
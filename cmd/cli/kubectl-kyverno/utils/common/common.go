@@ -483,9 +483,16 @@ OuterLoop:
 		WithClient(c.Client).
 		WithSubresourcesInPolicy(subresources)
 
-	mutateResponse := engine.Mutate(
+	rclient := registryclient.NewOrDie()
+	eng := engine.NewEngine(
+		cfg,
+		rclient,
+		engine.LegacyContextLoaderFactory(rclient),
+		nil,
+		nil,
+	)
+	mutateResponse := eng.Mutate(
 		context.Background(),
-		engine.LegacyContextLoaderFactory(registryclient.NewOrDie()),
 		policyContext,
 	)
 	if mutateResponse != nil {
@@ -511,11 +518,9 @@ OuterLoop:
 	var info Info
 	var validateResponse *engineapi.EngineResponse
 	if policyHasValidate {
-		validateResponse = engine.Validate(
+		validateResponse = eng.Validate(
 			context.Background(),
-			engine.LegacyContextLoaderFactory(registryclient.NewOrDie()),
 			policyContext,
-			cfg,
 		)
 		info = ProcessValidateEngineResponse(c.Policy, validateResponse, resPath, c.Rc, c.PolicyReport, c.AuditWarn)
 	}
@@ -524,12 +529,9 @@ OuterLoop:
 		engineResponses = append(engineResponses, validateResponse)
 	}
 
-	verifyImageResponse, _ := engine.VerifyAndPatchImages(
+	verifyImageResponse, _ := eng.VerifyAndPatchImages(
 		context.Background(),
-		engine.LegacyContextLoaderFactory(registryclient.NewOrDie()),
-		registryclient.NewOrDie(),
 		policyContext,
-		cfg,
 	)
 	if verifyImageResponse != nil && !verifyImageResponse.IsEmpty() {
 		engineResponses = append(engineResponses, verifyImageResponse)
@@ -544,8 +546,7 @@ OuterLoop:
 	}
 
 	if policyHasGenerate {
-		generateResponse := engine.ApplyBackgroundChecks(
-			engine.LegacyContextLoaderFactory(registryclient.NewOrDie()),
+		generateResponse := eng.ApplyBackgroundChecks(
 			policyContext,
 		)
 		if generateResponse != nil && !generateResponse.IsEmpty() {
@@ -762,7 +763,7 @@ func ProcessValidateEngineResponse(policy kyvernov1.PolicyInterface, validateRes
 						fmt.Printf("%d. %s: %s \n", i+1, valResponseRule.Name, valResponseRule.Message)
 					}
 
-				case engineapi.RuleStatusError:
+				case engineapi.RuleStatusError, engineapi.RuleStatusTimeout:
 					rc.Error++
 					vrule.Status = policyreportv1alpha2.StatusError
 
@@ -1080,7 +1081,13 @@ func initializeMockController(objects []runtime.Object) (*generate.GenerateContr
 	}
 
 	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
-	c := generate.NewGenerateControllerWithOnlyClient(client, engine.LegacyContextLoaderFactory(nil))
+	c := generate.NewGenerateControllerWithOnlyClient(client, engine.NewEngine(
+		config.NewDefaultConfiguration(),
+		nil,
+		engine.LegacyContextLoaderFactory(nil),
+		nil,
+		nil,
+	))
 	return c, nil
 }
 
@@ -7,6 +7,9 @@ type Configuration interface {
 	UsesTracing() bool
 	UsesProfiling() bool
 	UsesKubeconfig() bool
+	UsesResultSinks() bool
+	UsesEvaluationCache() bool
+	UsesLookupFunctions() bool
 	FlagSets() []*flag.FlagSet
 }
 
@@ -44,6 +47,24 @@ func WithKubeconfig() ConfigurationOption {
 	}
 }
 
+func WithResultSinks() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesResultSinks = true
+	}
+}
+
+func WithEvaluationCache() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesEvaluationCache = true
+	}
+}
+
+func WithLookupFunctions() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesLookupFunctions = true
+	}
+}
+
 func WithFlagSets(flagsets ...*flag.FlagSet) ConfigurationOption {
 	return func(c *configuration) {
 		c.flagSets = append(c.flagSets, flagsets...)
@@ -51,11 +72,14 @@ func WithFlagSets(flagsets ...*flag.FlagSet) ConfigurationOption {
 }
 
 type configuration struct {
-	usesMetrics    bool
-	usesTracing    bool
-	usesProfiling  bool
-	usesKubeconfig bool
-	flagSets       []*flag.FlagSet
+	usesMetrics         bool
+	usesTracing         bool
+	usesProfiling       bool
+	usesKubeconfig      bool
+	usesResultSinks     bool
+	usesEvaluationCache bool
+	usesLookupFunctions bool
+	flagSets            []*flag.FlagSet
 }
 
 func (c *configuration) UsesMetrics() bool {
@@ -74,6 +98,18 @@ func (c *configuration) UsesKubeconfig() bool {
 	return c.usesKubeconfig
 }
 
+func (c *configuration) UsesResultSinks() bool {
+	return c.usesResultSinks
+}
+
+func (c *configuration) UsesEvaluationCache() bool {
+	return c.usesEvaluationCache
+}
+
+func (c *configuration) UsesLookupFunctions() bool {
+	return c.usesLookupFunctions
+}
+
 func (c *configuration) FlagSets() []*flag.FlagSet {
 	return c.flagSets
 }
@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	"go.opentelemetry.io/otel/metric/global"
+)
+
+func NewEngine(
+	logger logr.Logger,
+	configuration config.Configuration,
+	rclient registryclient.Client,
+	exceptionSelector engineapi.PolicyExceptionSelector,
+	resourceLister jmespath.ResourceLister,
+) engineapi.Engine {
+	eng := engine.NewEngine(
+		configuration,
+		rclient,
+		engine.LegacyContextLoaderFactory(rclient),
+		exceptionSelector,
+		resourceLister,
+	)
+	if evaluationCacheSize <= 0 {
+		return eng
+	}
+	logger = logger.WithName("engine")
+	logger.Info("enable evaluation cache...", "size", evaluationCacheSize, "ttl", evaluationCacheTTL)
+	return engine.NewCachedEngine(eng, exceptionSelector, global.MeterProvider(), evaluationCacheSize, evaluationCacheTTL)
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"flag"
+	"time"
 
 	"github.com/kyverno/kyverno/pkg/logging"
 )
@@ -28,6 +29,14 @@ var (
 	kubeconfig           string
 	clientRateLimitQPS   float64
 	clientRateLimitBurst int
+	// result sinks
+	resultSinksConfig string
+	// evaluation cache
+	evaluationCacheSize int
+	evaluationCacheTTL  time.Duration
+	// lookup functions
+	lookupKinds               string
+	lookupInformerIdleTimeout time.Duration
 )
 
 func initLoggingFlags() {
@@ -63,6 +72,20 @@ func initKubeconfigFlags() {
 	flag.IntVar(&clientRateLimitBurst, "clientRateLimitBurst", 50, "Configure the maximum burst for throttle. Uses the client default if zero.")
 }
 
+func initResultSinksFlags() {
+	flag.StringVar(&resultSinksConfig, "resultSinksConfig", "", "Path to a file configuring the sinks policy results are sent to, results are not sent to any sink if empty.")
+}
+
+func initEvaluationCacheFlags() {
+	flag.IntVar(&evaluationCacheSize, "evaluationCacheSize", 0, "Maximum number of policy evaluation results reused for resources with unchanged content, results are not cached if 0.")
+	flag.DurationVar(&evaluationCacheTTL, "evaluationCacheTTL", time.Minute, "Duration policy evaluation results are reused for.")
+}
+
+func initLookupFunctionsFlags() {
+	flag.StringVar(&lookupKinds, "lookupKinds", "", "Comma separated list of the kinds the lookup and list functions can read, given as apiVersion/kind with wildcards (e.g. v1/ConfigMap,apps/v1/*), the functions are not available if empty.")
+	flag.DurationVar(&lookupInformerIdleTimeout, "lookupInformerIdleTimeout", 30*time.Minute, "Duration after which the informers of the kinds no longer read by the lookup and list functions are stopped.")
+}
+
 func InitFlags(config Configuration) {
 	// logging
 	initLoggingFlags()
@@ -82,6 +105,18 @@ func InitFlags(config Configuration) {
 	if config.UsesKubeconfig() {
 		initKubeconfigFlags()
 	}
+	// result sinks
+	if config.UsesResultSinks() {
+		initResultSinksFlags()
+	}
+	// evaluation cache
+	if config.UsesEvaluationCache() {
+		initEvaluationCacheFlags()
+	}
+	// lookup functions
+	if config.UsesLookupFunctions() {
+		initLookupFunctionsFlags()
+	}
 	for _, flagset := range config.FlagSets() {
 		flagset.VisitAll(func(f *flag.Flag) {
 			flag.CommandLine.Var(f.Value, f.Name, f.Usage)
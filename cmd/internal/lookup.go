@@ -0,0 +1,29 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+)
+
+func CreateResourceLister(ctx context.Context, logger logr.Logger, client dclient.Interface, resync time.Duration) jmespath.ResourceLister {
+	logger = logger.WithName("resource-lister").WithValues("kinds", lookupKinds, "idleTimeout", lookupInformerIdleTimeout)
+	if lookupKinds == "" {
+		return nil
+	}
+	var kinds []string
+	for _, kind := range strings.Split(lookupKinds, ",") {
+		if kind := strings.TrimSpace(kind); kind != "" {
+			kinds = append(kinds, kind)
+		}
+	}
+	logger.Info("create resource lister...")
+	lister, err := resolvers.NewInformerBasedResourceLister(ctx, client, resync, kinds, lookupInformerIdleTimeout)
+	checkError(logger, err, "failed to create informer based resource lister")
+	return lister
+}
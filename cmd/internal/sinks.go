@@ -0,0 +1,20 @@
+package internal
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/sinks"
+	"go.opentelemetry.io/otel/metric/global"
+)
+
+func CreateResultSinks(logger logr.Logger) sinks.Controller {
+	logger = logger.WithName("result-sinks").WithValues("config", resultSinksConfig)
+	if resultSinksConfig == "" {
+		return nil
+	}
+	logger.Info("create result sinks...")
+	config, err := sinks.LoadConfiguration(resultSinksConfig)
+	checkError(logger, err, "failed to load result sinks configuration")
+	controller, err := sinks.NewController(logger, global.MeterProvider(), *config)
+	checkError(logger, err, "failed to create result sinks")
+	return controller
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers/certmanager"
 	configcontroller "github.com/kyverno/kyverno/pkg/controllers/config"
+	exceptioncontroller "github.com/kyverno/kyverno/pkg/controllers/exception"
+	exceptionusagecontroller "github.com/kyverno/kyverno/pkg/controllers/exceptionusage"
 	genericwebhookcontroller "github.com/kyverno/kyverno/pkg/controllers/generic/webhook"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	openapicontroller "github.com/kyverno/kyverno/pkg/controllers/openapi"
@@ -38,6 +40,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/openapi"
 	"github.com/kyverno/kyverno/pkg/policycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/sinks"
 	"github.com/kyverno/kyverno/pkg/tls"
 	"github.com/kyverno/kyverno/pkg/toggle"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
@@ -45,9 +48,12 @@ import (
 	"github.com/kyverno/kyverno/pkg/validation/exception"
 	"github.com/kyverno/kyverno/pkg/webhooks"
 	webhooksexception "github.com/kyverno/kyverno/pkg/webhooks/exception"
+	webhookshandlers "github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	webhookspolicy "github.com/kyverno/kyverno/pkg/webhooks/policy"
 	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
+	webhooksaudit "github.com/kyverno/kyverno/pkg/webhooks/resource/audit"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
+	"go.opentelemetry.io/otel/metric/global"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	kubeinformers "k8s.io/client-go/informers"
@@ -137,6 +143,8 @@ func createNonLeaderControllers(
 
 func createrLeaderControllers(
 	admissionReports bool,
+	enablePolicyException bool,
+	exceptionRequiredApprovals int,
 	serverIP string,
 	webhookTimeout int,
 	autoUpdateWebhooks bool,
@@ -188,7 +196,7 @@ func createrLeaderControllers(
 			Rule: admissionregistrationv1.Rule{
 				APIGroups:   []string{"kyverno.io"},
 				APIVersions: []string{"v2alpha1"},
-				Resources:   []string{"policyexceptions"},
+				Resources:   []string{"policyexceptions", "policyexceptions/status"},
 			},
 			Operations: []admissionregistrationv1.OperationType{
 				admissionregistrationv1.Create,
@@ -198,37 +206,56 @@ func createrLeaderControllers(
 		genericwebhookcontroller.Fail,
 		genericwebhookcontroller.None,
 	)
-	return []internal.Controller{
-			internal.NewController(certmanager.ControllerName, certManager, certmanager.Workers),
-			internal.NewController(webhookcontroller.ControllerName, webhookController, webhookcontroller.Workers),
-			internal.NewController(exceptionWebhookControllerName, exceptionWebhookController, 1),
-		},
-		nil,
-		nil
+	leaderControllers := []internal.Controller{
+		internal.NewController(certmanager.ControllerName, certManager, certmanager.Workers),
+		internal.NewController(webhookcontroller.ControllerName, webhookController, webhookcontroller.Workers),
+		internal.NewController(exceptionWebhookControllerName, exceptionWebhookController, 1),
+	}
+	if enablePolicyException {
+		exceptionController := exceptioncontroller.NewController(
+			kyvernoClient,
+			kyvernoInformer.Kyverno().V2alpha1().PolicyExceptions(),
+			exceptionRequiredApprovals,
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(exceptioncontroller.ControllerName, exceptionController, exceptioncontroller.Workers))
+	}
+	return leaderControllers, nil, nil
 }
 
 func main() {
 	var (
 		// TODO: this has been added to backward support command line arguments
 		// will be removed in future and the configuration will be set only via configmaps
-		serverIP                   string
-		webhookTimeout             int
-		genWorkers                 int
-		maxQueuedEvents            int
-		autoUpdateWebhooks         bool
-		imagePullSecrets           string
-		imageSignatureRepository   string
-		allowInsecureRegistry      bool
-		webhookRegistrationTimeout time.Duration
-		admissionReports           bool
-		dumpPayload                bool
-		leaderElectionRetryPeriod  time.Duration
-		enablePolicyException      bool
-		exceptionNamespace         string
-		servicePort                int
+		serverIP                    string
+		webhookTimeout              int
+		genWorkers                  int
+		maxQueuedEvents             int
+		autoUpdateWebhooks          bool
+		imagePullSecrets            string
+		imageSignatureRepository    string
+		allowInsecureRegistry       bool
+		webhookRegistrationTimeout  time.Duration
+		admissionReports            bool
+		dumpPayload                 bool
+		dumpPayloadPath             string
+		dumpPayloadFormat           string
+		leaderElectionRetryPeriod   time.Duration
+		enablePolicyException       bool
+		exceptionNamespace          string
+		exceptionApproverGroups     string
+		exceptionRequiredApprovals  int
+		servicePort                 int
+		policyEvaluationWorkers     int
+		auditQueueSize              int
+		auditWorkers                int
+		maxAdmissionConcurrency     int
+		admissionQueueSize          int
+		admissionPriorityNamespaces string
 	)
 	flagset := flag.NewFlagSet("kyverno", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
+	flagset.StringVar(&dumpPayloadPath, "dumpPayloadPath", "", "Directory where admission reviews of resource requests are written, they can be replayed with the kyverno replay command. Nothing is written if empty.")
+	flagset.StringVar(&dumpPayloadFormat, "dumpPayloadFormat", webhookshandlers.DumpFormatReview, "Format of the written admission reviews, 'review' writes one file per review and 'jsonl' appends reviews to a JSON lines file.")
 	flagset.IntVar(&webhookTimeout, "webhookTimeout", webhookcontroller.DefaultWebhookTimeout, "Timeout for webhook configurations.")
 	flagset.IntVar(&genWorkers, "genWorkers", 10, "Workers for generate controller.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
@@ -244,13 +271,24 @@ func main() {
 	flagset.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
 	flagset.StringVar(&exceptionNamespace, "exceptionNamespace", "", "Configure the namespace to accept PolicyExceptions.")
 	flagset.BoolVar(&enablePolicyException, "enablePolicyException", false, "Enable PolicyException feature.")
+	flagset.StringVar(&exceptionApproverGroups, "exceptionApproverGroups", "", "Comma separated list of groups allowed to approve PolicyExceptions, required when exceptionRequiredApprovals is set.")
+	flagset.IntVar(&exceptionRequiredApprovals, "exceptionRequiredApprovals", 0, "Number of approvals required for a PolicyException to take effect, approvals are not required if 0.")
 	flagset.IntVar(&servicePort, "servicePort", 443, "Port used by the Kyverno Service resource and for webhook configurations.")
+	flagset.IntVar(&policyEvaluationWorkers, "policyEvaluationWorkers", 1, "Maximum number of validation and image verification policies evaluated concurrently for a single admission request, policies are evaluated sequentially if 1.")
+	flagset.IntVar(&auditQueueSize, "auditQueueSize", webhooksaudit.DefaultQueueSize, "Maximum number of admission requests waiting to be audited, requests are dropped when the queue is full.")
+	flagset.IntVar(&auditWorkers, "auditWorkers", webhooksaudit.DefaultWorkers, "Workers auditing admission requests.")
+	flagset.IntVar(&maxAdmissionConcurrency, "maxAdmissionConcurrency", 0, "Maximum number of resource admission requests processed concurrently, the number of requests is not limited if 0.")
+	flagset.IntVar(&admissionQueueSize, "admissionQueueSize", 100, "Maximum number of resource admission requests waiting to be processed when maxAdmissionConcurrency is reached, requests in excess are answered immediately according to the webhook failure policy.")
+	flagset.StringVar(&admissionPriorityNamespaces, "admissionPriorityNamespaces", "kube-system", "Comma separated list of namespaces (wildcards are supported) whose admission requests are processed before other waiting requests.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
 		internal.WithTracing(),
 		internal.WithMetrics(),
 		internal.WithKubeconfig(),
+		internal.WithResultSinks(),
+		internal.WithEvaluationCache(),
+		internal.WithLookupFunctions(),
 		internal.WithFlagSets(flagset),
 	)
 	// parse flags
@@ -265,6 +303,14 @@ func main() {
 	defer sdown()
 	// show version
 	showWarnings(logger)
+	if dumpPayloadFormat != webhookshandlers.DumpFormatReview && dumpPayloadFormat != webhookshandlers.DumpFormatJSONLines {
+		logger.Error(fmt.Errorf("unsupported format %s", dumpPayloadFormat), "invalid dumpPayloadFormat flag")
+		os.Exit(1)
+	}
+	if exceptionRequiredApprovals > 0 && exceptionApproverGroups == "" {
+		logger.Error(errors.New("approver groups are required when approvals are required"), "invalid exceptionApproverGroups flag")
+		os.Exit(1)
+	}
 	// create instrumented clients
 	kubeClient := internal.CreateKubernetesClient(logger, kubeclient.WithMetrics(metricsConfig, metrics.KubeClient), kubeclient.WithTracing())
 	leaderElectionClient := internal.CreateKubernetesClient(logger, kubeclient.WithMetrics(metricsConfig, metrics.KubeClient), kubeclient.WithTracing())
@@ -316,6 +362,7 @@ func main() {
 		logger.Error(err, "failed to create config map resolver")
 		os.Exit(1)
 	}
+	resourceLister := internal.CreateResourceLister(signalCtx, logger, dClient, resyncPeriod)
 	configuration, err := config.NewConfiguration(kubeClient)
 	if err != nil {
 		logger.Error(err, "failed to initialize configuration")
@@ -397,6 +444,8 @@ func main() {
 			// create leader controllers
 			leaderControllers, warmup, err := createrLeaderControllers(
 				admissionReports,
+				enablePolicyException,
+				exceptionRequiredApprovals,
 				serverIP,
 				webhookTimeout,
 				autoUpdateWebhooks,
@@ -470,12 +519,37 @@ func main() {
 		} else {
 			exceptionsLister = lister
 		}
+		exceptionsLister = engine.ApprovedExceptions(exceptionsLister, exceptionRequiredApprovals)
+	}
+	var exceptionUsage exceptionusagecontroller.Recorder
+	if enablePolicyException {
+		exceptionUsageController := exceptionusagecontroller.NewController(
+			kyvernoClient,
+			kyvernoInformer.Kyverno().V2alpha1().PolicyExceptions().Lister(),
+			metricsConfig,
+			exceptionusagecontroller.DefaultFlushInterval,
+		)
+		internal.NewController(exceptionusagecontroller.ControllerName, exceptionUsageController, exceptionusagecontroller.Workers).Run(signalCtx, logger.WithName("controllers"), &wg)
+		exceptionUsage = exceptionUsageController
 	}
+	resultSinks := internal.CreateResultSinks(logger)
+	if resultSinks != nil {
+		internal.NewController(sinks.ControllerName, resultSinks, sinks.Workers).Run(signalCtx, logger.WithName("controllers"), &wg)
+	}
+	auditQueue := webhooksaudit.NewController(logger.WithName(webhooksaudit.ControllerName), global.MeterProvider(), auditQueueSize)
+	internal.NewController(webhooksaudit.ControllerName, auditQueue, auditWorkers).Run(signalCtx, logger.WithName("controllers"), &wg)
+	// create engine
+	eng := internal.NewEngine(
+		logger,
+		configuration,
+		rclient,
+		engine.NewExceptionSelector(exceptionsLister),
+		resourceLister,
+	)
 	resourceHandlers := webhooksresource.NewHandlers(
-		engine.LegacyContextLoaderFactory(rclient),
+		eng,
 		dClient,
 		kyvernoClient,
-		rclient,
 		configuration,
 		metricsConfig,
 		policyCache,
@@ -484,15 +558,24 @@ func main() {
 		kubeInformer.Rbac().V1().RoleBindings().Lister(),
 		kubeInformer.Rbac().V1().ClusterRoleBindings().Lister(),
 		kyvernoInformer.Kyverno().V1beta1().UpdateRequests().Lister().UpdateRequests(config.KyvernoNamespace()),
-		exceptionsLister,
 		urgen,
 		eventGenerator,
 		openApiManager,
 		admissionReports,
+		exceptionUsage,
+		resultSinks,
+		policyEvaluationWorkers,
+		auditQueue,
 	)
+	var approverGroups []string
+	if exceptionApproverGroups != "" {
+		approverGroups = strings.Split(exceptionApproverGroups, ",")
+	}
 	exceptionHandlers := webhooksexception.NewHandlers(exception.ValidationOptions{
-		Enabled:   enablePolicyException,
-		Namespace: exceptionNamespace,
+		Enabled:           enablePolicyException,
+		Namespace:         exceptionNamespace,
+		ApproverGroups:    approverGroups,
+		RequiredApprovals: exceptionRequiredApprovals,
 	})
 	server := webhooks.NewServer(
 		policyHandlers,
@@ -502,7 +585,16 @@ func main() {
 		metricsConfig,
 		webhooks.DebugModeOptions{
 			DumpPayload: dumpPayload,
+			DumpPath:    dumpPayloadPath,
+			DumpFormat:  dumpPayloadFormat,
 		},
+		webhookshandlers.NewLimiter(
+			logger.WithName("limiter"),
+			global.MeterProvider(),
+			maxAdmissionConcurrency,
+			admissionQueueSize,
+			strings.Split(admissionPriorityNamespaces, ",")...,
+		),
 		func() ([]byte, []byte, error) {
 			secret, err := secretLister.Get(tls.GenerateTLSPairSecretName())
 			if err != nil {
@@ -19,6 +19,7 @@ import (
 	kyvernoclient "github.com/kyverno/kyverno/pkg/clients/kyverno"
 	metadataclient "github.com/kyverno/kyverno/pkg/clients/metadata"
 	"github.com/kyverno/kyverno/pkg/config"
+	exceptionusagecontroller "github.com/kyverno/kyverno/pkg/controllers/exceptionusage"
 	admissionreportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/admission"
 	aggregatereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/aggregate"
 	backgroundscancontroller "github.com/kyverno/kyverno/pkg/controllers/report/background"
@@ -27,11 +28,13 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/sinks"
 	kubeinformers "k8s.io/client-go/informers"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
@@ -67,6 +70,7 @@ func setupCosign(logger logr.Logger, imageSignatureRepository string) {
 }
 
 func createReportControllers(
+	logger logr.Logger,
 	backgroundScan bool,
 	admissionReports bool,
 	reportsChunkSize int,
@@ -78,9 +82,13 @@ func createReportControllers(
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	configMapResolver engineapi.ConfigmapResolver,
+	resourceLister jmespath.ResourceLister,
 	backgroundScanInterval time.Duration,
+	exceptionRequiredApprovals int,
 	configuration config.Configuration,
+	metricsConfig metrics.MetricsConfigManager,
 	eventGenerator event.Interface,
+	resultSinks sinks.Publisher,
 ) ([]internal.Controller, func(context.Context) error) {
 	var ctrls []internal.Controller
 	var warmups []func(context.Context) error
@@ -124,18 +132,35 @@ func createReportControllers(
 			))
 		}
 		if backgroundScan {
+			exceptionUsageController := exceptionusagecontroller.NewController(
+				kyvernoClient,
+				kyvernoV2Alpha1.PolicyExceptions().Lister(),
+				metricsConfig,
+				exceptionusagecontroller.DefaultFlushInterval,
+			)
+			ctrls = append(ctrls, internal.NewController(
+				exceptionusagecontroller.ControllerName,
+				exceptionUsageController,
+				exceptionusagecontroller.Workers,
+			))
 			ctrls = append(ctrls, internal.NewController(
 				backgroundscancontroller.ControllerName,
 				backgroundscancontroller.NewController(
 					client,
 					kyvernoClient,
-					rclient,
-					engine.LegacyContextLoaderFactory(rclient),
+					internal.NewEngine(
+						logger,
+						configuration,
+						rclient,
+						engine.NewExceptionSelector(engine.ApprovedExceptions(kyvernoV2Alpha1.PolicyExceptions().Lister(), exceptionRequiredApprovals)),
+						resourceLister,
+					),
 					metadataFactory,
 					kyvernoV1.Policies(),
 					kyvernoV1.ClusterPolicies(),
 					kubeInformer.Core().V1().Namespaces(),
-					kyvernoV2Alpha1.PolicyExceptions().Lister(),
+					exceptionUsageController,
+					resultSinks,
 					resourceReportController,
 					configMapResolver,
 					backgroundScanInterval,
@@ -157,6 +182,7 @@ func createReportControllers(
 }
 
 func createrLeaderControllers(
+	logger logr.Logger,
 	backgroundScan bool,
 	admissionReports bool,
 	reportsChunkSize int,
@@ -168,11 +194,16 @@ func createrLeaderControllers(
 	dynamicClient dclient.Interface,
 	rclient registryclient.Client,
 	configuration config.Configuration,
+	metricsConfig metrics.MetricsConfigManager,
 	eventGenerator event.Interface,
 	configMapResolver engineapi.ConfigmapResolver,
+	resourceLister jmespath.ResourceLister,
 	backgroundScanInterval time.Duration,
+	exceptionRequiredApprovals int,
+	resultSinks sinks.Publisher,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	reportControllers, warmup := createReportControllers(
+		logger,
 		backgroundScan,
 		admissionReports,
 		reportsChunkSize,
@@ -184,25 +215,30 @@ func createrLeaderControllers(
 		kubeInformer,
 		kyvernoInformer,
 		configMapResolver,
+		resourceLister,
 		backgroundScanInterval,
+		exceptionRequiredApprovals,
 		configuration,
+		metricsConfig,
 		eventGenerator,
+		resultSinks,
 	)
 	return reportControllers, warmup, nil
 }
 
 func main() {
 	var (
-		leaderElectionRetryPeriod time.Duration
-		imagePullSecrets          string
-		imageSignatureRepository  string
-		allowInsecureRegistry     bool
-		backgroundScan            bool
-		admissionReports          bool
-		reportsChunkSize          int
-		backgroundScanWorkers     int
-		backgroundScanInterval    time.Duration
-		maxQueuedEvents           int
+		leaderElectionRetryPeriod  time.Duration
+		imagePullSecrets           string
+		imageSignatureRepository   string
+		allowInsecureRegistry      bool
+		backgroundScan             bool
+		admissionReports           bool
+		reportsChunkSize           int
+		backgroundScanWorkers      int
+		backgroundScanInterval     time.Duration
+		maxQueuedEvents            int
+		exceptionRequiredApprovals int
 	)
 	flagset := flag.NewFlagSet("reports-controller", flag.ExitOnError)
 	flagset.DurationVar(&leaderElectionRetryPeriod, "leaderElectionRetryPeriod", leaderelection.DefaultRetryPeriod, "Configure leader election retry period.")
@@ -215,12 +251,16 @@ func main() {
 	flagset.IntVar(&backgroundScanWorkers, "backgroundScanWorkers", backgroundscancontroller.Workers, "Configure the number of background scan workers.")
 	flagset.DurationVar(&backgroundScanInterval, "backgroundScanInterval", time.Hour, "Configure background scan interval.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
+	flagset.IntVar(&exceptionRequiredApprovals, "exceptionRequiredApprovals", 0, "Number of approvals required for a PolicyException to take effect, approvals are not required if 0.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
 		internal.WithMetrics(),
 		internal.WithTracing(),
 		internal.WithKubeconfig(),
+		internal.WithResultSinks(),
+		internal.WithEvaluationCache(),
+		internal.WithLookupFunctions(),
 		internal.WithFlagSets(flagset),
 	)
 	// parse flags
@@ -279,6 +319,7 @@ func main() {
 		logger.Error(err, "failed to create config map resolver")
 		os.Exit(1)
 	}
+	resourceLister := internal.CreateResourceLister(ctx, logger, dClient, resyncPeriod)
 	configuration, err := config.NewConfiguration(kubeClient)
 	if err != nil {
 		logger.Error(err, "failed to initialize configuration")
@@ -298,6 +339,12 @@ func main() {
 	}
 	// start event generator
 	go eventGenerator.Run(ctx, 3)
+	// start result sinks
+	var wg sync.WaitGroup
+	resultSinks := internal.CreateResultSinks(logger)
+	if resultSinks != nil {
+		internal.NewController(sinks.ControllerName, resultSinks, sinks.Workers).Run(ctx, logger.WithName("controllers"), &wg)
+	}
 	// setup leader election
 	le, err := leaderelection.New(
 		logger.WithName("leader-election"),
@@ -315,6 +362,7 @@ func main() {
 			metadataInformer := metadatainformers.NewSharedInformerFactory(metadataClient, 15*time.Minute)
 			// create leader controllers
 			leaderControllers, warmup, err := createrLeaderControllers(
+				logger,
 				backgroundScan,
 				admissionReports,
 				reportsChunkSize,
@@ -326,9 +374,13 @@ func main() {
 				dClient,
 				rclient,
 				configuration,
+				metricsConfig,
 				eventGenerator,
 				configMapResolver,
+				resourceLister,
 				backgroundScanInterval,
+				exceptionRequiredApprovals,
+				resultSinks,
 			)
 			if err != nil {
 				logger.Error(err, "failed to create leader controllers")
@@ -365,6 +417,8 @@ func main() {
 	for {
 		select {
 		case <-ctx.Done():
+			// wait for result sinks to flush pending records
+			wg.Wait()
 			return
 		default:
 			le.Run(ctx)
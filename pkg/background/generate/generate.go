@@ -42,7 +42,7 @@ type GenerateController struct {
 	client        dclient.Interface
 	kyvernoClient versioned.Interface
 	statusControl common.StatusControlInterface
-	contextLoader engine.ContextLoaderFactory
+	engine        engineapi.Engine
 
 	// listers
 	urLister      kyvernov1beta1listers.UpdateRequestNamespaceLister
@@ -62,7 +62,7 @@ func NewGenerateController(
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
 	statusControl common.StatusControlInterface,
-	contextLoader engine.ContextLoaderFactory,
+	engine engineapi.Engine,
 	policyLister kyvernov1listers.ClusterPolicyLister,
 	npolicyLister kyvernov1listers.PolicyLister,
 	urLister kyvernov1beta1listers.UpdateRequestNamespaceLister,
@@ -74,7 +74,7 @@ func NewGenerateController(
 ) *GenerateController {
 	c := GenerateController{
 		client:                 client,
-		contextLoader:          contextLoader,
+		engine:                 engine,
 		kyvernoClient:          kyvernoClient,
 		statusControl:          statusControl,
 		policyLister:           policyLister,
@@ -200,7 +200,7 @@ func (c *GenerateController) applyGenerate(resource unstructured.Unstructured, u
 	}
 
 	// check if the policy still applies to the resource
-	engineResponse := engine.GenerateResponse(c.contextLoader, policyContext, ur)
+	engineResponse := c.engine.GenerateResponse(policyContext, ur)
 	if len(engineResponse.PolicyResponse.Rules) == 0 {
 		logger.V(4).Info(doesNotApply)
 		return nil, false, errors.New(doesNotApply)
@@ -345,8 +345,11 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 			break
 		}
 
-		// add configmap json data to context
-		if err := engine.LoadContext(context.TODO(), c.contextLoader, rule.Context, policyContext, rule.Name); err != nil {
+		// add configmap json data to context, the rule timeout bounds loading the context
+		ctx, cancel := engine.WithRuleTimeout(context.TODO(), &rule)
+		err = c.engine.ContextLoader(policyContext, rule.Name).Load(ctx, rule.Context, policyContext.JSONContext())
+		cancel()
+		if err != nil {
 			log.Error(err, "cannot add configmaps to context")
 			return nil, processExisting, err
 		}
@@ -828,10 +831,10 @@ func (c *GenerateController) ApplyResource(resource *unstructured.Unstructured)
 }
 
 // NewGenerateControllerWithOnlyClient returns an instance of Controller with only the client.
-func NewGenerateControllerWithOnlyClient(client dclient.Interface, contextLoader engine.ContextLoaderFactory) *GenerateController {
+func NewGenerateControllerWithOnlyClient(client dclient.Interface, engine engineapi.Engine) *GenerateController {
 	c := GenerateController{
-		client:        client,
-		contextLoader: contextLoader,
+		client: client,
+		engine: engine,
 	}
 	return &c
 }
@@ -0,0 +1,115 @@
+package exception
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "exception-controller"
+	maxRetries     = 10
+)
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+
+	// listers
+	polexLister kyvernov2alpha1listers.PolicyExceptionLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// number of approvals required for an exception to take effect
+	requiredApprovals int
+}
+
+// NewController returns a controller maintaining the ready condition of policy exceptions
+// according to their validity window and approvals
+func NewController(
+	kyvernoClient versioned.Interface,
+	polexInformer kyvernov2alpha1informers.PolicyExceptionInformer,
+	requiredApprovals int,
+) controllers.Controller {
+	c := controller{
+		kyvernoClient:     kyvernoClient,
+		polexLister:       polexInformer.Lister(),
+		queue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		requiredApprovals: requiredApprovals,
+	}
+	controllerutils.AddDefaultEventHandlers(logger, polexInformer.Informer(), c.queue)
+	return &c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	polex, err := c.polexLister.PolicyExceptions(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	now := time.Now()
+	ready, reason, message := readiness(polex, now, c.requiredApprovals)
+	_, err = controllerutils.UpdateStatus(
+		ctx,
+		polex,
+		c.kyvernoClient.KyvernoV2alpha1().PolicyExceptions(namespace),
+		func(polex *kyvernov2alpha1.PolicyException) error {
+			polex.GetStatus().SetReady(ready, reason, message)
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	// requeue when the exception validity window starts or ends
+	if next := nextTransition(polex, now); next != nil {
+		logger.V(4).Info("policy exception status will be refreshed", "after", next.Sub(now))
+		c.queue.AddAfter(key, next.Sub(now))
+	}
+	return nil
+}
+
+func readiness(polex *kyvernov2alpha1.PolicyException, now time.Time, requiredApprovals int) (bool, string, string) {
+	spec := polex.Spec
+	if spec.NotBefore != nil && now.Before(spec.NotBefore.Time) {
+		return false, kyvernov2alpha1.PolicyExceptionReasonNotYetValid, "policy exception is not valid before " + spec.NotBefore.UTC().Format(time.RFC3339)
+	}
+	if spec.ExpiresAt != nil && !now.Before(spec.ExpiresAt.Time) {
+		return false, kyvernov2alpha1.PolicyExceptionReasonExpired, "policy exception expired at " + spec.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if !polex.IsApproved(requiredApprovals) {
+		approvals := polex.Status.CountApprovals(polex.Generation, polex.GetAuthor())
+		return false, kyvernov2alpha1.PolicyExceptionReasonPending, fmt.Sprintf("policy exception is waiting for approvals (%d/%d)", approvals, requiredApprovals)
+	}
+	return true, kyvernov2alpha1.PolicyExceptionReasonActive, "policy exception is in effect"
+}
+
+func nextTransition(polex *kyvernov2alpha1.PolicyException, now time.Time) *time.Time {
+	spec := polex.Spec
+	if spec.NotBefore != nil && now.Before(spec.NotBefore.Time) {
+		return &spec.NotBefore.Time
+	}
+	if spec.ExpiresAt != nil && now.Before(spec.ExpiresAt.Time) {
+		return &spec.ExpiresAt.Time
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package exceptionusage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "exception-usage-controller"
+	maxRetries     = 10
+	// DefaultFlushInterval is the default interval at which usage is written to policy exceptions status
+	DefaultFlushInterval = 30 * time.Second
+)
+
+// Recorder records policy exceptions usage
+type Recorder interface {
+	// Record records the policy exceptions that caused rules to be skipped in the given engine responses
+	Record(ctx context.Context, cause metrics.RuleExecutionCause, responses ...*engineapi.EngineResponse)
+}
+
+type Controller interface {
+	controllers.Controller
+	Recorder
+}
+
+type usage struct {
+	matches   int64
+	lastMatch time.Time
+}
+
+type controller struct {
+	// clients
+	kyvernoClient versioned.Interface
+
+	// listers
+	polexLister kyvernov2alpha1listers.PolicyExceptionLister
+
+	// queue
+	queue workqueue.RateLimitingInterface
+
+	// config
+	metricsConfig metrics.MetricsConfigManager
+	flushInterval time.Duration
+
+	// usage not yet written to policy exceptions status
+	lock    sync.Mutex
+	pending map[string]usage
+}
+
+// NewController returns a controller recording policy exceptions usage in their status,
+// usage is accumulated in memory and written at most once per flush interval
+func NewController(
+	kyvernoClient versioned.Interface,
+	polexLister kyvernov2alpha1listers.PolicyExceptionLister,
+	metricsConfig metrics.MetricsConfigManager,
+	flushInterval time.Duration,
+) Controller {
+	return &controller{
+		kyvernoClient: kyvernoClient,
+		polexLister:   polexLister,
+		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
+		metricsConfig: metricsConfig,
+		flushInterval: flushInterval,
+		pending:       map[string]usage{},
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) Record(ctx context.Context, cause metrics.RuleExecutionCause, responses ...*engineapi.EngineResponse) {
+	now := time.Now()
+	for _, response := range responses {
+		if response == nil || response.Policy == nil {
+			continue
+		}
+		policyName, policyNamespace, policyType, _, _, _ := metrics.GetPolicyInfos(response.Policy)
+		if policyType == metrics.Cluster {
+			policyNamespace = "-"
+		}
+		resource := response.PolicyResponse.Resource
+		for _, rule := range response.PolicyResponse.Rules {
+			if rule.Status != engineapi.RuleStatusSkip {
+				continue
+			}
+			for _, key := range rule.Exceptions {
+				c.add(key, usage{matches: 1, lastMatch: now})
+				if c.metricsConfig != nil && c.metricsConfig.Config().CheckNamespace(policyNamespace) {
+					namespace, name, err := cache.SplitMetaNamespaceKey(key)
+					if err != nil {
+						logger.Error(err, "failed to parse policy exception key", "key", key)
+						continue
+					}
+					c.metricsConfig.RecordPolicyExceptionMatches(ctx, namespace, name, policyType, policyNamespace, policyName, rule.Name, resource.Kind, resource.Namespace, cause)
+				}
+			}
+		}
+	}
+}
+
+func (c *controller) add(key string, u usage) {
+	c.restore(key, u)
+	// delayed items are deduplicated, usage is flushed once per interval at most
+	c.queue.AddAfter(key, c.flushInterval)
+}
+
+func (c *controller) take(key string) (usage, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	u, ok := c.pending[key]
+	delete(c.pending, key)
+	return u, ok
+}
+
+func (c *controller) restore(key string, u usage) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	current := c.pending[key]
+	current.matches += u.matches
+	if current.lastMatch.Before(u.lastMatch) {
+		current.lastMatch = u.lastMatch
+	}
+	c.pending[key] = current
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
+	u, ok := c.take(key)
+	if !ok || u.matches == 0 {
+		return nil
+	}
+	polex, err := c.polexLister.PolicyExceptions(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		c.restore(key, u)
+		return err
+	}
+	_, err = controllerutils.UpdateStatus(
+		ctx,
+		polex,
+		c.kyvernoClient.KyvernoV2alpha1().PolicyExceptions(namespace),
+		func(polex *kyvernov2alpha1.PolicyException) error {
+			polex.GetStatus().RecordMatches(u.matches, u.lastMatch)
+			return nil
+		},
+	)
+	if err != nil {
+		c.restore(key, u)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package exceptionusage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newResponse(name string, status engineapi.RuleStatus, exceptions ...string) *engineapi.EngineResponse {
+	return &engineapi.EngineResponse{
+		Policy: &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}},
+		PolicyResponse: engineapi.PolicyResponse{
+			Resource: engineapi.ResourceSpec{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: name},
+			Rules: []engineapi.RuleResponse{{
+				Name:       "rule",
+				Status:     status,
+				Exceptions: exceptions,
+			}},
+		},
+	}
+}
+
+func Test_controller_Record(t *testing.T) {
+	polex := &kyvernov2alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: "polex", Namespace: "default"},
+		Status: kyvernov2alpha1.PolicyExceptionStatus{
+			MatchCount: 1,
+		},
+	}
+	client := fake.NewSimpleClientset(polex)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.NilError(t, indexer.Add(polex))
+	c := NewController(client, kyvernov2alpha1listers.NewPolicyExceptionLister(indexer), metrics.NewFakeMetricsConfig(), time.Hour).(*controller)
+	ctx := context.Background()
+	c.Record(ctx, metrics.AdmissionRequest,
+		newResponse("a", engineapi.RuleStatusSkip, "default/polex"),
+		newResponse("b", engineapi.RuleStatusSkip, "default/polex"),
+		newResponse("c", engineapi.RuleStatusPass),
+		nil,
+	)
+	c.Record(ctx, metrics.BackgroundScan,
+		newResponse("a", engineapi.RuleStatusSkip, "default/polex"),
+	)
+	assert.Equal(t, c.pending["default/polex"].matches, int64(3))
+	// flush usage
+	assert.NilError(t, c.reconcile(ctx, logging.GlobalLogger(), "default/polex", "default", "polex"))
+	assert.Equal(t, len(c.pending), 0)
+	updated, err := client.KyvernoV2alpha1().PolicyExceptions("default").Get(ctx, "polex", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, updated.Status.MatchCount, int64(4))
+	assert.Assert(t, updated.Status.LastMatchTime != nil)
+	// usage of deleted exceptions is dropped
+	c.Record(ctx, metrics.BackgroundScan, newResponse("a", engineapi.RuleStatusSkip, "default/deleted"))
+	assert.NilError(t, c.reconcile(ctx, logging.GlobalLogger(), "default/deleted", "default", "deleted"))
+	assert.Equal(t, len(c.pending), 0)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.opentelemetry.io/otel/metric/global"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -57,6 +58,9 @@ type controller struct {
 	// cache
 	metadataCache resource.MetadataCache
 
+	// summary
+	summary *summary
+
 	chunkSize int
 }
 
@@ -93,6 +97,7 @@ func NewController(
 		cbgscanrLister: cbgscanrInformer.Lister(),
 		queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), ControllerName),
 		metadataCache:  metadataCache,
+		summary:        newSummary(getSummaryMetrics(global.MeterProvider())),
 		chunkSize:      chunkSize,
 	}
 	controllerutils.AddDelayedExplicitEventHandlers(logger, polrInformer.Informer(), c.queue, enqueueDelay, keyFunc)
@@ -121,6 +126,12 @@ func NewController(
 }
 
 func (c *controller) Run(ctx context.Context, workers int) {
+	// counts are seeded from the existing reports so that results already reported are not counted as added
+	if err := c.seedSummary(ctx); err != nil {
+		logger.Error(err, "failed to seed policy report summary from existing reports")
+	}
+	c.summary.start()
+	defer c.summary.stop()
 	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
 }
 
@@ -362,11 +373,37 @@ func (c *controller) getPolicyReports(ctx context.Context, namespace string) ([]
 	return reports, nil
 }
 
+func (c *controller) seedSummary(ctx context.Context) error {
+	results := map[string][]policyreportv1alpha2.PolicyReportResult{}
+	cpolrs, err := c.client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range cpolrs.Items {
+		if controllerutils.IsManagedByKyverno(&cpolrs.Items[i]) {
+			results[""] = append(results[""], cpolrs.Items[i].Results...)
+		}
+	}
+	polrs, err := c.client.Wgpolicyk8sV1alpha2().PolicyReports(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range polrs.Items {
+		if controllerutils.IsManagedByKyverno(&polrs.Items[i]) {
+			namespace := polrs.Items[i].Namespace
+			results[namespace] = append(results[namespace], polrs.Items[i].Results...)
+		}
+	}
+	c.summary.seed(results)
+	return nil
+}
+
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
 	results, policyMap, err := c.buildReportsResults(ctx, key)
 	if err != nil {
 		return err
 	}
+	c.summary.update(ctx, key, results)
 	policyReports, err := c.getPolicyReports(ctx, key)
 	if err != nil {
 		return err
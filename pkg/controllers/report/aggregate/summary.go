@@ -0,0 +1,262 @@
+package aggregate
+
+import (
+	"context"
+	"sync"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/asyncint64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+)
+
+type summaryKey struct {
+	policy string
+	rule   string
+	result policyreportv1alpha2.PolicyResult
+}
+
+// summary maintains policy reports results counts per namespace, it is updated incrementally
+// every time the reports of a namespace are aggregated and exposed as a metric set.
+// Counts are exposed as gauges, trends are exposed as counters of the results added and removed
+// by each update so that their rate shows whether violations are growing or being fixed.
+type summary struct {
+	lock       sync.RWMutex
+	namespaces map[string]map[summaryKey]int64
+	// seeded is true when the counts were loaded from the existing reports, when they were not
+	// the first update of a namespace only sets its counts and no changes are recorded
+	seeded     bool
+	reconciled sets.Set[string]
+	metrics    *summaryMetrics
+}
+
+// summaryMetrics holds the summary instruments, the controller is created again every time leadership
+// is acquired so the instruments and their callback are registered once per process and observe the
+// summary of the running controller only
+type summaryMetrics struct {
+	lock   sync.RWMutex
+	active *summary
+
+	// instruments
+	clusterSummary   asyncint64.Gauge
+	namespaceSummary asyncint64.Gauge
+	resultsAdded     syncint64.Counter
+	resultsRemoved   syncint64.Counter
+}
+
+var (
+	summaryMetricsOnce   sync.Once
+	globalSummaryMetrics *summaryMetrics
+)
+
+func newSummary(metrics *summaryMetrics) *summary {
+	return &summary{
+		namespaces: map[string]map[summaryKey]int64{},
+		reconciled: sets.New[string](),
+		metrics:    metrics,
+	}
+}
+
+// getSummaryMetrics returns the summary metrics of the process, they are registered on first call
+func getSummaryMetrics(meterProvider metric.MeterProvider) *summaryMetrics {
+	summaryMetricsOnce.Do(func() {
+		globalSummaryMetrics = newSummaryMetrics(meterProvider)
+	})
+	return globalSummaryMetrics
+}
+
+func newSummaryMetrics(meterProvider metric.MeterProvider) *summaryMetrics {
+	meter := meterProvider.Meter(metrics.MeterName)
+	clusterSummary, err := meter.AsyncInt64().Gauge(
+		"kyverno_policy_report_summary",
+		instrument.WithDescription("can be used to track the number of policy report results per policy, rule and result across all namespaces"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_report_summary")
+		return nil
+	}
+	namespaceSummary, err := meter.AsyncInt64().Gauge(
+		"kyverno_policy_report_namespace_summary",
+		instrument.WithDescription("can be used to track the number of policy report results per namespace, policy, rule and result, for example to find the top violating namespaces"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_report_namespace_summary")
+		return nil
+	}
+	resultsAdded, err := meter.SyncInt64().Counter(
+		"kyverno_policy_report_results_added",
+		instrument.WithDescription("can be used to track the trend of policy report results per policy, rule and result, counts the results added to reports"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_report_results_added")
+		return nil
+	}
+	resultsRemoved, err := meter.SyncInt64().Counter(
+		"kyverno_policy_report_results_removed",
+		instrument.WithDescription("can be used to track the trend of policy report results per policy, rule and result, counts the results removed from reports"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_policy_report_results_removed")
+		return nil
+	}
+	m := &summaryMetrics{
+		clusterSummary:   clusterSummary,
+		namespaceSummary: namespaceSummary,
+		resultsAdded:     resultsAdded,
+		resultsRemoved:   resultsRemoved,
+	}
+	if err := meter.RegisterCallback([]instrument.Asynchronous{clusterSummary, namespaceSummary}, m.report); err != nil {
+		logger.Error(err, "Failed to register callback")
+	}
+	return m
+}
+
+// start makes the summary observed by the metrics callback
+func (s *summary) start() {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.lock.Lock()
+	defer s.metrics.lock.Unlock()
+	s.metrics.active = s
+}
+
+// stop detaches the summary from the metrics callback, counts are no longer reported once the controller stopped
+func (s *summary) stop() {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.lock.Lock()
+	defer s.metrics.lock.Unlock()
+	if s.metrics.active == s {
+		s.metrics.active = nil
+	}
+}
+
+// seed sets the results counts from the existing reports without recording changes,
+// results are grouped per namespace and cluster scoped results use an empty namespace
+func (s *summary) seed(results map[string][]policyreportv1alpha2.PolicyReportResult) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for namespace, results := range results {
+		if counts := countResults(results); len(counts) != 0 {
+			s.namespaces[namespace] = counts
+		}
+	}
+	s.seeded = true
+}
+
+func countResults(results []policyreportv1alpha2.PolicyReportResult) map[summaryKey]int64 {
+	counts := map[summaryKey]int64{}
+	for _, result := range results {
+		counts[summaryKey{policy: result.Policy, rule: result.Rule, result: result.Result}]++
+	}
+	return counts
+}
+
+// update replaces the results counts of the given namespace, cluster scoped results use an empty namespace.
+// It returns the changes of the counts compared to the previous update of the namespace.
+func (s *summary) update(ctx context.Context, namespace string, results []policyreportv1alpha2.PolicyReportResult) map[summaryKey]int64 {
+	counts := countResults(results)
+	s.lock.Lock()
+	previous := s.namespaces[namespace]
+	if len(counts) == 0 {
+		delete(s.namespaces, namespace)
+	} else {
+		s.namespaces[namespace] = counts
+	}
+	// without seed the previous counts of a namespace are unknown until it is reconciled once
+	known := s.seeded || s.reconciled.Has(namespace)
+	if !s.seeded {
+		s.reconciled.Insert(namespace)
+	}
+	s.lock.Unlock()
+	changes := map[summaryKey]int64{}
+	if !known {
+		return changes
+	}
+	for key, count := range counts {
+		if delta := count - previous[key]; delta != 0 {
+			changes[key] = delta
+		}
+	}
+	for key, count := range previous {
+		if _, ok := counts[key]; !ok {
+			changes[key] = -count
+		}
+	}
+	s.recordChanges(ctx, changes)
+	return changes
+}
+
+func (s *summary) recordChanges(ctx context.Context, changes map[summaryKey]int64) {
+	if s.metrics == nil {
+		return
+	}
+	for key, delta := range changes {
+		if delta > 0 {
+			s.metrics.resultsAdded.Add(ctx, delta, key.attributes()...)
+		} else {
+			s.metrics.resultsRemoved.Add(ctx, -delta, key.attributes()...)
+		}
+	}
+}
+
+// totals returns the results counts across all namespaces
+func (s *summary) totals() map[summaryKey]int64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	totals := map[summaryKey]int64{}
+	for _, counts := range s.namespaces {
+		for key, count := range counts {
+			totals[key] += count
+		}
+	}
+	return totals
+}
+
+func (m *summaryMetrics) report(ctx context.Context) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if m.active != nil {
+		m.active.report(ctx, m.clusterSummary, m.namespaceSummary)
+	}
+}
+
+func (s *summary) report(ctx context.Context, clusterSummary, namespaceSummary asyncint64.Gauge) {
+	for key, count := range s.totals() {
+		clusterSummary.Observe(ctx, count, key.attributes()...)
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for namespace, counts := range s.namespaces {
+		if namespace == "" {
+			namespace = "-"
+		}
+		for key, count := range counts {
+			namespaceSummary.Observe(ctx, count, append(key.attributes(), attribute.String("resource_namespace", namespace))...)
+		}
+	}
+}
+
+func (k summaryKey) attributes() []attribute.KeyValue {
+	// results reference policies by key, namespace is empty for cluster policies
+	policyNamespace, policyName, err := cache.SplitMetaNamespaceKey(k.policy)
+	if err != nil {
+		policyName = k.policy
+	}
+	if policyNamespace == "" {
+		policyNamespace = "-"
+	}
+	return []attribute.KeyValue{
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("rule_name", k.rule),
+		attribute.String("rule_result", string(k.result)),
+	}
+}
@@ -0,0 +1,112 @@
+package aggregate
+
+import (
+	"context"
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"gotest.tools/assert"
+)
+
+func newResult(policy, rule string, result policyreportv1alpha2.PolicyResult) policyreportv1alpha2.PolicyReportResult {
+	return policyreportv1alpha2.PolicyReportResult{Policy: policy, Rule: rule, Result: result}
+}
+
+func Test_summary(t *testing.T) {
+	ctx := context.Background()
+	s := newSummary(nil)
+	s.update(ctx, "foo", []policyreportv1alpha2.PolicyReportResult{
+		newResult("require-labels", "check-labels", policyreportv1alpha2.StatusPass),
+		newResult("require-labels", "check-labels", policyreportv1alpha2.StatusFail),
+		newResult("require-labels", "check-labels", policyreportv1alpha2.StatusFail),
+	})
+	s.update(ctx, "bar", []policyreportv1alpha2.PolicyReportResult{
+		newResult("require-labels", "check-labels", policyreportv1alpha2.StatusFail),
+		newResult("bar/local", "rule", policyreportv1alpha2.StatusWarn),
+	})
+	s.update(ctx, "", []policyreportv1alpha2.PolicyReportResult{
+		newResult("require-labels", "check-labels", policyreportv1alpha2.StatusPass),
+	})
+	assert.DeepEqual(t, s.totals(), map[summaryKey]int64{
+		{policy: "require-labels", rule: "check-labels", result: policyreportv1alpha2.StatusPass}: 2,
+		{policy: "require-labels", rule: "check-labels", result: policyreportv1alpha2.StatusFail}: 3,
+		{policy: "bar/local", rule: "rule", result: policyreportv1alpha2.StatusWarn}:              1,
+	})
+	// reconciling a namespace replaces its counts and returns the changes
+	changes := s.update(ctx, "foo", []policyreportv1alpha2.PolicyReportResult{
+		newResult("require-labels", "check-labels", policyreportv1alpha2.StatusPass),
+		newResult("require-labels", "other", policyreportv1alpha2.StatusPass),
+	})
+	assert.DeepEqual(t, changes, map[summaryKey]int64{
+		{policy: "require-labels", rule: "check-labels", result: policyreportv1alpha2.StatusFail}: -2,
+		{policy: "require-labels", rule: "other", result: policyreportv1alpha2.StatusPass}:        1,
+	})
+	assert.DeepEqual(t, s.totals(), map[summaryKey]int64{
+		{policy: "require-labels", rule: "check-labels", result: policyreportv1alpha2.StatusPass}: 2,
+		{policy: "require-labels", rule: "check-labels", result: policyreportv1alpha2.StatusFail}: 1,
+		{policy: "require-labels", rule: "other", result: policyreportv1alpha2.StatusPass}:        1,
+		{policy: "bar/local", rule: "rule", result: policyreportv1alpha2.StatusWarn}:              1,
+	})
+	// namespaces without results are dropped
+	s.update(ctx, "bar", nil)
+	assert.Equal(t, len(s.namespaces), 2)
+}
+
+func Test_summarySeed(t *testing.T) {
+	ctx := context.Background()
+	fail := summaryKey{policy: "require-labels", rule: "check-labels", result: policyreportv1alpha2.StatusFail}
+	pass := summaryKey{policy: "require-labels", rule: "check-labels", result: policyreportv1alpha2.StatusPass}
+	// without seed, the first update of a namespace has no known previous counts
+	s := newSummary(nil)
+	changes := s.update(ctx, "foo", []policyreportv1alpha2.PolicyReportResult{
+		newResult("require-labels", "check-labels", policyreportv1alpha2.StatusFail),
+	})
+	assert.Equal(t, len(changes), 0)
+	changes = s.update(ctx, "foo", nil)
+	assert.DeepEqual(t, changes, map[summaryKey]int64{fail: -1})
+	changes = s.update(ctx, "foo", []policyreportv1alpha2.PolicyReportResult{
+		newResult("require-labels", "check-labels", policyreportv1alpha2.StatusFail),
+	})
+	assert.DeepEqual(t, changes, map[summaryKey]int64{fail: 1})
+	// with seed, changes are computed against the existing reports
+	s = newSummary(nil)
+	s.seed(map[string][]policyreportv1alpha2.PolicyReportResult{
+		"foo": {
+			newResult("require-labels", "check-labels", policyreportv1alpha2.StatusFail),
+			newResult("require-labels", "check-labels", policyreportv1alpha2.StatusFail),
+		},
+		"bar": nil,
+	})
+	assert.DeepEqual(t, s.totals(), map[summaryKey]int64{fail: 2})
+	changes = s.update(ctx, "foo", []policyreportv1alpha2.PolicyReportResult{
+		newResult("require-labels", "check-labels", policyreportv1alpha2.StatusFail),
+		newResult("require-labels", "check-labels", policyreportv1alpha2.StatusPass),
+	})
+	assert.DeepEqual(t, changes, map[summaryKey]int64{fail: -1, pass: 1})
+	changes = s.update(ctx, "bar", []policyreportv1alpha2.PolicyReportResult{
+		newResult("require-labels", "check-labels", policyreportv1alpha2.StatusPass),
+	})
+	assert.DeepEqual(t, changes, map[summaryKey]int64{pass: 1})
+}
+
+func Test_summaryMetrics_start(t *testing.T) {
+	metrics := &summaryMetrics{}
+	first, second := newSummary(metrics), newSummary(metrics)
+	first.start()
+	assert.Equal(t, metrics.active, first)
+	// a new controller replaces the summary observed by the metrics, stopping the previous one keeps it
+	second.start()
+	first.stop()
+	assert.Equal(t, metrics.active, second)
+	second.stop()
+	assert.Assert(t, metrics.active == nil)
+}
+
+func Test_summaryKey_attributes(t *testing.T) {
+	attributes := summaryKey{policy: "bar/local", rule: "rule", result: policyreportv1alpha2.StatusWarn}.attributes()
+	assert.Equal(t, attributes[0].Value.AsString(), "bar")
+	assert.Equal(t, attributes[1].Value.AsString(), "local")
+	attributes = summaryKey{policy: "require-labels", rule: "rule", result: policyreportv1alpha2.StatusWarn}.attributes()
+	assert.Equal(t, attributes[0].Value.AsString(), "-")
+	assert.Equal(t, attributes[1].Value.AsString(), "require-labels")
+}
@@ -15,12 +15,13 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/controllers/exceptionusage"
 	"github.com/kyverno/kyverno/pkg/controllers/report/resource"
 	"github.com/kyverno/kyverno/pkg/controllers/report/utils"
-	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/event"
-	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/sinks"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -48,8 +49,7 @@ type controller struct {
 	// clients
 	client        dclient.Interface
 	kyvernoClient versioned.Interface
-	rclient       registryclient.Client
-	contextLoader engine.ContextLoaderFactory
+	engine        engineapi.Engine
 
 	// listers
 	polLister      kyvernov1listers.PolicyLister
@@ -57,7 +57,10 @@ type controller struct {
 	bgscanrLister  cache.GenericLister
 	cbgscanrLister cache.GenericLister
 	nsLister       corev1listers.NamespaceLister
-	polexLister    engine.PolicyExceptionLister
+
+	// exceptions usage and result sinks
+	exceptionUsage exceptionusage.Recorder
+	resultSink     sinks.Publisher
 
 	// queue
 	queue workqueue.RateLimitingInterface
@@ -75,13 +78,13 @@ type controller struct {
 func NewController(
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
-	rclient registryclient.Client,
-	contextLoader engine.ContextLoaderFactory,
+	engine engineapi.Engine,
 	metadataFactory metadatainformers.SharedInformerFactory,
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	nsInformer corev1informers.NamespaceInformer,
-	polexLister engine.PolicyExceptionLister,
+	exceptionUsage exceptionusage.Recorder,
+	resultSink sinks.Publisher,
 	metadataCache resource.MetadataCache,
 	informerCacheResolvers engineapi.ConfigmapResolver,
 	forceDelay time.Duration,
@@ -94,14 +97,14 @@ func NewController(
 	c := controller{
 		client:                 client,
 		kyvernoClient:          kyvernoClient,
-		rclient:                rclient,
-		contextLoader:          contextLoader,
+		engine:                 engine,
 		polLister:              polInformer.Lister(),
 		cpolLister:             cpolInformer.Lister(),
 		bgscanrLister:          bgscanr.Lister(),
 		cbgscanrLister:         cbgscanr.Lister(),
 		nsLister:               nsInformer.Lister(),
-		polexLister:            polexLister,
+		exceptionUsage:         exceptionUsage,
+		resultSink:             resultSink,
 		queue:                  queue,
 		metadataCache:          metadataCache,
 		informerCacheResolvers: informerCacheResolvers,
@@ -312,13 +315,19 @@ func (c *controller) reconcileReport(
 	// calculate necessary results
 	for _, policy := range backgroundPolicies {
 		if full || actual[reportutils.PolicyLabel(policy)] != policy.GetResourceVersion() {
-			scanner := utils.NewScanner(logger, c.contextLoader, c.client, c.rclient, c.informerCacheResolvers, c.polexLister, c.config)
+			scanner := utils.NewScanner(logger, c.engine, c.client, c.informerCacheResolvers, c.config)
 			for _, result := range scanner.ScanResource(ctx, *target, nsLabels, policy) {
 				if result.Error != nil {
 					return result.Error
 				} else {
 					ruleResults = append(ruleResults, reportutils.EngineResponseToReportResults(result.EngineResponse)...)
 					utils.GenerateEvents(logger, c.eventGen, c.config, result.EngineResponse)
+					if c.exceptionUsage != nil {
+						c.exceptionUsage.Record(ctx, metrics.BackgroundScan, result.EngineResponse)
+					}
+					if c.resultSink != nil {
+						c.resultSink.Record(ctx, metrics.BackgroundScan, result.EngineResponse)
+					}
 				}
 			}
 		}
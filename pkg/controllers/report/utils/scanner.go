@@ -10,18 +10,15 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
-	"github.com/kyverno/kyverno/pkg/registryclient"
 	"go.uber.org/multierr"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 type scanner struct {
 	logger                 logr.Logger
-	contextLoader          engine.ContextLoaderFactory
+	engine                 engineapi.Engine
 	client                 dclient.Interface
-	rclient                registryclient.Client
 	informerCacheResolvers engineapi.ConfigmapResolver
-	polexLister            engine.PolicyExceptionLister
 	excludeGroupRole       []string
 	config                 config.Configuration
 }
@@ -37,21 +34,17 @@ type Scanner interface {
 
 func NewScanner(
 	logger logr.Logger,
-	contextLoader engine.ContextLoaderFactory,
+	engine engineapi.Engine,
 	client dclient.Interface,
-	rclient registryclient.Client,
 	informerCacheResolvers engineapi.ConfigmapResolver,
-	polexLister engine.PolicyExceptionLister,
 	config config.Configuration,
 	excludeGroupRole ...string,
 ) Scanner {
 	return &scanner{
 		logger:                 logger,
-		contextLoader:          contextLoader,
+		engine:                 engine,
 		client:                 client,
-		rclient:                rclient,
 		informerCacheResolvers: informerCacheResolvers,
-		polexLister:            polexLister,
 		config:                 config,
 		excludeGroupRole:       excludeGroupRole,
 	}
@@ -104,9 +97,8 @@ func (s *scanner) validateResource(ctx context.Context, resource unstructured.Un
 		WithClient(s.client).
 		WithNamespaceLabels(nsLabels).
 		WithExcludeGroupRole(s.excludeGroupRole...).
-		WithInformerCacheResolver(s.informerCacheResolvers).
-		WithExceptions(s.polexLister)
-	return engine.Validate(ctx, s.contextLoader, policyCtx, s.config), nil
+		WithInformerCacheResolver(s.informerCacheResolvers)
+	return s.engine.Validate(ctx, policyCtx), nil
 }
 
 func (s *scanner) validateImages(ctx context.Context, resource unstructured.Unstructured, nsLabels map[string]string, policy kyvernov1.PolicyInterface) (*engineapi.EngineResponse, error) {
@@ -129,9 +121,8 @@ func (s *scanner) validateImages(ctx context.Context, resource unstructured.Unst
 		WithClient(s.client).
 		WithNamespaceLabels(nsLabels).
 		WithExcludeGroupRole(s.excludeGroupRole...).
-		WithInformerCacheResolver(s.informerCacheResolvers).
-		WithExceptions(s.polexLister)
-	response, _ := engine.VerifyAndPatchImages(ctx, s.contextLoader, s.rclient, policyCtx, s.config)
+		WithInformerCacheResolver(s.informerCacheResolvers)
+	response, _ := s.engine.VerifyAndPatchImages(ctx, policyCtx)
 	if len(response.PolicyResponse.Rules) > 0 {
 		s.logger.Info("validateImages", "policy", policy, "response", response)
 	}
@@ -447,6 +447,7 @@ func (c *controller) updatePolicyStatuses(ctx context.Context) error {
 		status.Autogen.Rules = nil
 		rules := autogen.ComputeRules(policy)
 		setRuleCount(rules, status)
+		setDependencies(policy, status)
 		for _, rule := range rules {
 			if strings.HasPrefix(rule.Name, "autogen-") {
 				status.Autogen.Rules = append(status.Autogen.Rules, rule)
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policygraph "github.com/kyverno/kyverno/pkg/policy/graph"
 	"github.com/kyverno/kyverno/pkg/utils"
 	"golang.org/x/exp/slices"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -131,3 +132,16 @@ func setRuleCount(rules []kyvernov1.Rule, status *kyvernov1.PolicyStatus) {
 	status.RuleCount.Mutate = mutateCount
 	status.RuleCount.VerifyImages = verifyImagesCount
 }
+
+func setDependencies(policy kyvernov1.PolicyInterface, status *kyvernov1.PolicyStatus) {
+	deps := policygraph.PolicyDependencies(policy)
+	status.Dependencies = kyvernov1.DependenciesStatus{
+		ConfigMaps:      deps.ConfigMaps,
+		APICalls:        deps.APICalls,
+		ImageRegistries: deps.ImageRegistries,
+		Kinds:           deps.Kinds,
+		Namespaces:      deps.Namespaces,
+		Selectors:       deps.Selectors,
+		GeneratedKinds:  deps.GeneratedKinds,
+	}
+}
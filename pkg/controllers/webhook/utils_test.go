@@ -157,3 +157,15 @@ func Test_RuleCount(t *testing.T) {
 	assert.Equal(t, status.RuleCount.Mutate, 1)
 	assert.Equal(t, status.RuleCount.VerifyImages, 2)
 }
+
+func Test_Dependencies(t *testing.T) {
+	var cpol kyverno.ClusterPolicy
+	err := json.Unmarshal([]byte(policy), &cpol)
+	assert.NilError(t, err)
+	status := cpol.GetStatus()
+	setDependencies(&cpol, status)
+	assert.DeepEqual(t, status.Dependencies.Kinds, []string{"CronJob", "DaemonSet", "Deployment", "Job", "Pod", "ReplicaSet", "ReplicationController", "StatefulSet"})
+	assert.DeepEqual(t, status.Dependencies.ImageRegistries, []string{"*", "ghcr.io"})
+	assert.DeepEqual(t, status.Dependencies.ConfigMaps, []string{"default/keys"})
+	assert.Assert(t, status.Dependencies.GeneratedKinds == nil)
+}
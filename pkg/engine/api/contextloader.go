@@ -10,3 +10,6 @@ import (
 type ContextLoader interface {
 	Load(ctx context.Context, contextEntries []kyvernov1.ContextEntry, jsonContext enginecontext.Interface) error
 }
+
+// ContextLoaderFactory creates the context loader used to load context entries of a rule
+type ContextLoaderFactory = func(pContext PolicyContext, ruleName string) ContextLoader
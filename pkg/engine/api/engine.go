@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+)
+
+// Engine applies policies to resources, it is built once with its dependencies
+// (configuration, context loader, registry client and exception selector) and
+// can be substituted or decorated by embedders, e.g. to add caching or metrics
+type Engine interface {
+	// Validate applies validation rules from policy on the resource
+	Validate(
+		ctx context.Context,
+		policyContext PolicyContext,
+	) *EngineResponse
+
+	// Mutate performs mutation. Overlay first and then mutation patches
+	Mutate(
+		ctx context.Context,
+		policyContext PolicyContext,
+	) *EngineResponse
+
+	// VerifyAndPatchImages verifies images signatures and attestations and patches images with their digest
+	VerifyAndPatchImages(
+		ctx context.Context,
+		policyContext PolicyContext,
+	) (*EngineResponse, *ImageVerificationMetadata)
+
+	// ApplyBackgroundChecks checks for validity of generate and mutateExisting rules on the resource
+	// 1. validate variables to be substitute in the general ruleInfo (match,exclude,condition)
+	//   - the caller has to check the ruleResponse to determine whether the path exist
+	//
+	// 2. returns the list of rules that are applicable on this policy and resource, if 1 succeed
+	ApplyBackgroundChecks(
+		policyContext PolicyContext,
+	) *EngineResponse
+
+	// GenerateResponse checks for validity of generate rule on the resource
+	GenerateResponse(
+		policyContext PolicyContext,
+		gr kyvernov1beta1.UpdateRequest,
+	) *EngineResponse
+
+	// ContextLoader returns the loader used to load context entries of the given rule
+	ContextLoader(
+		policyContext PolicyContext,
+		ruleName string,
+	) ContextLoader
+}
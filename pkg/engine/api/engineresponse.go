@@ -33,7 +33,7 @@ func (er EngineResponse) IsOneOf(status ...RuleStatus) bool {
 
 // IsSuccessful checks if any rule has failed or produced an error during execution
 func (er EngineResponse) IsSuccessful() bool {
-	return !er.IsOneOf(RuleStatusFail, RuleStatusError)
+	return !er.IsOneOf(RuleStatusFail, RuleStatusError, RuleStatusTimeout)
 }
 
 // IsSkipped checks if any rule has skipped resource or not.
@@ -46,9 +46,9 @@ func (er EngineResponse) IsFailed() bool {
 	return er.IsOneOf(RuleStatusFail)
 }
 
-// IsError checks if any rule resulted in a processing error
+// IsError checks if any rule resulted in a processing error or timed out
 func (er EngineResponse) IsError() bool {
-	return er.IsOneOf(RuleStatusError)
+	return er.IsOneOf(RuleStatusError, RuleStatusTimeout)
 }
 
 // IsEmpty checks if any rule results are present
@@ -74,7 +74,7 @@ func (er EngineResponse) GetPatches() [][]byte {
 
 // GetFailedRules returns failed rules
 func (er EngineResponse) GetFailedRules() []string {
-	return er.getRules(func(rule RuleResponse) bool { return rule.HasStatus(RuleStatusFail, RuleStatusError) })
+	return er.getRules(func(rule RuleResponse) bool { return rule.HasStatus(RuleStatusFail, RuleStatusError, RuleStatusTimeout) })
 }
 
 // GetSuccessRules returns success rules
@@ -0,0 +1,11 @@
+package api
+
+import (
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+)
+
+// PolicyExceptionSelector is used by the engine to find the policy exceptions of a rule
+type PolicyExceptionSelector interface {
+	// Find returns the policy exceptions declared for the given policy key and rule
+	Find(policyName string, rule string) ([]*kyvernov2alpha1.PolicyException, error)
+}
@@ -1,4 +1,4 @@
-package engine
+package api
 
 import (
 	"encoding/json"
@@ -8,13 +8,13 @@ import (
 	"github.com/pkg/errors"
 )
 
-const imageVerifyAnnotationKey = "kyverno.io/verify-images"
+const ImageVerifyAnnotationKey = "kyverno.io/verify-images"
 
 type ImageVerificationMetadata struct {
 	Data map[string]bool `json:"data"`
 }
 
-func (ivm *ImageVerificationMetadata) add(image string, verified bool) {
+func (ivm *ImageVerificationMetadata) Add(image string, verified bool) {
 	if ivm.Data == nil {
 		ivm.Data = make(map[string]bool)
 	}
@@ -22,7 +22,7 @@ func (ivm *ImageVerificationMetadata) add(image string, verified bool) {
 	ivm.Data[image] = verified
 }
 
-func (ivm *ImageVerificationMetadata) isVerified(image string) bool {
+func (ivm *ImageVerificationMetadata) IsVerified(image string) bool {
 	if ivm.Data == nil {
 		return false
 	}
@@ -35,7 +35,7 @@ func (ivm *ImageVerificationMetadata) isVerified(image string) bool {
 	return verified
 }
 
-func parseImageMetadata(jsonData string) (*ImageVerificationMetadata, error) {
+func ParseImageMetadata(jsonData string) (*ImageVerificationMetadata, error) {
 	var data map[string]bool
 	if err := json.Unmarshal([]byte(jsonData), &data); err != nil {
 		return nil, err
@@ -84,7 +84,7 @@ func (ivm *ImageVerificationMetadata) Patches(hasAnnotations bool, log logr.Logg
 
 func (ivm *ImageVerificationMetadata) Merge(other *ImageVerificationMetadata) {
 	for k, v := range other.Data {
-		ivm.add(k, v)
+		ivm.Add(k, v)
 	}
 }
 
@@ -93,5 +93,5 @@ func (ivm *ImageVerificationMetadata) IsEmpty() bool {
 }
 
 func makeAnnotationKeyForJSONPatch() string {
-	return "/metadata/annotations/" + strings.ReplaceAll(imageVerifyAnnotationKey, "/", "~1")
+	return "/metadata/annotations/" + strings.ReplaceAll(ImageVerifyAnnotationKey, "/", "~1")
 }
@@ -5,7 +5,6 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
-	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	corev1 "k8s.io/api/core/v1"
@@ -39,7 +38,6 @@ type PolicyContext interface {
 	Client() dclient.Interface
 	Copy() PolicyContext
 
-	FindExceptions(rule string) ([]*kyvernov2alpha1.PolicyException, error)
 	ExcludeResourceFunc() ExcludeFunc
 	ResolveConfigMap(ctx context.Context, namespace string, name string) (*corev1.ConfigMap, error)
 }
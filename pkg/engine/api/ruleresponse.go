@@ -33,6 +33,8 @@ type RuleResponse struct {
 	GeneratedResource unstructured.Unstructured
 	// Status rule status
 	Status RuleStatus
+	// Path is the path of the resource field failing the pattern of a validation rule, if known
+	Path string
 	// ExecutionStats statistics
 	ExecutionStats
 	// PatchedTarget is the patched resource for mutate.targets
@@ -43,6 +45,8 @@ type RuleResponse struct {
 	PatchedTargetParentResourceGVR metav1.GroupVersionResource
 	// PodSecurityChecks contains pod security checks (only if this is a pod security rule)
 	PodSecurityChecks *PodSecurityChecks
+	// Exceptions contains the keys of the policy exceptions the rule was skipped for
+	Exceptions []string
 }
 
 // HasStatus checks if rule status is in a given list
@@ -18,4 +18,7 @@ const (
 	// RuleStatusSkip indicates that the policy rule was not selected based on user inputs or applicability, for example
 	// when preconditions are not met, or when conditional or global anchors are not satistied.
 	RuleStatusSkip RuleStatus = "skip"
+	// RuleStatusTimeout indicates that the policy rule could not be evaluated within its time budget, it is
+	// handled like a processing error.
+	RuleStatusTimeout RuleStatus = "timeout"
 )
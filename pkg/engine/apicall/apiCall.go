@@ -198,7 +198,7 @@ func (a *apiCall) transformAndStore(jsonData []byte) ([]byte, error) {
 		return nil, errors.Wrapf(err, "failed to substitute variables in context entry %s JMESPath %s", a.entry.Name, a.entry.APICall.JMESPath)
 	}
 
-	results, err := applyJMESPathJSON(path.(string), jsonData)
+	results, err := applyJMESPathJSON(path.(string), jsonData, a.jsonCtx.Functions()...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to apply JMESPath %s for context entry %s", path, a.entry.Name)
 	}
@@ -217,14 +217,14 @@ func (a *apiCall) transformAndStore(jsonData []byte) ([]byte, error) {
 	return contextData, nil
 }
 
-func applyJMESPathJSON(jmesPath string, jsonData []byte) (interface{}, error) {
+func applyJMESPathJSON(jmesPath string, jsonData []byte, functions ...*jmespath.FunctionEntry) (interface{}, error) {
 	var data interface{}
 	err := json.Unmarshal(jsonData, &data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JSON: %s, error: %v", string(jsonData), err)
 	}
 
-	jp, err := jmespath.New(jmesPath)
+	jp, err := jmespath.New(jmesPath, functions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile JMESPath: %s, error: %v", jmesPath, err)
 	}
@@ -17,16 +17,15 @@ import (
 //   - the caller has to check the ruleResponse to determine whether the path exist
 //
 // 2. returns the list of rules that are applicable on this policy and resource, if 1 succeed
-func ApplyBackgroundChecks(
-	contextLoader ContextLoaderFactory,
+func (e *engine) ApplyBackgroundChecks(
 	policyContext engineapi.PolicyContext,
 ) (resp *engineapi.EngineResponse) {
 	policyStartTime := time.Now()
-	return filterRules(contextLoader, policyContext, policyStartTime)
+	e.loadPolicyFunctions(logging.WithName("ApplyBackgroundChecks"), policyContext)
+	return e.filterRules(policyContext, policyStartTime)
 }
 
-func filterRules(
-	contextLoader ContextLoaderFactory,
+func (e *engine) filterRules(
 	policyContext engineapi.PolicyContext,
 	startTime time.Time,
 ) *engineapi.EngineResponse {
@@ -63,7 +62,7 @@ func filterRules(
 
 	applyRules := policy.GetSpec().GetApplyRules()
 	for _, rule := range autogen.ComputeRules(policy) {
-		if ruleResp := filterRule(contextLoader, rule, policyContext); ruleResp != nil {
+		if ruleResp := e.filterRule(rule, policyContext); ruleResp != nil {
 			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResp)
 			if applyRules == kyvernov1.ApplyOne && ruleResp.Status != engineapi.RuleStatusSkip {
 				break
@@ -74,8 +73,7 @@ func filterRules(
 	return resp
 }
 
-func filterRule(
-	contextLoader ContextLoaderFactory,
+func (e *engine) filterRule(
 	rule kyvernov1.Rule,
 	policyContext engineapi.PolicyContext,
 ) *engineapi.RuleResponse {
@@ -89,7 +87,7 @@ func filterRule(
 	subresourceGVKToAPIResource := GetSubresourceGVKToAPIResourceMap(kindsInPolicy, policyContext)
 
 	// check if there is a corresponding policy exception
-	ruleResp := hasPolicyExceptions(policyContext, &rule, subresourceGVKToAPIResource, logger)
+	ruleResp := hasPolicyExceptions(e.exceptionSelector, policyContext, &rule, subresourceGVKToAPIResource, logger)
 	if ruleResp != nil {
 		return ruleResp
 	}
@@ -134,7 +132,7 @@ func filterRule(
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
 
-	if err := LoadContext(context.TODO(), contextLoader, rule.Context, policyContext, rule.Name); err != nil {
+	if err := LoadContext(context.TODO(), e.contextLoader, rule.Context, policyContext, rule.Name); err != nil {
 		logger.V(4).Info("cannot add external data to the context", "reason", err.Error())
 		return nil
 	}
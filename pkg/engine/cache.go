@@ -0,0 +1,220 @@
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"sync"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/tools/cache"
+)
+
+// nonDeterministicFunctions matches calls to JMESPath functions whose result can change between evaluations
+var nonDeterministicFunctions = regexp.MustCompile(`\b(time_now|time_now_utc|time_since|random|lookup|list|jwt_verify)\s*\(`)
+
+type cacheability struct {
+	resourceVersion string
+	cacheable       bool
+}
+
+type cachedEngine struct {
+	engineapi.Engine
+	exceptionSelector engineapi.PolicyExceptionSelector
+	responses         *utilcache.LRUExpireCache
+	ttl               time.Duration
+	// policies cacheability by policy uid
+	policies sync.Map
+	// instruments
+	requestsCounter syncint64.Counter
+}
+
+// NewCachedEngine returns an engine reusing validation responses computed in the last ttl for the same
+// policy version and resource content, the resource content is compared using the resource hash also used
+// by reports. Only policies without context entries other than variables and without calls to
+// time or random JMESPath functions are cached.
+func NewCachedEngine(
+	inner engineapi.Engine,
+	exceptionSelector engineapi.PolicyExceptionSelector,
+	meterProvider metric.MeterProvider,
+	size int,
+	ttl time.Duration,
+) engineapi.Engine {
+	requestsCounter, err := meterProvider.Meter(metrics.MeterName).SyncInt64().Counter(
+		"kyverno_evaluation_cache_requests",
+		instrument.WithDescription("can be used to track the number of policy evaluations served from the evaluation cache (hit) or computed by the engine (miss)"),
+	)
+	if err != nil {
+		logging.Error(err, "Failed to create instrument, kyverno_evaluation_cache_requests")
+	}
+	return &cachedEngine{
+		Engine:            inner,
+		exceptionSelector: exceptionSelector,
+		responses:         utilcache.NewLRUExpireCache(size),
+		ttl:               ttl,
+		requestsCounter:   requestsCounter,
+	}
+}
+
+func (e *cachedEngine) Validate(ctx context.Context, policyContext engineapi.PolicyContext) *engineapi.EngineResponse {
+	key, ok := e.cacheKey(policyContext)
+	if !ok {
+		return e.Engine.Validate(ctx, policyContext)
+	}
+	if value, ok := e.responses.Get(key); ok {
+		e.count(ctx, "hit")
+		return cachedResponse(value.(*engineapi.EngineResponse), policyContext)
+	}
+	e.count(ctx, "miss")
+	response := e.Engine.Validate(ctx, policyContext)
+	// errors can be transient, don't remember them
+	if response != nil && !response.IsError() {
+		e.responses.Add(key, cachedResponse(response, policyContext), e.ttl)
+	}
+	return response
+}
+
+func (e *cachedEngine) count(ctx context.Context, result string) {
+	if e.requestsCounter != nil {
+		e.requestsCounter.Add(ctx, 1, attribute.String("result", result))
+	}
+}
+
+// cacheKey computes the key identifying an evaluation, it returns false if the evaluation can't be cached
+func (e *cachedEngine) cacheKey(policyContext engineapi.PolicyContext) (string, bool) {
+	policy := policyContext.Policy()
+	if policy == nil || policy.GetResourceVersion() == "" || !e.isCacheable(policy) {
+		return "", false
+	}
+	policyKey, err := cache.MetaNamespaceKeyFunc(policy)
+	if err != nil {
+		return "", false
+	}
+	var exceptions []string
+	if e.exceptionSelector != nil {
+		now := time.Now()
+		for _, rule := range autogen.ComputeRules(policy) {
+			candidates, err := e.exceptionSelector.Find(policyKey, rule.Name)
+			if err != nil {
+				return "", false
+			}
+			for _, candidate := range candidates {
+				if candidate.IsActive(now) {
+					exceptions = append(exceptions, candidate.GetNamespace()+"/"+candidate.GetName()+"@"+candidate.GetResourceVersion())
+				}
+			}
+		}
+	}
+	newResource := policyContext.NewResource()
+	oldResource := policyContext.OldResource()
+	operation, _ := policyContext.JSONContext().Query("request.operation")
+	input := struct {
+		Policy          string
+		PolicyUID       types.UID
+		PolicyVersion   string
+		Exceptions      []string
+		Resource        resourceKey
+		OldResource     resourceKey
+		Operation       interface{}
+		SubResource     string
+		RequestResource interface{}
+		NamespaceLabels map[string]string
+		AdmissionInfo   kyvernov1beta1.RequestInfo
+	}{
+		Policy:          policyKey,
+		PolicyUID:       policy.GetUID(),
+		PolicyVersion:   policy.GetResourceVersion(),
+		Exceptions:      exceptions,
+		Resource:        newResourceKey(newResource),
+		OldResource:     newResourceKey(oldResource),
+		Operation:       operation,
+		SubResource:     policyContext.SubResource(),
+		RequestResource: policyContext.RequestResource(),
+		NamespaceLabels: policyContext.NamespaceLabels(),
+		AdmissionInfo:   policyContext.AdmissionInfo(),
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", false
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), true
+}
+
+// isCacheable checks if the evaluation of a policy only depends on the policy context
+func (e *cachedEngine) isCacheable(policy kyvernov1.PolicyInterface) bool {
+	if value, ok := e.policies.Load(policy.GetUID()); ok {
+		if entry := value.(cacheability); entry.resourceVersion == policy.GetResourceVersion() {
+			return entry.cacheable
+		}
+	}
+	cacheable := isPolicyCacheable(policy)
+	e.policies.Store(policy.GetUID(), cacheability{resourceVersion: policy.GetResourceVersion(), cacheable: cacheable})
+	return cacheable
+}
+
+func isPolicyCacheable(policy kyvernov1.PolicyInterface) bool {
+	rules := autogen.ComputeRules(policy)
+	for _, rule := range rules {
+		for _, entry := range rule.Context {
+			if entry.Variable == nil {
+				return false
+			}
+		}
+	}
+	// functions of the policy can be called from the rules, their expressions are scanned too
+	data, err := json.Marshal([]interface{}{rules, policy.GetSpec().Functions})
+	if err != nil {
+		return false
+	}
+	return !nonDeterministicFunctions.Match(data)
+}
+
+type resourceKey struct {
+	Namespace string
+	Name      string
+	Hash      string
+}
+
+func newResourceKey(resource unstructured.Unstructured) resourceKey {
+	if resource.Object == nil {
+		return resourceKey{}
+	}
+	return resourceKey{
+		Namespace: resource.GetNamespace(),
+		Name:      resource.GetName(),
+		Hash:      reportutils.CalculateResourceHash(resource),
+	}
+}
+
+// cachedResponse returns a copy of the response bound to the given policy context,
+// callers can modify the returned response without changing the cached one
+func cachedResponse(response *engineapi.EngineResponse, policyContext engineapi.PolicyContext) *engineapi.EngineResponse {
+	copy := *response
+	copy.PolicyResponse.Rules = append([]engineapi.RuleResponse(nil), response.PolicyResponse.Rules...)
+	copy.Policy = policyContext.Policy()
+	copy.NamespaceLabels = policyContext.NamespaceLabels()
+	resource := policyContext.NewResource()
+	if resource.Object == nil {
+		resource = policyContext.OldResource()
+	}
+	copy.PatchedResource = resource
+	copy.PolicyResponse.Timestamp = time.Now().Unix()
+	return &copy
+}
@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"go.opentelemetry.io/otel/metric"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type countingEngine struct {
+	engineapi.Engine
+	calls int
+}
+
+func (e *countingEngine) Validate(_ context.Context, policyContext engineapi.PolicyContext) *engineapi.EngineResponse {
+	e.calls++
+	return &engineapi.EngineResponse{
+		Policy: policyContext.Policy(),
+		PolicyResponse: engineapi.PolicyResponse{
+			Rules: []engineapi.RuleResponse{{Name: "rule", Status: engineapi.RuleStatusPass}},
+		},
+	}
+}
+
+func Test_cachedEngine(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{}
+	policy.SetName("policy")
+	policy.SetUID("uid")
+	policy.SetResourceVersion("1")
+	policy.Spec.Rules = []kyvernov1.Rule{{
+		Name: "rule",
+		Context: []kyvernov1.ContextEntry{{
+			Name:     "name",
+			Variable: &kyvernov1.Variable{JMESPath: "request.object.metadata.name"},
+		}},
+	}}
+	resource := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "test", "namespace": "default"},
+		"data":       map[string]interface{}{"key": "value"},
+	}}
+	inner := &countingEngine{}
+	eng := NewCachedEngine(inner, nil, metric.NewNoopMeterProvider(), 100, time.Minute)
+	validate := func(policy kyvernov1.PolicyInterface, resource unstructured.Unstructured) *engineapi.EngineResponse {
+		return eng.Validate(context.TODO(), NewPolicyContext().WithPolicy(policy).WithNewResource(resource))
+	}
+
+	response := validate(policy, resource)
+	assert.Equal(t, inner.calls, 1)
+	// callers modifying the response don't change the cached one
+	response.PolicyResponse.Rules = append(response.PolicyResponse.Rules[:0], engineapi.RuleResponse{Name: "other"})
+	response = validate(policy, resource)
+	assert.Equal(t, inner.calls, 1)
+	assert.Equal(t, response.PolicyResponse.Rules[0].Name, "rule")
+
+	// status changes don't invalidate the cache
+	withStatus := *resource.DeepCopy()
+	withStatus.Object["status"] = map[string]interface{}{"ready": true}
+	validate(policy, withStatus)
+	assert.Equal(t, inner.calls, 1)
+
+	// content changes do
+	changed := *resource.DeepCopy()
+	changed.Object["data"] = map[string]interface{}{"key": "other"}
+	validate(policy, changed)
+	assert.Equal(t, inner.calls, 2)
+
+	// so do policy changes
+	updated := policy.DeepCopy()
+	updated.SetResourceVersion("2")
+	validate(updated, resource)
+	assert.Equal(t, inner.calls, 3)
+
+	// policies with external context entries are not cached
+	withAPICall := policy.DeepCopy()
+	withAPICall.SetUID("uid-api-call")
+	withAPICall.Spec.Rules[0].Context = append(withAPICall.Spec.Rules[0].Context, kyvernov1.ContextEntry{
+		Name:    "pods",
+		APICall: &kyvernov1.APICall{URLPath: "/api/v1/pods"},
+	})
+	validate(withAPICall, resource)
+	validate(withAPICall, resource)
+	assert.Equal(t, inner.calls, 5)
+}
+
+func Test_isPolicyCacheable(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{}
+	policy.Spec.Rules = []kyvernov1.Rule{{
+		Name: "rule",
+		Validation: kyvernov1.Validation{
+			Message: "{{ request.object.metadata.name }}",
+		},
+	}}
+	assert.Assert(t, isPolicyCacheable(policy))
+	policy.Spec.Rules[0].Validation.Message = "{{ time_now_utc() }}"
+	assert.Assert(t, !isPolicyCacheable(policy))
+	policy.Spec.Rules[0].Validation.Message = "{{ random('[a-z]{4}') }}"
+	assert.Assert(t, !isPolicyCacheable(policy))
+	policy.Spec.Rules[0].Validation.Message = "{{ lookup('v1', 'Namespace', '', 'default').metadata.name }}"
+	assert.Assert(t, !isPolicyCacheable(policy))
+	policy.Spec.Rules[0].Validation.Message = "{{ now() }}"
+	policy.Spec.Functions = []kyvernov1.Function{{Name: "now", Expression: "time_now_utc()"}}
+	assert.Assert(t, !isPolicyCacheable(policy))
+	policy.Spec.Functions[0].Expression = "to_upper(request.object.metadata.name)"
+	assert.Assert(t, isPolicyCacheable(policy))
+}
@@ -10,6 +10,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov1beta1 "github.com/kyverno/kyverno/api/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/logging"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	"github.com/pkg/errors"
@@ -91,6 +92,15 @@ type Interface interface {
 	// Reset sets the internal state to the last checkpoint, but does not remove the checkpoint.
 	Reset()
 
+	// Copy returns a copy of the context, the internal state is shared until one of the copies is modified.
+	Copy() Interface
+
+	// SetFunctions sets the user defined functions available to queries
+	SetFunctions(functions ...*jmespath.FunctionEntry)
+
+	// Functions returns the user defined functions available to queries
+	Functions() []*jmespath.FunctionEntry
+
 	EvalInterface
 
 	// AddJSON  merges the json with context
@@ -103,6 +113,7 @@ type context struct {
 	jsonRaw            []byte
 	jsonRawCheckpoints [][]byte
 	images             map[string]map[string]apiutils.ImageInfo
+	functions          []*jmespath.FunctionEntry
 }
 
 // NewContext returns a new context
@@ -318,6 +329,33 @@ func (ctx *context) Reset() {
 	ctx.reset(false)
 }
 
+// Copy returns a copy of the context, the internal state is never modified in place
+// so it can be shared until one of the copies is modified.
+func (ctx *context) Copy() Interface {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+	n := len(ctx.jsonRawCheckpoints)
+	return &context{
+		jsonRaw: ctx.jsonRaw,
+		// limit the capacity so that appending a checkpoint to a copy never writes to the shared array
+		jsonRawCheckpoints: ctx.jsonRawCheckpoints[:n:n],
+		images:             ctx.images,
+		functions:          ctx.functions,
+	}
+}
+
+func (ctx *context) SetFunctions(functions ...*jmespath.FunctionEntry) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	ctx.functions = functions
+}
+
+func (ctx *context) Functions() []*jmespath.FunctionEntry {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+	return ctx.functions
+}
+
 func (ctx *context) reset(remove bool) {
 	ctx.mutex.Lock()
 	defer ctx.mutex.Unlock()
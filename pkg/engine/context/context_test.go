@@ -119,3 +119,51 @@ func Test_addResourceAndUserContext(t *testing.T) {
 		t.Error("expected result does not match")
 	}
 }
+
+func Test_Copy(t *testing.T) {
+	ctx := NewContext()
+	if err := AddResource(ctx, []byte(`{"kind":"Pod"}`)); err != nil {
+		t.Fatal(err)
+	}
+	ctx.Checkpoint()
+	copy := ctx.Copy()
+	if err := copy.AddNamespace("test"); err != nil {
+		t.Fatal(err)
+	}
+	copy.Checkpoint()
+	ctx.Checkpoint()
+	if err := ctx.AddNamespace("other"); err != nil {
+		t.Fatal(err)
+	}
+	result, err := copy.Query("request.namespace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "test" {
+		t.Errorf("expected test, got %v", result)
+	}
+	result, err = ctx.Query("request.namespace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "other" {
+		t.Errorf("expected other, got %v", result)
+	}
+	// restoring the copy goes back to its own checkpoint
+	copy.Restore()
+	result, err = copy.Query("request.namespace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "test" {
+		t.Errorf("expected test, got %v", result)
+	}
+	copy.Restore()
+	result, err = copy.Query("request.object.kind")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "Pod" {
+		t.Errorf("expected Pod, got %v", result)
+	}
+}
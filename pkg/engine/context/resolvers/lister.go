@@ -0,0 +1,208 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/auth"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// cacheSyncTimeout bounds the time a lookup waits for the cache of a newly registered resource
+	cacheSyncTimeout = 5 * time.Second
+	// accessCheckPeriod is the time after which a denied access is checked again
+	accessCheckPeriod = time.Minute
+)
+
+type resourceInformer struct {
+	namespaced bool
+	informer   informers.GenericInformer
+	stop       context.CancelFunc
+	err        error
+	checked    time.Time
+	used       time.Time
+}
+
+type informerBasedResourceLister struct {
+	ctx          context.Context
+	client       dclient.Interface
+	resync       time.Duration
+	allowedKinds []string
+	idleTimeout  time.Duration
+	lock         sync.Mutex
+	informers    map[schema.GroupVersionResource]*resourceInformer
+}
+
+// NewInformerBasedResourceLister returns a lister serving resources from informer caches, informers are registered
+// the first time a resource is read if its kind matches one of the allowed kinds (apiVersion/kind patterns) and
+// kyverno is allowed to list and watch it. Informers not used for the idle timeout are stopped.
+func NewInformerBasedResourceLister(ctx context.Context, client dclient.Interface, resync time.Duration, allowedKinds []string, idleTimeout time.Duration) (jmespath.ResourceLister, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	l := &informerBasedResourceLister{
+		ctx:          ctx,
+		client:       client,
+		resync:       resync,
+		allowedKinds: allowedKinds,
+		idleTimeout:  idleTimeout,
+		informers:    map[schema.GroupVersionResource]*resourceInformer{},
+	}
+	if idleTimeout > 0 {
+		go wait.Until(l.stopIdleInformers, idleTimeout/2, ctx.Done())
+	}
+	return l, nil
+}
+
+func (l *informerBasedResourceLister) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	informer, err := l.informer(apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+	var obj runtime.Object
+	if informer.namespaced {
+		if namespace == "" {
+			return nil, fmt.Errorf("namespace is required to get %s %s", kind, name)
+		}
+		obj, err = informer.informer.Lister().ByNamespace(namespace).Get(name)
+	} else {
+		obj, err = informer.informer.Lister().Get(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resource, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T", obj)
+	}
+	// objects of the cache must not be modified
+	return resource.DeepCopy(), nil
+}
+
+func (l *informerBasedResourceLister) List(apiVersion, kind, namespace string, selector labels.Selector) ([]*unstructured.Unstructured, error) {
+	informer, err := l.informer(apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+	var objs []runtime.Object
+	if informer.namespaced && namespace != "" {
+		objs, err = informer.informer.Lister().ByNamespace(namespace).List(selector)
+	} else {
+		objs, err = informer.informer.Lister().List(selector)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		resource, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object type %T", obj)
+		}
+		resources = append(resources, resource.DeepCopy())
+	}
+	return resources, nil
+}
+
+// informer returns the synced informer of the given kind, the informer is registered on first use
+func (l *informerBasedResourceLister) informer(apiVersion, kind string) (*resourceInformer, error) {
+	if !wildcard.CheckPatterns(l.allowedKinds, apiVersion+"/"+kind) {
+		return nil, fmt.Errorf("%s %s can not be read, it is not one of the kinds allowed for lookups", apiVersion, kind)
+	}
+	apiResource, parentAPIResource, gvr, err := l.client.Discovery().FindResource(apiVersion, kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find resource %s %s: %v", apiVersion, kind, err)
+	}
+	if parentAPIResource != nil {
+		return nil, fmt.Errorf("subresource %s %s can not be listed", apiVersion, kind)
+	}
+	informer, err := l.register(apiVersion, kind, apiResource.Namespaced, gvr)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(l.ctx, cacheSyncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(ctx.Done(), informer.informer.Informer().HasSynced) {
+		return nil, fmt.Errorf("cache of %s is not synced", gvr.GroupResource().String())
+	}
+	return informer, nil
+}
+
+func (l *informerBasedResourceLister) register(apiVersion, kind string, namespaced bool, gvr schema.GroupVersionResource) (*resourceInformer, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := time.Now()
+	if informer, ok := l.informers[gvr]; ok {
+		if informer.err == nil || now.Sub(informer.checked) < accessCheckPeriod {
+			informer.used = now
+			return informer, informer.err
+		}
+	}
+	informer := &resourceInformer{
+		namespaced: namespaced,
+		err:        l.checkAccess(apiVersion, kind, gvr),
+		checked:    now,
+		used:       now,
+	}
+	if informer.err == nil {
+		ctx, stop := context.WithCancel(l.ctx)
+		informer.informer = dynamicinformer.NewFilteredDynamicInformer(
+			l.client.GetDynamicInterface(),
+			gvr,
+			metav1.NamespaceAll,
+			l.resync,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+			nil,
+		)
+		informer.stop = stop
+		go informer.informer.Informer().Run(ctx.Done())
+	}
+	l.informers[gvr] = informer
+	return informer, informer.err
+}
+
+// stopIdleInformers stops the informers that were not used for the idle timeout, they are registered again
+// if their resources are read later
+func (l *informerBasedResourceLister) stopIdleInformers() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for gvr, informer := range l.informers {
+		if time.Since(informer.used) < l.idleTimeout {
+			continue
+		}
+		if informer.stop != nil {
+			informer.stop()
+		}
+		delete(l.informers, gvr)
+	}
+}
+
+// checkAccess checks that the service account of kyverno can list and watch the resource in all namespaces
+func (l *informerBasedResourceLister) checkAccess(apiVersion, kind string, gvr schema.GroupVersionResource) error {
+	ssar := l.client.GetKubeClient().AuthorizationV1().SelfSubjectAccessReviews()
+	for _, verb := range []string{"list", "watch"} {
+		allowed, err := auth.NewCanI(l.client.Discovery(), ssar, apiVersion+"/"+kind, "", verb, "").RunAccessCheck(l.ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check access to %s: %v", gvr.GroupResource().String(), err)
+		}
+		if !allowed {
+			return fmt.Errorf("kyverno is not allowed to %s %s", verb, gvr.GroupResource().String())
+		}
+	}
+	return nil
+}
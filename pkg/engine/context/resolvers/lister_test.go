@@ -0,0 +1,138 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+var ingressesGVR = schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}
+
+type ingressDiscovery struct {
+	dclient.IDiscovery
+}
+
+func (d ingressDiscovery) FindResource(groupVersion string, kind string) (*metav1.APIResource, *metav1.APIResource, schema.GroupVersionResource, error) {
+	if groupVersion != "networking.k8s.io/v1" || kind != "Ingress" {
+		return nil, nil, schema.GroupVersionResource{}, kerrors.NewNotFound(schema.GroupResource{Resource: kind}, "")
+	}
+	return &metav1.APIResource{Name: "ingresses", Kind: "Ingress", Namespaced: true}, nil, ingressesGVR, nil
+}
+
+func (d ingressDiscovery) GetGVRFromKind(kind string) (schema.GroupVersionResource, error) {
+	return ingressesGVR, nil
+}
+
+func newIngress(namespace, name string, labels map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata":   map[string]interface{}{"namespace": namespace, "name": name, "labels": labels},
+	}}
+}
+
+func newListerClient(t *testing.T, allowed bool, objects ...runtime.Object) dclient.Interface {
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), map[schema.GroupVersionResource]string{ingressesGVR: "IngressList"}, objects...)
+	assert.NilError(t, err)
+	client.SetDiscovery(ingressDiscovery{})
+	client.GetKubeClient().(*kubefake.Clientset).PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowed}}, nil
+	})
+	return client
+}
+
+func Test_InformerBasedResourceLister(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	client := newListerClient(t, true,
+		newIngress("default", "web", map[string]interface{}{"app": "web"}),
+		newIngress("default", "api", map[string]interface{}{"app": "api"}),
+		newIngress("test", "web", map[string]interface{}{"app": "web"}),
+	)
+	lister, err := NewInformerBasedResourceLister(ctx, client, 0, []string{"*"}, 0)
+	assert.NilError(t, err)
+
+	resource, err := lister.Get("networking.k8s.io/v1", "Ingress", "test", "web")
+	assert.NilError(t, err)
+	assert.Equal(t, resource.GetNamespace(), "test")
+	_, err = lister.Get("networking.k8s.io/v1", "Ingress", "test", "api")
+	assert.Assert(t, kerrors.IsNotFound(err))
+	_, err = lister.Get("networking.k8s.io/v1", "Ingress", "", "api")
+	assert.ErrorContains(t, err, "namespace is required")
+
+	resources, err := lister.List("networking.k8s.io/v1", "Ingress", "", labels.Everything())
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 3)
+	resources, err = lister.List("networking.k8s.io/v1", "Ingress", "default", labels.Everything())
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 2)
+	resources, err = lister.List("networking.k8s.io/v1", "Ingress", "", labels.SelectorFromSet(labels.Set{"app": "web"}))
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 2)
+
+	_, err = lister.List("v1", "Gadget", "", labels.Everything())
+	assert.ErrorContains(t, err, "failed to find resource v1 Gadget")
+}
+
+func Test_InformerBasedResourceListerAllowedKinds(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	client := newListerClient(t, true, newIngress("default", "web", nil))
+	lister, err := NewInformerBasedResourceLister(ctx, client, 0, []string{"networking.k8s.io/v1/Ingress"}, 0)
+	assert.NilError(t, err)
+	_, err = lister.Get("networking.k8s.io/v1", "Ingress", "default", "web")
+	assert.NilError(t, err)
+	_, err = lister.List("v1", "ConfigMap", "", labels.Everything())
+	assert.Error(t, err, "v1 ConfigMap can not be read, it is not one of the kinds allowed for lookups")
+	lister, err = NewInformerBasedResourceLister(ctx, client, 0, nil, 0)
+	assert.NilError(t, err)
+	_, err = lister.Get("networking.k8s.io/v1", "Ingress", "default", "web")
+	assert.Error(t, err, "networking.k8s.io/v1 Ingress can not be read, it is not one of the kinds allowed for lookups")
+}
+
+func Test_InformerBasedResourceListerStopIdleInformers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	client := newListerClient(t, true, newIngress("default", "web", nil))
+	lister, err := NewInformerBasedResourceLister(ctx, client, 0, []string{"networking.k8s.io/*"}, 0)
+	assert.NilError(t, err)
+	_, err = lister.Get("networking.k8s.io/v1", "Ingress", "default", "web")
+	assert.NilError(t, err)
+	informerLister := lister.(*informerBasedResourceLister)
+	informerLister.idleTimeout = time.Hour
+	informerLister.stopIdleInformers()
+	assert.Equal(t, len(informerLister.informers), 1)
+	informerLister.idleTimeout = time.Nanosecond
+	informerLister.stopIdleInformers()
+	assert.Equal(t, len(informerLister.informers), 0)
+	// the informer is registered again when its resources are read
+	_, err = lister.Get("networking.k8s.io/v1", "Ingress", "default", "web")
+	assert.NilError(t, err)
+	assert.Equal(t, len(informerLister.informers), 1)
+}
+
+func Test_InformerBasedResourceListerAccessDenied(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	lister, err := NewInformerBasedResourceLister(ctx, newListerClient(t, false), 0, []string{"*"}, 0)
+	assert.NilError(t, err)
+	_, err = lister.List("networking.k8s.io/v1", "Ingress", "", labels.Everything())
+	assert.Error(t, err, "kyverno is not allowed to list ingresses.networking.k8s.io")
+}
+
+func Test_NewInformerBasedResourceListerNilClient(t *testing.T) {
+	_, err := NewInformerBasedResourceLister(context.TODO(), nil, 0, nil, 0)
+	assert.Error(t, err, "client must not be nil")
+}
@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+)
+
+type engine struct {
+	configuration     config.Configuration
+	rclient           registryclient.Client
+	contextLoader     engineapi.ContextLoaderFactory
+	exceptionSelector engineapi.PolicyExceptionSelector
+	resourceLister    jmespath.ResourceLister
+	lookupFunctions   []*jmespath.FunctionEntry
+	imageFunctions    []*jmespath.FunctionEntry
+	functions         *functionsCache
+}
+
+// NewEngine returns an engine applying policies with the given dependencies,
+// exceptionSelector can be nil when policy exceptions are not enabled and
+// resourceLister can be nil when the lookup functions are not available
+func NewEngine(
+	configuration config.Configuration,
+	rclient registryclient.Client,
+	contextLoader engineapi.ContextLoaderFactory,
+	exceptionSelector engineapi.PolicyExceptionSelector,
+	resourceLister jmespath.ResourceLister,
+) engineapi.Engine {
+	e := &engine{
+		configuration:     configuration,
+		rclient:           rclient,
+		contextLoader:     contextLoader,
+		exceptionSelector: exceptionSelector,
+		resourceLister:    resourceLister,
+		functions:         newFunctionsCache(),
+	}
+	if configuration != nil {
+		e.imageFunctions = jmespath.NewImageFunctions(configuration)
+	}
+	if resourceLister != nil {
+		e.lookupFunctions = jmespath.NewLookupFunctions(resourceLister)
+	}
+	return e
+}
+
+func (e *engine) ContextLoader(
+	policyContext engineapi.PolicyContext,
+	ruleName string,
+) engineapi.ContextLoader {
+	return e.contextLoader(policyContext, ruleName)
+}
@@ -0,0 +1,241 @@
+package engine
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	matched "github.com/kyverno/kyverno/pkg/utils/match"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+type exceptionSelector struct {
+	lister PolicyExceptionLister
+}
+
+// NewExceptionSelector returns a selector finding the policy exceptions of a rule using the given lister
+func NewExceptionSelector(lister PolicyExceptionLister) engineapi.PolicyExceptionSelector {
+	if lister == nil {
+		return nil
+	}
+	return &exceptionSelector{
+		lister: lister,
+	}
+}
+
+func (s *exceptionSelector) Find(policyName string, rule string) ([]*kyvernov2alpha1.PolicyException, error) {
+	polexs, err := s.lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var result []*kyvernov2alpha1.PolicyException
+	for _, polex := range polexs {
+		if polex.Contains(policyName, rule) {
+			result = append(result, polex)
+		}
+	}
+	return result, nil
+}
+
+// findExceptions returns the exceptions in effect for the rule that apply to the resource being admitted
+func findExceptions(
+	selector engineapi.PolicyExceptionSelector,
+	policyContext engineapi.PolicyContext,
+	rule *kyvernov1.Rule,
+	subresourceGVKToAPIResource map[string]*metav1.APIResource,
+	log logr.Logger,
+) ([]*kyvernov2alpha1.PolicyException, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	policyName, err := cache.MetaNamespaceKeyFunc(policyContext.Policy())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute policy key")
+	}
+	candidates, err := selector.Find(policyName, rule.Name)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var result []*kyvernov2alpha1.PolicyException
+	for _, candidate := range candidates {
+		// expired or not yet valid exceptions don't apply
+		if !candidate.IsActive(now) {
+			continue
+		}
+		err := matched.CheckMatchesResources(
+			policyContext.NewResource(),
+			candidate.Spec.Match,
+			policyContext.NamespaceLabels(),
+			subresourceGVKToAPIResource,
+			policyContext.SubResource(),
+			policyContext.AdmissionInfo(),
+			policyContext.ExcludeGroupRole(),
+		)
+		// if there's an error it means no match
+		if err != nil {
+			continue
+		}
+		if candidate.Spec.Conditions != nil {
+			conditions, err := datautils.ToMap(candidate.Spec.Conditions)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to convert exception conditions")
+			}
+			passed, err := checkPreconditions(log, policyContext, conditions)
+			if err != nil {
+				return nil, err
+			}
+			if !passed {
+				continue
+			}
+		}
+		result = append(result, candidate)
+	}
+	return result, nil
+}
+
+// matchesException checks if an exception applies to the resource being admitted
+func matchesException(
+	selector engineapi.PolicyExceptionSelector,
+	policyContext engineapi.PolicyContext,
+	rule *kyvernov1.Rule,
+	subresourceGVKToAPIResource map[string]*metav1.APIResource,
+	log logr.Logger,
+) (*kyvernov2alpha1.PolicyException, error) {
+	exceptions, err := findExceptions(selector, policyContext, rule, subresourceGVKToAPIResource, log)
+	if err != nil {
+		return nil, err
+	}
+	policyName, err := cache.MetaNamespaceKeyFunc(policyContext.Policy())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute policy key")
+	}
+	for _, exception := range exceptions {
+		// image scoped exceptions are applied per image when verifying images
+		if len(exception.ImageReferences(policyName, rule.Name)) == 0 {
+			return exception, nil
+		}
+	}
+	return nil, nil
+}
+
+// hasPolicyExceptions returns nil when there are no matching exceptions.
+// A rule response is returned when an exception is matched, or there is an error.
+func hasPolicyExceptions(selector engineapi.PolicyExceptionSelector, ctx engineapi.PolicyContext, rule *kyvernov1.Rule, subresourceGVKToAPIResource map[string]*metav1.APIResource, log logr.Logger) *engineapi.RuleResponse {
+	// if matches, check if there is a corresponding policy exception
+	exception, err := matchesException(selector, ctx, rule, subresourceGVKToAPIResource, log)
+	// if we found an exception
+	if err == nil && exception != nil {
+		key, err := cache.MetaNamespaceKeyFunc(exception)
+		if err != nil {
+			log.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
+			return &engineapi.RuleResponse{
+				Name:    rule.Name,
+				Message: "failed to find matched exception " + key,
+				Status:  engineapi.RuleStatusError,
+			}
+		}
+		log.V(3).Info("policy rule skipped due to policy exception", "exception", key)
+		return &engineapi.RuleResponse{
+			Name:       rule.Name,
+			Message:    "rule skipped due to policy exception " + key,
+			Status:     engineapi.RuleStatusSkip,
+			Exceptions: []string{key},
+		}
+	}
+	return nil
+}
+
+// filterExceptedImages removes the images covered by an image scoped exception,
+// it returns the remaining images and the keys of the exceptions that were applied
+func filterExceptedImages(
+	selector engineapi.PolicyExceptionSelector,
+	policyContext engineapi.PolicyContext,
+	rule *kyvernov1.Rule,
+	subresourceGVKToAPIResource map[string]*metav1.APIResource,
+	images []apiutils.ImageInfo,
+	log logr.Logger,
+) ([]apiutils.ImageInfo, []string, error) {
+	exceptions, err := findExceptions(selector, policyContext, rule, subresourceGVKToAPIResource, log)
+	if err != nil || len(exceptions) == 0 {
+		return images, nil, err
+	}
+	policyName, err := cache.MetaNamespaceKeyFunc(policyContext.Policy())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to compute policy key")
+	}
+	var keys []string
+	var remaining []apiutils.ImageInfo
+	for _, image := range images {
+		excepted := false
+		for _, exception := range exceptions {
+			if imageMatches(image.String(), exception.ImageReferences(policyName, rule.Name)) {
+				key, err := cache.MetaNamespaceKeyFunc(exception)
+				if err != nil {
+					return nil, nil, errors.Wrap(err, "failed to compute policy exception key")
+				}
+				log.V(3).Info("image verification skipped due to policy exception", "image", image.String(), "exception", key)
+				if !datautils.SliceContains(keys, key) {
+					keys = append(keys, key)
+				}
+				excepted = true
+				break
+			}
+		}
+		if !excepted {
+			remaining = append(remaining, image)
+		}
+	}
+	return remaining, keys, nil
+}
+
+// exceptedImagesResponse builds the rule response returned when all images were excluded by exceptions
+func exceptedImagesResponse(rule *kyvernov1.Rule, keys []string) *engineapi.RuleResponse {
+	return &engineapi.RuleResponse{
+		Name:       rule.Name,
+		Type:       engineapi.ImageVerify,
+		Message:    "rule skipped due to policy exception " + strings.Join(keys, ", "),
+		Status:     engineapi.RuleStatusSkip,
+		Exceptions: keys,
+	}
+}
+
+type approvedExceptionLister struct {
+	inner             PolicyExceptionLister
+	requiredApprovals int
+}
+
+// ApprovedExceptions wraps the given lister so that only exceptions approved by at least
+// requiredApprovals distinct users are returned. When requiredApprovals is not positive
+// the lister is returned as is.
+func ApprovedExceptions(lister PolicyExceptionLister, requiredApprovals int) PolicyExceptionLister {
+	if lister == nil || requiredApprovals <= 0 {
+		return lister
+	}
+	return &approvedExceptionLister{
+		inner:             lister,
+		requiredApprovals: requiredApprovals,
+	}
+}
+
+func (l *approvedExceptionLister) List(selector labels.Selector) ([]*kyvernov2alpha1.PolicyException, error) {
+	polexs, err := l.inner.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	var result []*kyvernov2alpha1.PolicyException
+	for _, polex := range polexs {
+		if polex.IsApproved(l.requiredApprovals) {
+			result = append(result, polex)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/logging"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type polexLister []*kyvernov2alpha1.PolicyException
+
+func (l polexLister) List(labels.Selector) ([]*kyvernov2alpha1.PolicyException, error) {
+	return l, nil
+}
+
+func newExceptionPolicyContext(t *testing.T) *PolicyContext {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+	}
+	resource, err := kubeutils.BytesToUnstructured([]byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "test", "namespace": "default"},
+		"spec": {"containers": [{"name": "nginx", "image": "nginx:latest"}]}
+	}`))
+	assert.NilError(t, err)
+	jsonContext := enginecontext.NewContext()
+	assert.NilError(t, jsonContext.AddResource(resource.Object))
+	return NewPolicyContextWithJsonContext(jsonContext).
+		WithPolicy(policy).
+		WithNewResource(*resource)
+}
+
+func newException(name string, imageReferences ...string) *kyvernov2alpha1.PolicyException {
+	return &kyvernov2alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: kyvernov2alpha1.PolicyExceptionSpec{
+			Match: kyvernov2beta1.MatchResources{
+				Any: kyvernov1.ResourceFilters{{
+					ResourceDescription: kyvernov1.ResourceDescription{
+						Kinds: []string{"Pod"},
+					},
+				}},
+			},
+			Exceptions: []kyvernov2alpha1.Exception{{
+				PolicyName:      "policy",
+				RuleNames:       []string{"rule"},
+				ImageReferences: imageReferences,
+			}},
+		},
+	}
+}
+
+func newCondition(t *testing.T, key string, operator kyvernov2beta1.ConditionOperator, value interface{}) kyvernov2beta1.Condition {
+	rawKey, err := json.Marshal(key)
+	assert.NilError(t, err)
+	rawValue, err := json.Marshal(value)
+	assert.NilError(t, err)
+	return kyvernov2beta1.Condition{
+		RawKey:   &apiextv1.JSON{Raw: rawKey},
+		Operator: operator,
+		RawValue: &apiextv1.JSON{Raw: rawValue},
+	}
+}
+
+func Test_hasPolicyExceptions(t *testing.T) {
+	rule := &kyvernov1.Rule{Name: "rule"}
+	expired := newException("expired")
+	expired.Spec.ExpiresAt = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	notYetValid := newException("not-yet-valid")
+	notYetValid.Spec.NotBefore = &metav1.Time{Time: time.Now().Add(time.Hour)}
+	conditionFailed := newException("condition-failed")
+	conditionFailed.Spec.Conditions = &kyvernov2beta1.AnyAllConditions{
+		AllConditions: []kyvernov2beta1.Condition{newCondition(t, "{{ request.object.metadata.name }}", "Equals", "other")},
+	}
+	conditionPassed := newException("condition-passed")
+	conditionPassed.Spec.Conditions = &kyvernov2beta1.AnyAllConditions{
+		AllConditions: []kyvernov2beta1.Condition{newCondition(t, "{{ request.object.metadata.name }}", "Equals", "test")},
+	}
+	tests := []struct {
+		name       string
+		exceptions []*kyvernov2alpha1.PolicyException
+		want       string
+	}{{
+		name:       "no exception",
+		exceptions: nil,
+	}, {
+		name:       "active exception",
+		exceptions: []*kyvernov2alpha1.PolicyException{newException("active")},
+		want:       "rule skipped due to policy exception default/active",
+	}, {
+		name:       "expired exception",
+		exceptions: []*kyvernov2alpha1.PolicyException{expired},
+	}, {
+		name:       "not yet valid exception",
+		exceptions: []*kyvernov2alpha1.PolicyException{notYetValid},
+	}, {
+		name:       "conditions not met",
+		exceptions: []*kyvernov2alpha1.PolicyException{conditionFailed},
+	}, {
+		name:       "conditions met",
+		exceptions: []*kyvernov2alpha1.PolicyException{conditionPassed},
+		want:       "rule skipped due to policy exception default/condition-passed",
+	}, {
+		name:       "image scoped exception",
+		exceptions: []*kyvernov2alpha1.PolicyException{newException("scoped", "nginx*")},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policyContext := newExceptionPolicyContext(t)
+			selector := NewExceptionSelector(polexLister(tt.exceptions))
+			resp := hasPolicyExceptions(selector, policyContext, rule, nil, logging.GlobalLogger())
+			if tt.want == "" {
+				assert.Assert(t, resp == nil)
+			} else {
+				assert.Assert(t, resp != nil)
+				assert.Equal(t, resp.Message, tt.want)
+				assert.Equal(t, len(resp.Exceptions), 1)
+			}
+		})
+	}
+}
+
+func Test_filterExceptedImages(t *testing.T) {
+	rule := &kyvernov1.Rule{Name: "rule"}
+	var images []apiutils.ImageInfo
+	for _, image := range []string{"nginx:latest", "ghcr.io/kyverno/kyverno:latest"} {
+		info, err := imageutils.GetImageInfo(image, cfg)
+		assert.NilError(t, err)
+		images = append(images, apiutils.ImageInfo{ImageInfo: *info})
+	}
+	policyContext := newExceptionPolicyContext(t)
+	selector := NewExceptionSelector(polexLister{newException("scoped", "ghcr.io/kyverno/*")})
+	remaining, keys, err := filterExceptedImages(selector, policyContext, rule, nil, images, logging.GlobalLogger())
+	assert.NilError(t, err)
+	assert.Equal(t, len(remaining), 1)
+	assert.Equal(t, remaining[0].String(), images[0].String())
+	assert.DeepEqual(t, keys, []string{"default/scoped"})
+}
+
+func Test_ApprovedExceptions(t *testing.T) {
+	approved := newException("approved")
+	approved.Generation = 2
+	approved.Status.Approvals = []kyvernov2alpha1.Approval{{User: "alice", Generation: 2}}
+	stale := newException("stale")
+	stale.Generation = 2
+	stale.Status.Approvals = []kyvernov2alpha1.Approval{{User: "alice", Generation: 1}}
+	pending := newException("pending")
+	lister := polexLister{approved, stale, pending}
+	// no approvals required
+	polexs, err := ApprovedExceptions(lister, 0).List(labels.Everything())
+	assert.NilError(t, err)
+	assert.Equal(t, len(polexs), 3)
+	// one approval required
+	polexs, err = ApprovedExceptions(lister, 1).List(labels.Everything())
+	assert.NilError(t, err)
+	assert.Equal(t, len(polexs), 1)
+	assert.Equal(t, polexs[0].Name, "approved")
+}
@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+)
+
+// maxCachedFunctions bounds the number of function sets kept compiled
+const maxCachedFunctions = 1000
+
+// functionsCache holds the compiled functions of the policies, keyed by definition
+type functionsCache struct {
+	sync.Mutex
+	entries map[string][]*jmespath.FunctionEntry
+}
+
+func newFunctionsCache() *functionsCache {
+	return &functionsCache{
+		entries: map[string][]*jmespath.FunctionEntry{},
+	}
+}
+
+// loadPolicyFunctions makes the functions defined by the policy, the image functions using the engine configuration
+// and the lookup functions available to the queries of the JSON context, functions are compiled once per definition
+// and the lookup functions of namespaced policies are restricted to the namespace of the policy
+func (e *engine) loadPolicyFunctions(logger logr.Logger, policyContext engineapi.PolicyContext) {
+	var functions []kyvernov1.Function
+	lookupFunctions := e.lookupFunctions
+	if policy := policyContext.Policy(); policy != nil {
+		functions = policy.GetSpec().Functions
+		// namespaced policies can only read the resources of their namespace
+		if policy.IsNamespaced() && e.resourceLister != nil {
+			lookupFunctions = jmespath.NewLookupFunctions(jmespath.NewNamespacedResourceLister(e.resourceLister, policy.GetNamespace()))
+		}
+	}
+	entries, err := e.compileFunctions(functions)
+	if err != nil {
+		logger.Error(err, "failed to compile policy functions")
+	}
+	// entries are shared between evaluations and must not be modified
+	entries = append(append(append([]*jmespath.FunctionEntry{}, e.imageFunctions...), lookupFunctions...), entries...)
+	policyContext.JSONContext().SetFunctions(entries...)
+}
+
+func (e *engine) compileFunctions(functions []kyvernov1.Function) ([]*jmespath.FunctionEntry, error) {
+	if len(functions) == 0 {
+		return nil, nil
+	}
+	raw, err := json.Marshal(functions)
+	if err != nil {
+		return nil, err
+	}
+	key := string(raw)
+	e.functions.Lock()
+	defer e.functions.Unlock()
+	if entries, ok := e.functions.entries[key]; ok {
+		return entries, nil
+	}
+	userFunctions := make([]jmespath.UserFunction, 0, len(functions))
+	for _, function := range functions {
+		userFunctions = append(userFunctions, jmespath.UserFunction(function))
+	}
+	// user functions are compiled with the image functions of the engine
+	entries, err := jmespath.CompileUserFunctions(e.imageFunctions, userFunctions...)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.functions.entries) >= maxCachedFunctions {
+		e.functions.entries = map[string][]*jmespath.FunctionEntry{}
+	}
+	e.functions.entries[key] = entries
+	return entries, nil
+}
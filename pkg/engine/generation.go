@@ -11,17 +11,16 @@ import (
 )
 
 // GenerateResponse checks for validity of generate rule on the resource
-func GenerateResponse(
-	contextLoader ContextLoaderFactory,
+func (e *engine) GenerateResponse(
 	policyContext engineapi.PolicyContext,
 	gr kyvernov1beta1.UpdateRequest,
 ) (resp *engineapi.EngineResponse) {
 	policyStartTime := time.Now()
-	return filterGenerateRules(contextLoader, policyContext, gr.Spec.Policy, policyStartTime)
+	e.loadPolicyFunctions(logging.WithName("GenerateResponse"), policyContext)
+	return e.filterGenerateRules(policyContext, gr.Spec.Policy, policyStartTime)
 }
 
-func filterGenerateRules(
-	contextLoader ContextLoaderFactory,
+func (e *engine) filterGenerateRules(
 	policyContext engineapi.PolicyContext,
 	policyNameKey string,
 	startTime time.Time,
@@ -60,7 +59,7 @@ func filterGenerateRules(
 	}
 
 	for _, rule := range autogen.ComputeRules(policyContext.Policy()) {
-		if ruleResp := filterRule(contextLoader, rule, policyContext); ruleResp != nil {
+		if ruleResp := e.filterRule(rule, policyContext); ruleResp != nil {
 			resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResp)
 		}
 	}
@@ -66,13 +66,10 @@ func extractMatchingImages(policyContext engineapi.PolicyContext, rule *kyvernov
 	return matchingImages, imageRefs, nil
 }
 
-func VerifyAndPatchImages(
+func (e *engine) VerifyAndPatchImages(
 	ctx context.Context,
-	contextLoader ContextLoaderFactory,
-	rclient registryclient.Client,
 	policyContext engineapi.PolicyContext,
-	cfg config.Configuration,
-) (*engineapi.EngineResponse, *ImageVerificationMetadata) {
+) (*engineapi.EngineResponse, *engineapi.ImageVerificationMetadata) {
 	resp := &engineapi.EngineResponse{}
 
 	policy := policyContext.Policy()
@@ -88,10 +85,11 @@ func VerifyAndPatchImages(
 			"applied", resp.PolicyResponse.RulesAppliedCount, "successful", resp.IsSuccessful())
 	}()
 
+	e.loadPolicyFunctions(logger, policyContext)
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
 
-	ivm := &ImageVerificationMetadata{}
+	ivm := &engineapi.ImageVerificationMetadata{}
 	rules := autogen.ComputeRules(policyContext.Policy())
 	applyRules := policy.GetSpec().GetApplyRules()
 
@@ -106,6 +104,14 @@ func VerifyAndPatchImages(
 				if len(rule.VerifyImages) == 0 {
 					return
 				}
+				ctx, cancel := WithRuleTimeout(ctx, rule)
+				defer cancel()
+				first := len(resp.PolicyResponse.Rules)
+				defer func() {
+					for i := first; i < len(resp.PolicyResponse.Rules); i++ {
+						checkTimeout(ctx, &resp.PolicyResponse.Rules[i])
+					}
+				}()
 
 				kindsInPolicy := append(rule.MatchResources.GetKinds(), rule.ExcludeResources.GetKinds()...)
 				subresourceGVKToAPIResource := GetSubresourceGVKToAPIResourceMap(kindsInPolicy, policyContext)
@@ -115,7 +121,7 @@ func VerifyAndPatchImages(
 				}
 
 				// check if there is a corresponding policy exception
-				ruleResp := hasPolicyExceptions(policyContext, rule, subresourceGVKToAPIResource, logger)
+				ruleResp := hasPolicyExceptions(e.exceptionSelector, policyContext, rule, subresourceGVKToAPIResource, logger)
 				if ruleResp != nil {
 					resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResp)
 					return
@@ -123,7 +129,7 @@ func VerifyAndPatchImages(
 
 				logger.V(3).Info("processing image verification rule", "ruleSelector", applyRules)
 
-				ruleImages, imageRefs, err := extractMatchingImages(policyContext, rule, cfg)
+				ruleImages, imageRefs, err := extractMatchingImages(policyContext, rule, e.configuration)
 				if err != nil {
 					appendResponse(resp, rule, fmt.Sprintf("failed to extract images: %s", err.Error()), engineapi.RuleStatusError)
 					return
@@ -138,8 +144,19 @@ func VerifyAndPatchImages(
 					return
 				}
 
+				// check if there are image scoped policy exceptions
+				ruleImages, exceptions, err := filterExceptedImages(e.exceptionSelector, policyContext, rule, subresourceGVKToAPIResource, ruleImages, logger)
+				if err != nil {
+					appendResponse(resp, rule, fmt.Sprintf("failed to check policy exceptions: %s", err.Error()), engineapi.RuleStatusError)
+					return
+				}
+				if len(ruleImages) == 0 {
+					resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *exceptedImagesResponse(rule, exceptions))
+					return
+				}
+
 				policyContext.JSONContext().Restore()
-				if err := LoadContext(ctx, contextLoader, rule.Context, policyContext, rule.Name); err != nil {
+				if err := LoadContext(ctx, e.contextLoader, rule.Context, policyContext, rule.Name); err != nil {
 					appendResponse(resp, rule, fmt.Sprintf("failed to load context: %s", err.Error()), engineapi.RuleStatusError)
 					return
 				}
@@ -152,7 +169,7 @@ func VerifyAndPatchImages(
 
 				iv := &imageVerifier{
 					logger:        logger,
-					rclient:       rclient,
+					rclient:       e.rclient,
 					policyContext: policyContext,
 					rule:          ruleCopy,
 					resp:          resp,
@@ -160,7 +177,7 @@ func VerifyAndPatchImages(
 				}
 
 				for _, imageVerify := range ruleCopy.VerifyImages {
-					iv.verify(ctx, imageVerify, ruleImages, cfg)
+					iv.verify(ctx, imageVerify, ruleImages, e.configuration)
 				}
 			},
 		)
@@ -206,7 +223,7 @@ type imageVerifier struct {
 	policyContext engineapi.PolicyContext
 	rule          *kyvernov1.Rule
 	resp          *engineapi.EngineResponse
-	ivm           *ImageVerificationMetadata
+	ivm           *engineapi.ImageVerificationMetadata
 }
 
 // verify applies policy rules to each matching image. The policy rule results and annotation patches are
@@ -219,7 +236,7 @@ func (iv *imageVerifier) verify(ctx context.Context, imageVerify kyvernov1.Image
 		image := imageInfo.String()
 
 		if hasImageVerifiedAnnotationChanged(iv.policyContext, iv.logger) {
-			msg := imageVerifyAnnotationKey + " annotation cannot be changed"
+			msg := engineapi.ImageVerifyAnnotationKey + " annotation cannot be changed"
 			iv.logger.Info("image verification error", "reason", msg)
 			ruleResp := ruleResponse(*iv.rule, engineapi.ImageVerify, msg, engineapi.RuleStatusFail)
 			iv.resp.PolicyResponse.Rules = append(iv.resp.PolicyResponse.Rules, *ruleResp)
@@ -260,7 +277,7 @@ func (iv *imageVerifier) verify(ctx context.Context, imageVerify kyvernov1.Image
 		if ruleResp != nil {
 			if len(imageVerify.Attestors) > 0 || len(imageVerify.Attestations) > 0 {
 				verified := ruleResp.Status == engineapi.RuleStatusPass
-				iv.ivm.add(image, verified)
+				iv.ivm.Add(image, verified)
 			}
 
 			iv.resp.PolicyResponse.Rules = append(iv.resp.PolicyResponse.Rules, *ruleResp)
@@ -300,7 +317,7 @@ func hasImageVerifiedAnnotationChanged(ctx engineapi.PolicyContext, log logr.Log
 		return false
 	}
 
-	key := imageVerifyAnnotationKey
+	key := engineapi.ImageVerifyAnnotationKey
 	newValue := newResource.GetAnnotations()[key]
 	oldValue := oldResource.GetAnnotations()[key]
 	result := newValue != oldValue
@@ -404,7 +421,7 @@ func (iv *imageVerifier) verifyAttestors(
 func (iv *imageVerifier) handleRegistryErrors(image string, err error) *engineapi.RuleResponse {
 	msg := fmt.Sprintf("failed to verify image %s: %s", image, err.Error())
 	var netErr *net.OpError
-	if errors.As(err, &netErr) {
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
 		return ruleResponse(*iv.rule, engineapi.ImageVerify, msg, engineapi.RuleStatusError)
 	}
 
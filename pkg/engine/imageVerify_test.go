@@ -165,15 +165,18 @@ func doVerifyAndPatchImages(
 	rclient registryclient.Client,
 	pContext engineapi.PolicyContext,
 	cfg config.Configuration,
-) (*engineapi.EngineResponse, *ImageVerificationMetadata) {
-	return VerifyAndPatchImages(
-		ctx,
-		LegacyContextLoaderFactory(rclient),
+) (*engineapi.EngineResponse, *engineapi.ImageVerificationMetadata) {
+	e := NewEngine(
+		cfg,
 		rclient,
+		LegacyContextLoaderFactory(rclient),
+		nil,
+		nil,
+	)
+	return e.VerifyAndPatchImages(
+		ctx,
 		pContext,
-		cfg,
 	)
-
 }
 
 func Test_CosignMockAttest(t *testing.T) {
@@ -187,7 +190,7 @@ func Test_CosignMockAttest(t *testing.T) {
 		fmt.Sprintf("expected: %v, got: %v, failure: %v",
 			engineapi.RuleStatusPass, er.PolicyResponse.Rules[0].Status, er.PolicyResponse.Rules[0].Message))
 	assert.Equal(t, ivm.IsEmpty(), false)
-	assert.Equal(t, ivm.isVerified("ghcr.io/jimbugwadia/pause2:latest"), true)
+	assert.Equal(t, ivm.IsVerified("ghcr.io/jimbugwadia/pause2:latest"), true)
 }
 
 func Test_CosignMockAttest_fail(t *testing.T) {
@@ -737,7 +740,7 @@ func createStaticKeyAttestorSet(s string, withPublicKey, withSecret, withKMS boo
 }
 
 func Test_ChangedAnnotation(t *testing.T) {
-	annotationKey := imageVerifyAnnotationKey
+	annotationKey := engineapi.ImageVerifyAnnotationKey
 	annotationNew := fmt.Sprintf("\"annotations\": {\"%s\": \"%s\"}", annotationKey, "true")
 	newResource := strings.ReplaceAll(testResource, "\"annotations\": {}", annotationNew)
 
@@ -773,7 +776,7 @@ func Test_MarkImageVerified(t *testing.T) {
 	assert.Assert(t, verifiedImages != nil)
 	assert.Assert(t, verifiedImages.Data != nil)
 	assert.Equal(t, len(verifiedImages.Data), 1)
-	assert.Equal(t, verifiedImages.isVerified(image), true)
+	assert.Equal(t, verifiedImages.IsVerified(image), true)
 
 	patches, err := verifiedImages.Patches(false, logging.GlobalLogger())
 	assert.NilError(t, err)
@@ -783,7 +786,7 @@ func Test_MarkImageVerified(t *testing.T) {
 	patchedAnnotations := resource.GetAnnotations()
 	assert.Equal(t, len(patchedAnnotations), 1)
 
-	json := patchedAnnotations[imageVerifyAnnotationKey]
+	json := patchedAnnotations[engineapi.ImageVerifyAnnotationKey]
 	assert.Assert(t, json != "")
 
 	verified, err := isImageVerified(resource, image, logging.GlobalLogger())
@@ -866,5 +869,5 @@ func Test_ParsePEMDelimited(t *testing.T) {
 	assert.Assert(t, verifiedImages != nil)
 	assert.Assert(t, verifiedImages.Data != nil)
 	assert.Equal(t, len(verifiedImages.Data), 1)
-	assert.Equal(t, verifiedImages.isVerified(image), true)
+	assert.Equal(t, verifiedImages.IsVerified(image), true)
 }
@@ -0,0 +1,178 @@
+package jmespath
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+)
+
+// function names
+var (
+	sha256Hash = "sha256"
+	sha512Hash = "sha512"
+	hmacSha256 = "hmac_sha256"
+	jwtDecode  = "jwt_decode"
+	jwtVerify  = "jwt_verify"
+)
+
+func jpSha256(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(sha256Hash, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(str.String()))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func jpSha512(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(sha512Hash, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha512.Sum512([]byte(str.String()))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func jpHmacSha256(arguments []interface{}) (interface{}, error) {
+	message, err := validateArg(hmacSha256, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	key, err := validateArg(hmacSha256, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, []byte(key.String()))
+	mac.Write([]byte(message.String()))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func jpJwtDecode(arguments []interface{}) (interface{}, error) {
+	token, err := validateArg(jwtDecode, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(strings.TrimSpace(token.String()), ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf(genericError, jwtDecode, "token must have three parts separated by dots")
+	}
+	header, err := decodeJwtPart(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf(genericError, jwtDecode, fmt.Sprintf("invalid header: %v", err))
+	}
+	claims, err := decodeJwtPart(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf(genericError, jwtDecode, fmt.Sprintf("invalid claims: %v", err))
+	}
+	return map[string]interface{}{
+		"header":    header,
+		"claims":    claims,
+		"signature": parts[2],
+	}, nil
+}
+
+func decodeJwtPart(part string) (map[string]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	if err != nil {
+		return nil, err
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+func jpJwtVerify(arguments []interface{}) (interface{}, error) {
+	token, err := validateArg(jwtVerify, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseJwks(arguments[1])
+	if err != nil {
+		return nil, fmt.Errorf(genericError, jwtVerify, err.Error())
+	}
+	signature, err := jose.ParseSigned(strings.TrimSpace(token.String()))
+	if err != nil {
+		return false, nil
+	}
+	payload, err := verifyJws(signature, keys)
+	if err != nil {
+		return nil, fmt.Errorf(genericError, jwtVerify, err.Error())
+	}
+	if payload == nil {
+		return false, nil
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return false, nil
+	}
+	// the registered time claims are checked when present
+	now := float64(time.Now().Unix())
+	if exp, ok := claims["exp"].(float64); ok && now >= exp {
+		return false, nil
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now < nbf {
+		return false, nil
+	}
+	return true, nil
+}
+
+// verifyJws verifies the signature with the keys matching the key id of the token, or with every key of the set
+// when the token has no key id or no key matches it. It returns the payload, or nil if no key verifies the signature.
+func verifyJws(signature *jose.JSONWebSignature, keys *jose.JSONWebKeySet) ([]byte, error) {
+	candidates := keys.Keys
+	for _, s := range signature.Signatures {
+		if s.Header.KeyID != "" {
+			if matching := keys.Key(s.Header.KeyID); len(matching) > 0 {
+				candidates = matching
+			}
+			break
+		}
+	}
+	for _, key := range candidates {
+		payload, err := signature.Verify(key)
+		if err == nil {
+			return payload, nil
+		}
+		if errors.Is(err, jose.ErrUnsupportedKeyType) {
+			return nil, fmt.Errorf("key %q can't verify signatures: %v", key.KeyID, err)
+		}
+	}
+	return nil, nil
+}
+
+// parseJwks parses a JSON Web Key Set given as a JSON string or an object
+func parseJwks(arg interface{}) (*jose.JSONWebKeySet, error) {
+	var data []byte
+	switch typed := arg.(type) {
+	case string:
+		data = []byte(typed)
+	case map[string]interface{}:
+		raw, err := json.Marshal(typed)
+		if err != nil {
+			return nil, err
+		}
+		data = raw
+	default:
+		return nil, fmt.Errorf("the key set must be a string or an object")
+	}
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("invalid key set: %v", err)
+	}
+	if len(keys.Keys) == 0 {
+		return nil, fmt.Errorf("the key set has no keys")
+	}
+	return &keys, nil
+}
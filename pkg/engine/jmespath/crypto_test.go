@@ -0,0 +1,145 @@
+package jmespath
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"gotest.tools/assert"
+)
+
+func Test_Hashes(t *testing.T) {
+	testCases := []struct {
+		jmesPath       string
+		expectedResult string
+	}{
+		{jmesPath: "sha256('kyverno')", expectedResult: "6900ead2739f4d80767db3f097b8c7e352c395dac245945161cb7d5e3f8438b3"},
+		{jmesPath: "sha512('')", expectedResult: "cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e"},
+		{jmesPath: "hmac_sha256('The quick brown fox jumps over the lazy dog', 'key')", expectedResult: "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.jmesPath, func(t *testing.T) {
+			jp, err := New(tc.jmesPath)
+			assert.NilError(t, err)
+			result, err := jp.Search("")
+			assert.NilError(t, err)
+			assert.Equal(t, result, tc.expectedResult)
+		})
+	}
+}
+
+func newToken(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: jose.JSONWebKey{Key: key, KeyID: kid}}, nil)
+	assert.NilError(t, err)
+	payload, err := json.Marshal(claims)
+	assert.NilError(t, err)
+	signature, err := signer.Sign(payload)
+	assert.NilError(t, err)
+	token, err := signature.CompactSerialize()
+	assert.NilError(t, err)
+	return token
+}
+
+func Test_JwtDecode(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	token := newToken(t, key, "signing-key", map[string]interface{}{"sub": "system:serviceaccount:default:app", "aud": "vault"})
+
+	jp, err := New(fmt.Sprintf("jwt_decode('%s')", token))
+	assert.NilError(t, err)
+	result, err := jp.Search("")
+	assert.NilError(t, err)
+	decoded := result.(map[string]interface{})
+	assert.DeepEqual(t, decoded["header"], map[string]interface{}{"alg": "ES256", "kid": "signing-key"})
+	assert.DeepEqual(t, decoded["claims"], map[string]interface{}{"sub": "system:serviceaccount:default:app", "aud": "vault"})
+
+	jp, err = New("jwt_decode('not-a-token')")
+	assert.NilError(t, err)
+	_, err = jp.Search("")
+	assert.ErrorContains(t, err, "token must have three parts")
+}
+
+func Test_JwtVerify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: "signing-key", Algorithm: "ES256", Use: "sig"}}})
+	assert.NilError(t, err)
+	now := time.Now().Unix()
+	testCases := []struct {
+		description    string
+		token          string
+		expectedResult bool
+	}{
+		{
+			description:    "valid token",
+			token:          newToken(t, key, "signing-key", map[string]interface{}{"sub": "app", "exp": now + 3600}),
+			expectedResult: true,
+		},
+		{
+			description:    "expired token",
+			token:          newToken(t, key, "signing-key", map[string]interface{}{"sub": "app", "exp": now - 3600}),
+			expectedResult: false,
+		},
+		{
+			description:    "token not valid yet",
+			token:          newToken(t, key, "signing-key", map[string]interface{}{"sub": "app", "nbf": now + 3600}),
+			expectedResult: false,
+		},
+		{
+			description:    "unknown key",
+			token:          newToken(t, other, "signing-key", map[string]interface{}{"sub": "app"}),
+			expectedResult: false,
+		},
+		{
+			description:    "malformed token",
+			token:          "not-a-token",
+			expectedResult: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			jp, err := New("jwt_verify(token, jwks)")
+			assert.NilError(t, err)
+			result, err := jp.Search(map[string]interface{}{"token": tc.token, "jwks": string(jwks)})
+			assert.NilError(t, err)
+			assert.Equal(t, result, tc.expectedResult)
+		})
+	}
+
+	var jwksObject map[string]interface{}
+	assert.NilError(t, json.Unmarshal(jwks, &jwksObject))
+	jp, err := New("jwt_verify(token, jwks)")
+	assert.NilError(t, err)
+	result, err := jp.Search(map[string]interface{}{"token": testCases[0].token, "jwks": jwksObject})
+	assert.NilError(t, err)
+	assert.Equal(t, result, true)
+
+	_, err = jp.Search(map[string]interface{}{"token": testCases[0].token, "jwks": `{"keys":[]}`})
+	assert.ErrorContains(t, err, "the key set has no keys")
+
+	// tokens without key id are verified with every key of the set
+	keys, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: other.Public(), KeyID: "other-key", Algorithm: "ES256", Use: "sig"},
+		{Key: key.Public(), KeyID: "signing-key", Algorithm: "ES256", Use: "sig"},
+	}})
+	assert.NilError(t, err)
+	result, err = jp.Search(map[string]interface{}{"token": newToken(t, key, "", map[string]interface{}{"sub": "app"}), "jwks": string(keys)})
+	assert.NilError(t, err)
+	assert.Equal(t, result, true)
+	result, err = jp.Search(map[string]interface{}{"token": newToken(t, key, "unknown-key", map[string]interface{}{"sub": "app"}), "jwks": string(keys)})
+	assert.NilError(t, err)
+	assert.Equal(t, result, true)
+
+	// keys that can't verify signatures are reported
+	privateKeys, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key, KeyID: "signing-key", Algorithm: "ES256", Use: "sig"}}})
+	assert.NilError(t, err)
+	_, err = jp.Search(map[string]interface{}{"token": testCases[0].token, "jwks": string(privateKeys)})
+	assert.ErrorContains(t, err, `key "signing-key" can't verify signatures`)
+}
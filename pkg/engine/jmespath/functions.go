@@ -600,6 +600,181 @@ func GetFunctions() []*FunctionEntry {
 			ReturnType: []JpType{JpString},
 			Note:       "returns the result of rounding time down to a multiple of duration",
 		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: cidrContains,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpCidrContains,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "checks if a CIDR (first string) contains an IP address or a CIDR (second string)",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: ipInRange,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpIPInRange,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "checks if an IP address is between a start and end IP address, both inclusive",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: cidrOverlaps,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpCidrOverlaps,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "checks if two CIDRs have at least one IP address in common",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: isPrivateIP,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpIsPrivateIP,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "checks if an IP address is private according to RFC 1918 (IPv4) or RFC 4193 (IPv6)",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: parseURL,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpParseURL,
+			},
+			ReturnType: []JpType{JpObject},
+			Note:       "decodes a URL to an object with the scheme, user, host, hostname, port, path, query and fragment of the URL",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: portInRange,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpNumber, JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpPortInRange,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "checks if a port is in a port range (e.g. 8000-9000), both inclusive",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: sha256Hash,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpSha256,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "computes the SHA-256 hash of a string, hex encoded",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: sha512Hash,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpSha512,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "computes the SHA-512 hash of a string, hex encoded",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: hmacSha256,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpHmacSha256,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "computes the HMAC-SHA256 of a message (first string) with a key (second string), hex encoded",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: jwtDecode,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpJwtDecode,
+			},
+			ReturnType: []JpType{JpObject},
+			Note:       "decodes the header and claims of a JWT without verifying its signature",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: jwtVerify,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString, JpObject}},
+				},
+				Handler: jpJwtVerify,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "verifies the signature of a JWT with a JSON Web Key Set (string or object) and checks its exp and nbf claims, the keys matching the kid header are used, or all the keys if none matches",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: quantityCompare,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString, JpNumber}},
+					{Types: []JpType{JpString, JpNumber}},
+				},
+				Handler: jpQuantityCompare,
+			},
+			ReturnType: []JpType{JpNumber},
+			Note:       "compares two resource quantities (e.g. '500Mi' and '1Gi'), returns -1, 0 or 1",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: quantitySum,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpArray}},
+				},
+				Handler: jpQuantitySum,
+			},
+			ReturnType: []JpType{JpString},
+			Note:       "sums an array of resource quantities",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: selectorMatches,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpObject}},
+					{Types: []JpType{JpObject}},
+				},
+				Handler: jpSelectorMatches,
+			},
+			ReturnType: []JpType{JpBool},
+			Note:       "checks if a set of labels matches a label selector with matchLabels and matchExpressions",
+		},
+		imageParseEntry(defaultConfiguration),
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: durationParse,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+				},
+				Handler: jpDurationParse,
+			},
+			ReturnType: []JpType{JpNumber},
+			Note:       "parses a duration (e.g. '1h30m') and returns it in seconds",
+		},
 	}
 }
 
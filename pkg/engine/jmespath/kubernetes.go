@@ -0,0 +1,160 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	gojmespath "github.com/jmespath/go-jmespath"
+	"github.com/kyverno/kyverno/pkg/config"
+	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// function names
+var (
+	quantityCompare = "quantity_compare"
+	quantitySum     = "quantity_sum"
+	selectorMatches = "selector_matches"
+	imageParse      = "image_parse"
+	durationParse   = "duration_parse"
+)
+
+func jpQuantityCompare(arguments []interface{}) (interface{}, error) {
+	first, err := parseQuantityArg(quantityCompare, arguments[0], 1)
+	if err != nil {
+		return nil, err
+	}
+	second, err := parseQuantityArg(quantityCompare, arguments[1], 2)
+	if err != nil {
+		return nil, err
+	}
+	return float64(first.Cmp(second)), nil
+}
+
+func jpQuantitySum(arguments []interface{}) (interface{}, error) {
+	values, ok := arguments[0].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf(invalidArgumentTypeError, quantitySum, 1, "Array")
+	}
+	var sum resource.Quantity
+	for _, value := range values {
+		quantity, err := parseQuantityArg(quantitySum, value, 1)
+		if err != nil {
+			return nil, err
+		}
+		sum.Add(quantity)
+	}
+	return sum.String(), nil
+}
+
+// parseQuantityArg parses a quantity given as a string or a number
+func parseQuantityArg(f string, arg interface{}, index int) (resource.Quantity, error) {
+	switch typed := arg.(type) {
+	case string:
+		quantity, err := resource.ParseQuantity(typed)
+		if err != nil {
+			return resource.Quantity{}, fmt.Errorf(genericError, f, err.Error())
+		}
+		return quantity, nil
+	case float64:
+		quantity, err := resource.ParseQuantity(strconv.FormatFloat(typed, 'f', -1, 64))
+		if err != nil {
+			return resource.Quantity{}, fmt.Errorf(genericError, f, err.Error())
+		}
+		return quantity, nil
+	}
+	return resource.Quantity{}, fmt.Errorf(invalidArgumentTypeError, f, index, "String or Number")
+}
+
+func jpSelectorMatches(arguments []interface{}) (interface{}, error) {
+	selectorArg, err := validateArg(selectorMatches, arguments, 0, reflect.Map)
+	if err != nil {
+		return nil, err
+	}
+	labelsArg, err := validateArg(selectorMatches, arguments, 1, reflect.Map)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(selectorArg.Interface())
+	if err != nil {
+		return nil, fmt.Errorf(genericError, selectorMatches, err.Error())
+	}
+	var labelSelector metav1.LabelSelector
+	if err := json.Unmarshal(raw, &labelSelector); err != nil {
+		return nil, fmt.Errorf(genericError, selectorMatches, fmt.Sprintf("invalid label selector: %v", err))
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf(genericError, selectorMatches, err.Error())
+	}
+	set := labels.Set{}
+	for key, value := range labelsArg.Interface().(map[string]interface{}) {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf(genericError, selectorMatches, fmt.Sprintf("the value of label %s must be a string", key))
+		}
+		set[key] = s
+	}
+	return selector.Matches(set), nil
+}
+
+// defaultConfiguration is used by the image functions returned by GetFunctions
+var defaultConfiguration = config.NewDefaultConfiguration()
+
+// NewImageFunctions returns the image functions using the default registry of the given configuration, the
+// returned entries can be passed to New to replace the functions of GetFunctions using the default configuration
+func NewImageFunctions(configuration config.Configuration) []*FunctionEntry {
+	return []*FunctionEntry{imageParseEntry(configuration)}
+}
+
+func imageParseEntry(configuration config.Configuration) *FunctionEntry {
+	return &FunctionEntry{
+		Entry: &gojmespath.FunctionEntry{
+			Name: imageParse,
+			Arguments: []ArgSpec{
+				{Types: []JpType{JpString}},
+			},
+			Handler: func(arguments []interface{}) (interface{}, error) {
+				return jpImageParse(configuration, arguments)
+			},
+		},
+		ReturnType: []JpType{JpObject},
+		Note:       "parses an image reference into its registry, path, name, tag and digest, images without registry use the default registry",
+	}
+}
+
+func jpImageParse(configuration config.Configuration, arguments []interface{}) (interface{}, error) {
+	image, err := validateArg(imageParse, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	info, err := imageutils.GetImageInfo(image.String(), configuration)
+	if err != nil {
+		return nil, fmt.Errorf(genericError, imageParse, err.Error())
+	}
+	return map[string]interface{}{
+		"registry":  info.Registry,
+		"path":      info.Path,
+		"name":      info.Name,
+		"tag":       info.Tag,
+		"digest":    info.Digest,
+		"reference": info.String(),
+	}, nil
+}
+
+func jpDurationParse(arguments []interface{}) (interface{}, error) {
+	str, err := validateArg(durationParse, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	duration, err := time.ParseDuration(str.String())
+	if err != nil {
+		return nil, fmt.Errorf(genericError, durationParse, err.Error())
+	}
+	return duration.Seconds(), nil
+}
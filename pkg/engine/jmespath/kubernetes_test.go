@@ -0,0 +1,99 @@
+package jmespath
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+)
+
+func Test_KubernetesFunctions(t *testing.T) {
+	data := map[string]interface{}{
+		"labels": map[string]interface{}{"app": "web", "tier": "frontend"},
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{"memory": "500Mi", "cpu": "250m"},
+			"limits":   map[string]interface{}{"memory": "1Gi", "cpu": 1.0},
+		},
+		"containers": []interface{}{
+			map[string]interface{}{"memory": "256Mi"},
+			map[string]interface{}{"memory": "1Gi"},
+		},
+	}
+	testCases := []struct {
+		jmesPath       string
+		expectedResult interface{}
+	}{
+		{jmesPath: "quantity_compare(resources.requests.memory, resources.limits.memory)", expectedResult: -1.0},
+		{jmesPath: "quantity_compare('1Gi', '1024Mi')", expectedResult: 0.0},
+		{jmesPath: "quantity_compare(resources.limits.cpu, resources.requests.cpu)", expectedResult: 1.0},
+		{jmesPath: "quantity_compare('1Gi', '500Mi') > `0`", expectedResult: true},
+		{jmesPath: "quantity_sum(containers[].memory)", expectedResult: "1280Mi"},
+		{jmesPath: "quantity_sum(['250m', '750m', `1`])", expectedResult: "2"},
+		{jmesPath: "quantity_sum(`[]`)", expectedResult: "0"},
+		{jmesPath: "selector_matches({matchLabels: {app: 'web'}}, labels)", expectedResult: true},
+		{jmesPath: "selector_matches({matchExpressions: [{key: 'tier', operator: 'In', values: ['backend', 'frontend']}]}, labels)", expectedResult: true},
+		{jmesPath: "selector_matches({matchLabels: {app: 'web'}, matchExpressions: [{key: 'tier', operator: 'NotIn', values: ['frontend']}]}, labels)", expectedResult: false},
+		{jmesPath: "selector_matches({matchExpressions: [{key: 'team', operator: 'DoesNotExist'}]}, labels)", expectedResult: true},
+		{jmesPath: "selector_matches(`{}`, labels)", expectedResult: true},
+		{jmesPath: "image_parse('nginx')", expectedResult: map[string]interface{}{"registry": "docker.io", "path": "nginx", "name": "nginx", "tag": "latest", "digest": "", "reference": "docker.io/nginx:latest"}},
+		{jmesPath: "image_parse('ghcr.io/kyverno/kyverno:v1.9.0').{registry: registry, path: path, tag: tag}", expectedResult: map[string]interface{}{"registry": "ghcr.io", "path": "kyverno/kyverno", "tag": "v1.9.0"}},
+		{jmesPath: "image_parse('registry.corp:5000/app@sha256:128c6e3534b842a2eec139999b8ce8aa9a2af9907e2b9269550809d18cd832a3').digest", expectedResult: "sha256:128c6e3534b842a2eec139999b8ce8aa9a2af9907e2b9269550809d18cd832a3"},
+		{jmesPath: "duration_parse('1h30m')", expectedResult: 5400.0},
+		{jmesPath: "duration_parse('500ms')", expectedResult: 0.5},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.jmesPath, func(t *testing.T) {
+			jp, err := New(tc.jmesPath)
+			assert.NilError(t, err)
+			result, err := jp.Search(data)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result, tc.expectedResult)
+		})
+	}
+}
+
+func Test_KubernetesFunctionsErrors(t *testing.T) {
+	testCases := []string{
+		"quantity_compare('1Gi', 'big')",
+		"quantity_sum(['1Gi', `true`])",
+		"selector_matches({matchExpressions: [{key: 'app', operator: 'Unknown'}]}, `{}`)",
+		"selector_matches(`{}`, {app: `1`})",
+		"image_parse('INVALID:image:name')",
+		"duration_parse('1 day')",
+	}
+	for _, jmesPath := range testCases {
+		t.Run(jmesPath, func(t *testing.T) {
+			jp, err := New(jmesPath)
+			assert.NilError(t, err)
+			_, err = jp.Search(map[string]interface{}{})
+			assert.Assert(t, err != nil)
+		})
+	}
+}
+
+type registryConfiguration struct {
+	config.Configuration
+	registry string
+}
+
+func (c registryConfiguration) GetDefaultRegistry() string {
+	return c.registry
+}
+
+func Test_ImageFunctions(t *testing.T) {
+	configuration := registryConfiguration{Configuration: config.NewDefaultConfiguration(), registry: "registry.corp"}
+	jp, err := New("image_parse('nginx').reference", NewImageFunctions(configuration)...)
+	assert.NilError(t, err)
+	result, err := jp.Search(map[string]interface{}{})
+	assert.NilError(t, err)
+	assert.Equal(t, result, "registry.corp/nginx:latest")
+
+	// user defined functions use the given image functions
+	functions, err := CompileUserFunctions(NewImageFunctions(configuration), UserFunction{Name: "registry", Parameters: []string{"image"}, Expression: "image_parse(image).registry"})
+	assert.NilError(t, err)
+	jp, err = New("registry('nginx')", functions...)
+	assert.NilError(t, err)
+	result, err = jp.Search(map[string]interface{}{})
+	assert.NilError(t, err)
+	assert.Equal(t, result, "registry.corp")
+}
@@ -0,0 +1,163 @@
+package jmespath
+
+import (
+	"fmt"
+	"reflect"
+
+	gojmespath "github.com/jmespath/go-jmespath"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// function names
+var (
+	lookup        = "lookup"
+	listResources = "list"
+)
+
+// lookupFunctions are the names of the functions returned by NewLookupFunctions
+var lookupFunctions = []string{lookup, listResources}
+
+// ResourceLister reads the resources of the cluster for the lookup and list functions
+type ResourceLister interface {
+	// Get returns the resource of the given kind, namespace and name, namespace is empty for cluster wide resources
+	Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error)
+	// List returns the resources of the given kind matching the selector, all namespaces are listed if namespace is empty
+	List(apiVersion, kind, namespace string, selector labels.Selector) ([]*unstructured.Unstructured, error)
+}
+
+// NewNamespacedResourceLister returns a lister reading only the resources of the given namespace, it is used for
+// the lookup and list functions of namespaced policies. Resources of other namespaces and cluster wide resources
+// can't be read, all namespaces are replaced with the given namespace when listing resources.
+func NewNamespacedResourceLister(lister ResourceLister, namespace string) ResourceLister {
+	return namespacedResourceLister{lister: lister, namespace: namespace}
+}
+
+type namespacedResourceLister struct {
+	lister    ResourceLister
+	namespace string
+}
+
+func (l namespacedResourceLister) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	if namespace != l.namespace {
+		return nil, fmt.Errorf("namespaced policies can only read resources of namespace %s", l.namespace)
+	}
+	return l.lister.Get(apiVersion, kind, namespace, name)
+}
+
+func (l namespacedResourceLister) List(apiVersion, kind, namespace string, selector labels.Selector) ([]*unstructured.Unstructured, error) {
+	if namespace == "" {
+		namespace = l.namespace
+	}
+	if namespace != l.namespace {
+		return nil, fmt.Errorf("namespaced policies can only read resources of namespace %s", l.namespace)
+	}
+	return l.lister.List(apiVersion, kind, namespace, selector)
+}
+
+// NewLookupFunctions returns the lookup and list functions reading resources from the given lister,
+// the returned entries can be passed to New
+func NewLookupFunctions(lister ResourceLister) []*FunctionEntry {
+	return []*FunctionEntry{
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: lookup,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+				},
+				Handler: func(arguments []interface{}) (interface{}, error) {
+					return jpLookup(lister, arguments)
+				},
+			},
+			ReturnType: []JpType{JpObject},
+			Note:       "returns the resource with the given apiVersion, kind, namespace and name, null if it doesn't exist, the namespace is empty for cluster wide resources, namespaced policies can only read resources of their namespace",
+		},
+		{
+			Entry: &gojmespath.FunctionEntry{
+				Name: listResources,
+				Arguments: []ArgSpec{
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpString}},
+					{Types: []JpType{JpAny}},
+				},
+				Handler: func(arguments []interface{}) (interface{}, error) {
+					return jpList(lister, arguments)
+				},
+			},
+			ReturnType: []JpType{JpArray},
+			Note:       "returns the resources with the given apiVersion and kind matching a label selector (string or map of labels), all namespaces are listed if the namespace is empty, namespaced policies can only list resources of their namespace",
+		},
+	}
+}
+
+func jpLookup(lister ResourceLister, arguments []interface{}) (interface{}, error) {
+	var args [4]string
+	for i := range args {
+		arg, err := validateArg(lookup, arguments, i, reflect.String)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg.String()
+	}
+	if args[3] == "" {
+		return nil, fmt.Errorf(genericError, lookup, "name must not be empty")
+	}
+	resource, err := lister.Get(args[0], args[1], args[2], args[3])
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf(genericError, lookup, err.Error())
+	}
+	return resource.UnstructuredContent(), nil
+}
+
+func jpList(lister ResourceLister, arguments []interface{}) (interface{}, error) {
+	var args [3]string
+	for i := range args {
+		arg, err := validateArg(listResources, arguments, i, reflect.String)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg.String()
+	}
+	selector, err := parseSelector(arguments[3])
+	if err != nil {
+		return nil, fmt.Errorf(genericError, listResources, err.Error())
+	}
+	resources, err := lister.List(args[0], args[1], args[2], selector)
+	if err != nil {
+		return nil, fmt.Errorf(genericError, listResources, err.Error())
+	}
+	items := make([]interface{}, 0, len(resources))
+	for _, resource := range resources {
+		items = append(items, resource.UnstructuredContent())
+	}
+	return items, nil
+}
+
+// parseSelector converts a label selector given as a string or a map of labels, null selects everything
+func parseSelector(selector interface{}) (labels.Selector, error) {
+	switch typed := selector.(type) {
+	case nil:
+		return labels.Everything(), nil
+	case string:
+		return labels.Parse(typed)
+	case map[string]interface{}:
+		set := make(labels.Set, len(typed))
+		for key, value := range typed {
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("the value of label %s must be a string", key)
+			}
+			set[key] = s
+		}
+		return labels.ValidatedSelectorFromSet(set)
+	}
+	return nil, fmt.Errorf("selector must be a string, an object or null")
+}
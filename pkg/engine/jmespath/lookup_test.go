@@ -0,0 +1,134 @@
+package jmespath
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type fakeLister []*unstructured.Unstructured
+
+func (l fakeLister) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	for _, resource := range l {
+		if resource.GetAPIVersion() == apiVersion && resource.GetKind() == kind && resource.GetNamespace() == namespace && resource.GetName() == name {
+			return resource, nil
+		}
+	}
+	return nil, kerrors.NewNotFound(schema.GroupResource{Resource: kind}, name)
+}
+
+func (l fakeLister) List(apiVersion, kind, namespace string, selector labels.Selector) ([]*unstructured.Unstructured, error) {
+	var resources []*unstructured.Unstructured
+	for _, resource := range l {
+		if resource.GetAPIVersion() != apiVersion || resource.GetKind() != kind {
+			continue
+		}
+		if namespace != "" && resource.GetNamespace() != namespace {
+			continue
+		}
+		if selector.Matches(labels.Set(resource.GetLabels())) {
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+func newIngress(namespace, name, host string, labels map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata":   map[string]interface{}{"namespace": namespace, "name": name, "labels": labels},
+		"spec":       map[string]interface{}{"rules": []interface{}{map[string]interface{}{"host": host}}},
+	}}
+}
+
+func Test_LookupFunctions(t *testing.T) {
+	lister := fakeLister{
+		newIngress("default", "web", "web.example.com", map[string]interface{}{"app": "web"}),
+		newIngress("default", "api", "api.example.com", map[string]interface{}{"app": "api"}),
+		newIngress("test", "web", "web.test.example.com", map[string]interface{}{"app": "web"}),
+	}
+	testCases := []struct {
+		query          string
+		expectedResult interface{}
+	}{
+		{query: "lookup('networking.k8s.io/v1', 'Ingress', 'default', 'web').spec.rules[0].host", expectedResult: "web.example.com"},
+		{query: "lookup('networking.k8s.io/v1', 'Ingress', 'default', 'missing')", expectedResult: nil},
+		{query: "length(list('networking.k8s.io/v1', 'Ingress', '', null))", expectedResult: 3.0},
+		{query: "list('networking.k8s.io/v1', 'Ingress', 'default', '')[].metadata.name", expectedResult: []interface{}{"web", "api"}},
+		{query: "list('networking.k8s.io/v1', 'Ingress', '', 'app=web')[].spec.rules[].host[]", expectedResult: []interface{}{"web.example.com", "web.test.example.com"}},
+		{query: "list('networking.k8s.io/v1', 'Ingress', '', {app: 'api'})[].metadata.name", expectedResult: []interface{}{"api"}},
+		{query: "list('networking.k8s.io/v1', 'Ingress', 'test', 'app in (api)')", expectedResult: []interface{}{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			jp, err := New(tc.query, NewLookupFunctions(lister)...)
+			assert.NilError(t, err)
+			result, err := jp.Search(map[string]interface{}{})
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result, tc.expectedResult)
+		})
+	}
+}
+
+func Test_LookupFunctionsErrors(t *testing.T) {
+	testCases := []string{
+		"lookup('networking.k8s.io/v1', 'Ingress', 'default', '')",
+		"list('networking.k8s.io/v1', 'Ingress', '', 'app in (')",
+		"list('networking.k8s.io/v1', 'Ingress', '', {app: `1`})",
+		"list('networking.k8s.io/v1', 'Ingress', '', `1`)",
+	}
+	for _, query := range testCases {
+		t.Run(query, func(t *testing.T) {
+			jp, err := New(query, NewLookupFunctions(fakeLister{})...)
+			assert.NilError(t, err)
+			_, err = jp.Search(map[string]interface{}{})
+			assert.Assert(t, err != nil)
+		})
+	}
+}
+
+func Test_LookupFunctionsReserved(t *testing.T) {
+	_, err := NewUserFunctions(UserFunction{Name: "lookup", Expression: "@"})
+	assert.Error(t, err, "function lookup conflicts with a built-in function")
+}
+
+func Test_NamespacedResourceLister(t *testing.T) {
+	lister := NewNamespacedResourceLister(fakeLister{
+		newIngress("default", "web", "web.example.com", nil),
+		newIngress("test", "web", "web.test.example.com", nil),
+	}, "test")
+	testCases := []struct {
+		query          string
+		expectedResult interface{}
+		expectedError  bool
+	}{
+		{query: "lookup('networking.k8s.io/v1', 'Ingress', 'test', 'web').spec.rules[0].host", expectedResult: "web.test.example.com"},
+		{query: "lookup('networking.k8s.io/v1', 'Ingress', 'default', 'web')", expectedError: true},
+		{query: "lookup('v1', 'Namespace', '', 'default')", expectedError: true},
+		{query: "list('networking.k8s.io/v1', 'Ingress', '', null)[].metadata.namespace", expectedResult: []interface{}{"test"}},
+		{query: "list('networking.k8s.io/v1', 'Ingress', 'default', null)", expectedError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			jp, err := New(tc.query, NewLookupFunctions(lister)...)
+			assert.NilError(t, err)
+			result, err := jp.Search(map[string]interface{}{})
+			if tc.expectedError {
+				assert.ErrorContains(t, err, "namespaced policies can only read resources of namespace test")
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result, tc.expectedResult)
+		})
+	}
+}
+
+func Test_LookupFunctionsInUserFunctions(t *testing.T) {
+	_, err := NewUserFunctions(UserFunction{Name: "ingress", Parameters: []string{"name"}, Expression: "lookup('networking.k8s.io/v1', 'Ingress', 'default', name)"})
+	assert.Error(t, err, "function ingress calls lookup, lookup functions can only be called from the variables of the policy rules")
+}
@@ -0,0 +1,156 @@
+package jmespath
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+
+	netutils "github.com/kyverno/kyverno/pkg/utils/net"
+)
+
+// function names
+var (
+	cidrContains = "cidr_contains"
+	ipInRange    = "ip_in_range"
+	cidrOverlaps = "cidr_overlaps"
+	isPrivateIP  = "is_private_ip"
+	parseURL     = "parse_url"
+	portInRange  = "port_in_range"
+)
+
+func jpCidrContains(arguments []interface{}) (interface{}, error) {
+	cidr, err := validateArg(cidrContains, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	ip, err := validateArg(cidrContains, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	outer, err := netutils.ParsePrefix(cidr.String())
+	if err != nil {
+		return nil, fmt.Errorf(genericError, cidrContains, err.Error())
+	}
+	inner, err := netutils.ParsePrefix(ip.String())
+	if err != nil {
+		return nil, fmt.Errorf(genericError, cidrContains, err.Error())
+	}
+	return netutils.PrefixContains(outer, inner), nil
+}
+
+func jpIPInRange(arguments []interface{}) (interface{}, error) {
+	var bounds [3]string
+	for i := range bounds {
+		arg, err := validateArg(ipInRange, arguments, i, reflect.String)
+		if err != nil {
+			return nil, err
+		}
+		bounds[i] = arg.String()
+	}
+	ip, err := netutils.ParseAddr(bounds[0])
+	if err != nil {
+		return nil, fmt.Errorf(genericError, ipInRange, err.Error())
+	}
+	start, err := netutils.ParseAddr(bounds[1])
+	if err != nil {
+		return nil, fmt.Errorf(genericError, ipInRange, err.Error())
+	}
+	end, err := netutils.ParseAddr(bounds[2])
+	if err != nil {
+		return nil, fmt.Errorf(genericError, ipInRange, err.Error())
+	}
+	if start.BitLen() != end.BitLen() {
+		return nil, fmt.Errorf(genericError, ipInRange, "start and end of the range must be of the same IP version")
+	}
+	// addresses of different versions compare by version first
+	return ip.BitLen() == start.BitLen() && ip.Compare(start) >= 0 && ip.Compare(end) <= 0, nil
+}
+
+func jpCidrOverlaps(arguments []interface{}) (interface{}, error) {
+	first, err := validateArg(cidrOverlaps, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	second, err := validateArg(cidrOverlaps, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	a, err := netutils.ParsePrefix(first.String())
+	if err != nil {
+		return nil, fmt.Errorf(genericError, cidrOverlaps, err.Error())
+	}
+	b, err := netutils.ParsePrefix(second.String())
+	if err != nil {
+		return nil, fmt.Errorf(genericError, cidrOverlaps, err.Error())
+	}
+	return a.Overlaps(b), nil
+}
+
+func jpIsPrivateIP(arguments []interface{}) (interface{}, error) {
+	arg, err := validateArg(isPrivateIP, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	ip, err := netutils.ParseAddr(arg.String())
+	if err != nil {
+		return nil, fmt.Errorf(genericError, isPrivateIP, err.Error())
+	}
+	return ip.IsPrivate(), nil
+}
+
+func jpParseURL(arguments []interface{}) (interface{}, error) {
+	arg, err := validateArg(parseURL, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(arg.String())
+	if err != nil {
+		return nil, fmt.Errorf(genericError, parseURL, err.Error())
+	}
+	query := map[string]interface{}{}
+	for key, values := range u.Query() {
+		var list []interface{}
+		for _, value := range values {
+			list = append(list, value)
+		}
+		query[key] = list
+	}
+	return map[string]interface{}{
+		"scheme":   u.Scheme,
+		"user":     u.User.Username(),
+		"host":     u.Host,
+		"hostname": u.Hostname(),
+		"port":     u.Port(),
+		"path":     u.Path,
+		"query":    query,
+		"fragment": u.Fragment,
+	}, nil
+}
+
+func jpPortInRange(arguments []interface{}) (interface{}, error) {
+	var port int
+	switch typed := arguments[0].(type) {
+	case float64:
+		port = int(typed)
+		if float64(port) != typed {
+			return nil, fmt.Errorf(genericError, portInRange, fmt.Sprintf("invalid port %v", typed))
+		}
+	case string:
+		p, err := netutils.ParsePort(typed)
+		if err != nil {
+			return nil, fmt.Errorf(genericError, portInRange, err.Error())
+		}
+		port = p
+	default:
+		return nil, fmt.Errorf(invalidArgumentTypeError, portInRange, 1, "Number or String")
+	}
+	portRange, err := validateArg(portInRange, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	min, max, err := netutils.ParsePortRange(portRange.String())
+	if err != nil {
+		return nil, fmt.Errorf(genericError, portInRange, err.Error())
+	}
+	return port >= min && port <= max, nil
+}
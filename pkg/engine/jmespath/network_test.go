@@ -0,0 +1,87 @@
+package jmespath
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_NetworkPredicates(t *testing.T) {
+	testCases := []struct {
+		jmesPath       string
+		expectedResult bool
+	}{
+		{jmesPath: "cidr_contains('10.0.0.0/8', '10.1.2.3')", expectedResult: true},
+		{jmesPath: "cidr_contains('10.0.0.0/8', '10.1.0.0/16')", expectedResult: true},
+		{jmesPath: "cidr_contains('10.1.0.0/16', '10.0.0.0/8')", expectedResult: false},
+		{jmesPath: "cidr_contains('10.0.0.0/8', '192.168.1.1')", expectedResult: false},
+		{jmesPath: "cidr_contains('fd00::/8', 'fd12::1')", expectedResult: true},
+		{jmesPath: "ip_in_range('10.0.0.5', '10.0.0.1', '10.0.0.10')", expectedResult: true},
+		{jmesPath: "ip_in_range('10.0.0.10', '10.0.0.1', '10.0.0.10')", expectedResult: true},
+		{jmesPath: "ip_in_range('10.0.0.11', '10.0.0.1', '10.0.0.10')", expectedResult: false},
+		{jmesPath: "ip_in_range('fd00::1', '10.0.0.1', '10.0.0.10')", expectedResult: false},
+		{jmesPath: "cidr_overlaps('10.0.0.0/8', '10.1.0.0/16')", expectedResult: true},
+		{jmesPath: "cidr_overlaps('10.0.0.0/16', '10.1.0.0/16')", expectedResult: false},
+		{jmesPath: "is_private_ip('192.168.1.1')", expectedResult: true},
+		{jmesPath: "is_private_ip('fd00::1')", expectedResult: true},
+		{jmesPath: "is_private_ip('8.8.8.8')", expectedResult: false},
+		{jmesPath: "port_in_range(`8080`, '8000-9000')", expectedResult: true},
+		{jmesPath: "port_in_range('443', '443')", expectedResult: true},
+		{jmesPath: "port_in_range(`22`, '8000-9000')", expectedResult: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.jmesPath, func(t *testing.T) {
+			jp, err := New(tc.jmesPath)
+			assert.NilError(t, err)
+
+			result, err := jp.Search("")
+			assert.NilError(t, err)
+
+			res, ok := result.(bool)
+			assert.Assert(t, ok)
+			assert.Equal(t, res, tc.expectedResult)
+		})
+	}
+}
+
+func Test_NetworkErrors(t *testing.T) {
+	testCases := []string{
+		"cidr_contains('10.0.0.0/33', '10.1.2.3')",
+		"cidr_overlaps('10.0.0.0/8', 'foo')",
+		"ip_in_range('10.0.0.5', '10.0.0.1', 'fd00::1')",
+		"is_private_ip('foo')",
+		"port_in_range('http', '80')",
+		"port_in_range(`80`, '90-80')",
+	}
+	for _, tc := range testCases {
+		t.Run(tc, func(t *testing.T) {
+			jp, err := New(tc)
+			assert.NilError(t, err)
+
+			_, err = jp.Search("")
+			assert.Assert(t, err != nil)
+		})
+	}
+}
+
+func Test_ParseURL(t *testing.T) {
+	jp, err := New("parse_url('https://user@example.com:8443/api/v1?watch=true&label=a&label=b#top')")
+	assert.NilError(t, err)
+
+	result, err := jp.Search("")
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, result, map[string]interface{}{
+		"scheme":   "https",
+		"user":     "user",
+		"host":     "example.com:8443",
+		"hostname": "example.com",
+		"port":     "8443",
+		"path":     "/api/v1",
+		"query": map[string]interface{}{
+			"watch": []interface{}{"true"},
+			"label": []interface{}{"a", "b"},
+		},
+		"fragment": "top",
+	})
+}
@@ -4,7 +4,8 @@ import (
 	gojmespath "github.com/jmespath/go-jmespath"
 )
 
-func New(query string) (*gojmespath.JMESPath, error) {
+// New compiles a query with the kyverno functions and the given user defined functions registered
+func New(query string, functions ...*FunctionEntry) (*gojmespath.JMESPath, error) {
 	jp, err := gojmespath.Compile(query)
 	if err != nil {
 		return nil, err
@@ -13,6 +14,9 @@ func New(query string) (*gojmespath.JMESPath, error) {
 	for _, function := range GetFunctions() {
 		jp.Register(function.Entry)
 	}
+	for _, function := range functions {
+		jp.Register(function.Entry)
+	}
 
 	return jp, nil
 }
@@ -0,0 +1,288 @@
+package jmespath
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	gojmespath "github.com/jmespath/go-jmespath"
+)
+
+// builtinFunctions are the functions of the JMESPath specification
+var builtinFunctions = []string{
+	"abs", "avg", "ceil", "contains", "ends_with", "floor", "join", "keys", "length", "map", "max", "max_by",
+	"merge", "min", "min_by", "not_null", "reverse", "sort", "sort_by", "starts_with", "sum", "to_array",
+	"to_number", "to_string", "type", "values",
+}
+
+// FunctionNames returns the names of the functions that can be called in expressions, the functions of the
+// JMESPath specification included
+func FunctionNames() []string {
+	names := make([]string, 0, len(builtinFunctions))
+	names = append(names, builtinFunctions...)
+	for _, function := range GetFunctions() {
+		names = append(names, function.Entry.Name)
+	}
+	return names
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// UserFunction is a function defined by a JMESPath expression, the expression is evaluated against an object
+// holding the arguments of the function keyed by parameter name
+type UserFunction struct {
+	Name       string
+	Parameters []string
+	Expression string
+}
+
+// NewUserFunctions validates and compiles user defined functions, the returned entries can be passed to New.
+// User defined functions can call each other but recursive calls are not allowed.
+func NewUserFunctions(functions ...UserFunction) ([]*FunctionEntry, error) {
+	return CompileUserFunctions(nil, functions...)
+}
+
+// CompileUserFunctions validates and compiles user defined functions like NewUserFunctions, the given entries
+// replace the functions of GetFunctions with the same name in the expressions of the user defined functions
+func CompileUserFunctions(entries []*FunctionEntry, functions ...UserFunction) ([]*FunctionEntry, error) {
+	if err := validateUserFunctions(functions); err != nil {
+		return nil, err
+	}
+	userEntries := make([]*FunctionEntry, 0, len(functions))
+	queries := make([]*gojmespath.JMESPath, 0, len(functions))
+	for _, function := range functions {
+		query, err := gojmespath.Compile(function.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile function %s: %v", function.Name, err)
+		}
+		queries = append(queries, query)
+		userEntries = append(userEntries, newUserFunctionEntry(function, query))
+	}
+	// functions are registered once all of them are compiled so that they can call each other
+	for _, query := range queries {
+		for _, function := range GetFunctions() {
+			query.Register(function.Entry)
+		}
+		for _, entry := range entries {
+			query.Register(entry.Entry)
+		}
+		for _, entry := range userEntries {
+			query.Register(entry.Entry)
+		}
+	}
+	return userEntries, nil
+}
+
+func newUserFunctionEntry(function UserFunction, query *gojmespath.JMESPath) *FunctionEntry {
+	parameters := function.Parameters
+	arguments := make([]ArgSpec, 0, len(parameters))
+	for range parameters {
+		arguments = append(arguments, ArgSpec{Types: []JpType{JpAny}})
+	}
+	return &FunctionEntry{
+		Entry: &gojmespath.FunctionEntry{
+			Name:      function.Name,
+			Arguments: arguments,
+			Handler: func(arguments []interface{}) (interface{}, error) {
+				if len(arguments) != len(parameters) {
+					return nil, fmt.Errorf(genericError, function.Name, fmt.Sprintf("expected %d arguments, got %d", len(parameters), len(arguments)))
+				}
+				data := make(map[string]interface{}, len(parameters))
+				for i, parameter := range parameters {
+					data[parameter] = arguments[i]
+				}
+				return query.Search(data)
+			},
+		},
+		ReturnType: []JpType{JpAny},
+		Note:       "user defined function",
+	}
+}
+
+// CheckUserFunctionCalls checks that the user defined functions called in the expression are given
+// as many arguments as they have parameters
+func CheckUserFunctionCalls(expression string, functions ...UserFunction) error {
+	arity := make(map[string]int, len(functions))
+	for _, function := range functions {
+		arity[function.Name] = len(function.Parameters)
+	}
+	for _, call := range functionCalls(expression) {
+		if expected, ok := arity[call.name]; ok && expected != call.arguments {
+			return fmt.Errorf("function %s expects %d arguments, %d given", call.name, expected, call.arguments)
+		}
+	}
+	return nil
+}
+
+func validateUserFunctions(functions []UserFunction) error {
+	reserved := make(map[string]bool)
+	for _, name := range builtinFunctions {
+		reserved[name] = true
+	}
+	for _, function := range GetFunctions() {
+		reserved[function.Entry.Name] = true
+	}
+	for _, name := range lookupFunctions {
+		reserved[name] = true
+	}
+	calls := make(map[string][]string, len(functions))
+	for _, function := range functions {
+		if !identifier.MatchString(function.Name) {
+			return fmt.Errorf("invalid function name %q", function.Name)
+		}
+		if reserved[function.Name] {
+			return fmt.Errorf("function %s conflicts with a built-in function", function.Name)
+		}
+		if _, ok := calls[function.Name]; ok {
+			return fmt.Errorf("function %s is defined more than once", function.Name)
+		}
+		parameters := make(map[string]bool, len(function.Parameters))
+		for _, parameter := range function.Parameters {
+			if !identifier.MatchString(parameter) {
+				return fmt.Errorf("invalid parameter name %q in function %s", parameter, function.Name)
+			}
+			if parameters[parameter] {
+				return fmt.Errorf("parameter %s is declared more than once in function %s", parameter, function.Name)
+			}
+			parameters[parameter] = true
+		}
+		if _, err := gojmespath.Compile(function.Expression); err != nil {
+			return fmt.Errorf("invalid expression in function %s: %v", function.Name, err)
+		}
+		if err := CheckUserFunctionCalls(function.Expression, functions...); err != nil {
+			return fmt.Errorf("invalid expression in function %s: %v", function.Name, err)
+		}
+		var called []string
+		for _, call := range functionCalls(function.Expression) {
+			// user functions are compiled once for all evaluations, they can't call functions bound to a lister
+			for _, name := range lookupFunctions {
+				if call.name == name {
+					return fmt.Errorf("function %s calls %s, lookup functions can only be called from the variables of the policy rules", function.Name, name)
+				}
+			}
+			called = append(called, call.name)
+		}
+		calls[function.Name] = called
+	}
+	// recursion is detected with a depth first search of the call graph
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(functions))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("function %s is recursive: %s", name, strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, callee := range calls[name] {
+			if _, ok := calls[callee]; !ok {
+				continue
+			}
+			if err := visit(callee, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, function := range functions {
+		if err := visit(function.Name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type functionCall struct {
+	name      string
+	arguments int
+}
+
+// functionCalls lists the function calls of an expression with their number of arguments, literals and
+// quoted identifiers are skipped
+func functionCalls(expression string) []functionCall {
+	var calls []functionCall
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == '\'' || c == '`' || c == '"':
+			i = skipQuoted(expression, i)
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			start := i
+			for i < len(expression) && isIdentifierChar(expression[i]) {
+				i++
+			}
+			j := i
+			for j < len(expression) && expression[j] == ' ' {
+				j++
+			}
+			if j < len(expression) && expression[j] == '(' {
+				calls = append(calls, functionCall{name: expression[start:i], arguments: countArguments(expression, j)})
+			}
+		default:
+			i++
+		}
+	}
+	return calls
+}
+
+// countArguments counts the arguments of the call starting with the parenthesis at index open
+func countArguments(expression string, open int) int {
+	depth := 0
+	arguments := 0
+	empty := true
+	for i := open; i < len(expression); {
+		c := expression[i]
+		switch c {
+		case '\'', '`', '"':
+			empty = false
+			i = skipQuoted(expression, i)
+			continue
+		case '(', '[', '{':
+			if depth > 0 {
+				empty = false
+			}
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				if empty {
+					return 0
+				}
+				return arguments + 1
+			}
+		case ',':
+			if depth == 1 {
+				arguments++
+			}
+		case ' ':
+		default:
+			empty = false
+		}
+		i++
+	}
+	return arguments + 1
+}
+
+// skipQuoted returns the index following the quoted string starting at index start
+func skipQuoted(expression string, start int) int {
+	quote := expression[start]
+	for i := start + 1; i < len(expression); i++ {
+		switch expression[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(expression)
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,127 @@
+package jmespath
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var imagesFunction = UserFunction{
+	Name:       "images",
+	Parameters: []string{"pod"},
+	Expression: "[pod.spec.containers[].image, pod.spec.initContainers[].image][][]",
+}
+
+func Test_UserFunctions(t *testing.T) {
+	functions, err := NewUserFunctions(
+		imagesFunction,
+		UserFunction{
+			Name:       "registries",
+			Parameters: []string{"pod"},
+			Expression: "images(pod)[].split(@, '/')[0]",
+		},
+		UserFunction{
+			Name:       "prefix",
+			Parameters: []string{"value", "prefix"},
+			Expression: "join('', [prefix, value])",
+		},
+	)
+	assert.NilError(t, err)
+	pod := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers":     []interface{}{map[string]interface{}{"image": "ghcr.io/nginx"}},
+			"initContainers": []interface{}{map[string]interface{}{"image": "docker.io/busybox"}},
+		},
+	}
+	testCases := []struct {
+		query          string
+		expectedResult interface{}
+	}{
+		{query: "images(@)", expectedResult: []interface{}{"ghcr.io/nginx", "docker.io/busybox"}},
+		{query: "registries(@)", expectedResult: []interface{}{"ghcr.io", "docker.io"}},
+		{query: "prefix('bar', 'foo')", expectedResult: "foobar"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			jp, err := New(tc.query, functions...)
+			assert.NilError(t, err)
+
+			result, err := jp.Search(pod)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result, tc.expectedResult)
+		})
+	}
+}
+
+func Test_UserFunctions_Invalid(t *testing.T) {
+	testCases := []struct {
+		name      string
+		functions []UserFunction
+		err       string
+	}{{
+		name:      "builtin",
+		functions: []UserFunction{{Name: "to_upper", Expression: "@"}},
+		err:       "function to_upper conflicts with a built-in function",
+	}, {
+		name:      "duplicate",
+		functions: []UserFunction{imagesFunction, imagesFunction},
+		err:       "function images is defined more than once",
+	}, {
+		name:      "invalid name",
+		functions: []UserFunction{{Name: "my-images", Expression: "@"}},
+		err:       `invalid function name "my-images"`,
+	}, {
+		name:      "duplicate parameter",
+		functions: []UserFunction{{Name: "f", Parameters: []string{"a", "a"}, Expression: "a"}},
+		err:       "parameter a is declared more than once in function f",
+	}, {
+		name: "arity",
+		functions: []UserFunction{imagesFunction, {
+			Name:       "registries",
+			Parameters: []string{"pod"},
+			Expression: "images(pod, 'foo')",
+		}},
+		err: "invalid expression in function registries: function images expects 1 arguments, 2 given",
+	}, {
+		name:      "recursion",
+		functions: []UserFunction{{Name: "f", Parameters: []string{"a"}, Expression: "f(a)"}},
+		err:       "function f is recursive: f -> f",
+	}, {
+		name: "mutual recursion",
+		functions: []UserFunction{
+			{Name: "f", Parameters: []string{"a"}, Expression: "g(a)"},
+			{Name: "g", Parameters: []string{"a"}, Expression: "to_upper(h(a))"},
+			{Name: "h", Parameters: []string{"a"}, Expression: "f(a)"},
+		},
+		err: "function f is recursive: f -> g -> h -> f",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewUserFunctions(tc.functions...)
+			assert.Error(t, err, tc.err)
+		})
+	}
+}
+
+func Test_CheckUserFunctionCalls(t *testing.T) {
+	functions := []UserFunction{imagesFunction, {Name: "now", Expression: "time_now_utc()"}}
+	testCases := []struct {
+		expression string
+		valid      bool
+	}{
+		{expression: "images(request.object)", valid: true},
+		{expression: "length(images(request.object))", valid: true},
+		{expression: "images(merge(request.object, `{\"a\": [1, 2]}`))", valid: true},
+		{expression: "now()", valid: true},
+		{expression: "'images(a, b)'", valid: true},
+		{expression: "images()", valid: false},
+		{expression: "images(request.object, 'a,b')", valid: false},
+		{expression: "now(@)", valid: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			err := CheckUserFunctionCalls(tc.expression, functions...)
+			assert.Equal(t, err == nil, tc.valid)
+		})
+	}
+}
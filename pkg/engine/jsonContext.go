@@ -20,9 +20,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-type ContextLoaderFactory = func(pContext engineapi.PolicyContext, ruleName string) engineapi.ContextLoader
-
-func LegacyContextLoaderFactory(rclient registryclient.Client) ContextLoaderFactory {
+func LegacyContextLoaderFactory(rclient registryclient.Client) engineapi.ContextLoaderFactory {
 	if store.IsMock() {
 		return func(pContext engineapi.PolicyContext, ruleName string) engineapi.ContextLoader {
 			policy := pContext.Policy()
@@ -55,22 +53,21 @@ type contextLoader struct {
 
 func (l *contextLoader) Load(ctx context.Context, contextEntries []kyvernov1.ContextEntry, enginectx enginecontext.Interface) error {
 	for _, entry := range contextEntries {
-		if entry.ConfigMap != nil {
-			if err := loadConfigMap(ctx, l.logger, entry, enginectx, l.cmResolver); err != nil {
-				return err
-			}
-		} else if entry.APICall != nil {
-			if err := loadAPIData(ctx, l.logger, entry, enginectx, l.client); err != nil {
-				return err
-			}
-		} else if entry.ImageRegistry != nil {
-			if err := loadImageData(ctx, l.rclient, l.logger, entry, enginectx); err != nil {
-				return err
-			}
-		} else if entry.Variable != nil {
-			if err := loadVariable(l.logger, entry, enginectx); err != nil {
-				return err
+		entry := entry
+		err := loadWithTimeout(ctx, entry, func(ctx context.Context) error {
+			if entry.ConfigMap != nil {
+				return loadConfigMap(ctx, l.logger, entry, enginectx, l.cmResolver)
+			} else if entry.APICall != nil {
+				return loadAPIData(ctx, l.logger, entry, enginectx, l.client)
+			} else if entry.ImageRegistry != nil {
+				return loadImageData(ctx, l.rclient, l.logger, entry, enginectx)
+			} else if entry.Variable != nil {
+				return loadVariable(l.logger, entry, enginectx)
 			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 	}
 	return nil
@@ -98,19 +95,20 @@ func (l *mockContextLoader) Load(ctx context.Context, contextEntries []kyvernov1
 	hasRegistryAccess := store.GetRegistryAccess()
 	// Context Variable should be loaded after the values loaded from values file
 	for _, entry := range contextEntries {
-		if entry.ImageRegistry != nil && hasRegistryAccess {
-			rclient := store.GetRegistryClient()
-			if err := loadImageData(ctx, rclient, l.logger, entry, enginectx); err != nil {
-				return err
-			}
-		} else if entry.Variable != nil {
-			if err := loadVariable(l.logger, entry, enginectx); err != nil {
-				return err
-			}
-		} else if entry.APICall != nil && store.IsApiCallAllowed() {
-			if err := loadAPIData(ctx, l.logger, entry, enginectx, l.client); err != nil {
-				return err
+		entry := entry
+		err := loadWithTimeout(ctx, entry, func(ctx context.Context) error {
+			if entry.ImageRegistry != nil && hasRegistryAccess {
+				rclient := store.GetRegistryClient()
+				return loadImageData(ctx, rclient, l.logger, entry, enginectx)
+			} else if entry.Variable != nil {
+				return loadVariable(l.logger, entry, enginectx)
+			} else if entry.APICall != nil && store.IsApiCallAllowed() {
+				return loadAPIData(ctx, l.logger, entry, enginectx, l.client)
 			}
+			return nil
+		})
+		if err != nil {
+			return err
 		}
 	}
 	if rule != nil && len(rule.ForEachValues) > 0 {
@@ -123,7 +121,7 @@ func (l *mockContextLoader) Load(ctx context.Context, contextEntries []kyvernov1
 	return nil
 }
 
-func LoadContext(ctx context.Context, factory ContextLoaderFactory, contextEntries []kyvernov1.ContextEntry, pContext engineapi.PolicyContext, ruleName string) error {
+func LoadContext(ctx context.Context, factory engineapi.ContextLoaderFactory, contextEntries []kyvernov1.ContextEntry, pContext engineapi.PolicyContext, ruleName string) error {
 	return factory(pContext, ruleName).Load(ctx, contextEntries, pContext.JSONContext())
 }
 
@@ -157,7 +155,7 @@ func loadVariable(logger logr.Logger, entry kyvernov1.ContextEntry, ctx engineco
 			return fmt.Errorf("failed to substitute variables in context entry %s %s: %v", entry.Name, entry.Variable.Value, err)
 		}
 		if path != "" {
-			variable, err := applyJMESPath(path, variable)
+			variable, err := applyJMESPath(path, variable, ctx.Functions()...)
 			if err == nil {
 				output = variable
 			} else if defaultValue == nil {
@@ -219,7 +217,7 @@ func fetchImageData(ctx context.Context, rclient registryclient.Client, logger l
 		return nil, err
 	}
 	if path != "" {
-		imageData, err = applyJMESPath(path.(string), imageData)
+		imageData, err = applyJMESPath(path.(string), imageData, enginectx.Functions()...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to apply JMESPath (%s) results to context entry %s, error: %v", entry.ImageRegistry.JMESPath, entry.Name, err)
 		}
@@ -293,8 +291,8 @@ func loadAPIData(ctx context.Context, logger logr.Logger, entry kyvernov1.Contex
 	return nil
 }
 
-func applyJMESPath(jmesPath string, data interface{}) (interface{}, error) {
-	jp, err := jmespath.New(jmesPath)
+func applyJMESPath(jmesPath string, data interface{}, functions ...*jmespath.FunctionEntry) (interface{}, error) {
+	jp, err := jmespath.New(jmesPath, functions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile JMESPath: %s, error: %v", jmesPath, err)
 	}
@@ -36,10 +36,29 @@ func NewResponse(status engineapi.RuleStatus, resource unstructured.Unstructured
 }
 
 func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.Unstructured, logger logr.Logger) *Response {
-	updatedRule, err := variables.SubstituteAllInRule(logger, ctx, *rule)
+	var patches string
+	var expanded bool
+	if rule.Mutation.PatchesJSON6902 != "" {
+		var err error
+		patches, expanded, err = patch.ExpandPatchesJSON6902(rule.Mutation.PatchesJSON6902, ctx, 0, logger)
+		if err != nil {
+			return NewErrorResponse("failed to expand patchesJson6902", err)
+		}
+	}
+
+	substitutedRule := rule
+	if expanded {
+		// expanded patches are already substituted
+		substitutedRule = rule.DeepCopy()
+		substitutedRule.Mutation.PatchesJSON6902 = ""
+	}
+	updatedRule, err := variables.SubstituteAllInRule(logger, ctx, *substitutedRule)
 	if err != nil {
 		return NewErrorResponse("variable substitution failed", err)
 	}
+	if expanded {
+		updatedRule.Mutation.PatchesJSON6902 = patches
+	}
 
 	m := updatedRule.Mutation
 	patcher := NewPatcher(updatedRule.Name, m.GetPatchStrategicMerge(), m.PatchesJSON6902, resource, ctx, logger)
@@ -69,11 +88,28 @@ func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.U
 	return NewResponse(engineapi.RuleStatusPass, patchedResource, resp.Patches, resp.Message)
 }
 
-func ForEach(name string, foreach kyvernov1.ForEachMutation, ctx context.Interface, resource unstructured.Unstructured, logger logr.Logger) *Response {
+func ForEach(name string, foreach kyvernov1.ForEachMutation, ctx context.Interface, resource unstructured.Unstructured, nesting int, logger logr.Logger) *Response {
+	var patches string
+	var expanded bool
+	if foreach.PatchesJSON6902 != "" {
+		var err error
+		patches, expanded, err = patch.ExpandPatchesJSON6902(foreach.PatchesJSON6902, ctx, nesting+1, logger)
+		if err != nil {
+			return NewErrorResponse("failed to expand patchesJson6902", err)
+		}
+		if expanded {
+			// expanded patches are already substituted
+			foreach.PatchesJSON6902 = ""
+		}
+	}
+
 	fe, err := substituteAllInForEach(foreach, ctx, logger)
 	if err != nil {
 		return NewErrorResponse("variable substitution failed", err)
 	}
+	if expanded {
+		fe.PatchesJSON6902 = patches
+	}
 
 	patcher := NewPatcher(name, fe.GetPatchStrategicMerge(), fe.PatchesJSON6902, resource, ctx, logger)
 	if patcher == nil {
@@ -1,12 +1,17 @@
 package patch
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
 	"time"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/go-logr/logr"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
 )
@@ -98,3 +103,188 @@ func ConvertPatchesToJSON(patchesJSON6902 string) ([]byte, error) {
 
 	return []byte(patchesJSON6902), nil
 }
+
+const (
+	// OperationIf is the JSON patch operation field holding a JMESPath condition, the operation is applied only
+	// if the condition evaluates to true
+	OperationIf = "if"
+	// OperationForEach is the JSON patch operation field holding a JMESPath expression evaluating to a list, an
+	// operation is generated for each element of the list with the element available in the element variable
+	OperationForEach = "foreach"
+)
+
+// ExpandPatchesJSON6902 evaluates the if and foreach fields of JSON patch operations against the context and
+// returns the resulting operations, conditions are evaluated before any operation is applied. When operations
+// are expanded the returned operations have their variables substituted and must not be substituted again, as
+// element values may contain variables. Patches without if or foreach fields are returned unchanged and false.
+func ExpandPatchesJSON6902(patches string, ctx context.Interface, nesting int, logger logr.Logger) (string, bool, error) {
+	operations, err := parseOperations(patches)
+	if err != nil {
+		return "", false, err
+	}
+	expand := false
+	for _, operation := range operations {
+		_, hasIf := operation[OperationIf]
+		_, hasForEach := operation[OperationForEach]
+		expand = expand || hasIf || hasForEach
+	}
+	if !expand {
+		return patches, false, nil
+	}
+	var expanded []interface{}
+	for i, operation := range operations {
+		list, hasForEach := operation[OperationForEach]
+		if !hasForEach {
+			apply, err := evaluateOperationCondition(operation, ctx)
+			if err != nil {
+				return "", false, fmt.Errorf("failed to evaluate patchesJson6902[%d].if: %w", i, err)
+			}
+			if apply {
+				delete(operation, OperationIf)
+				substituted, err := variables.SubstituteAll(logger, ctx, operation)
+				if err != nil {
+					return "", false, fmt.Errorf("failed to substitute variables in patchesJson6902[%d]: %w", i, err)
+				}
+				expanded = append(expanded, substituted)
+			}
+			continue
+		}
+		expression, ok := list.(string)
+		if !ok {
+			return "", false, fmt.Errorf("patchesJson6902[%d].foreach must be a JMESPath expression", i)
+		}
+		elements, err := evaluateOperationList(expression, ctx)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to evaluate patchesJson6902[%d].foreach %s: %w", i, expression, err)
+		}
+		delete(operation, OperationForEach)
+		for index, element := range elements {
+			generated, err := generateOperation(operation, element, index, nesting, ctx, logger)
+			if err != nil {
+				return "", false, fmt.Errorf("failed to generate patchesJson6902[%d] for element %d: %w", i, index, err)
+			}
+			if generated != nil {
+				expanded = append(expanded, generated)
+			}
+		}
+	}
+	if expanded == nil {
+		expanded = []interface{}{}
+	}
+	data, err := json.Marshal(expanded)
+	if err != nil {
+		return "", false, err
+	}
+	logger.V(4).Info("expanded JSON patch operations", "patches", string(data))
+	return string(data), true, nil
+}
+
+const variablePlaceholder = "__kyverno_variable_%d__"
+
+var regexVariablePlaceholder = regexp.MustCompile(`__kyverno_variable_(\d+)__`)
+
+// parseOperations reads the JSON patch operations, unquoted variables make patches invalid until they are
+// substituted so they are replaced with placeholders to read the operations and restored in their values
+func parseOperations(patches string) ([]map[string]interface{}, error) {
+	var vars []string
+	// variables can be nested, inner variables are replaced first
+	for variables.RegexVariables.MatchString(patches) {
+		patches = variables.ReplaceAllVars(patches, func(variable string) string {
+			vars = append(vars, variable)
+			return fmt.Sprintf(variablePlaceholder, len(vars)-1)
+		})
+	}
+	patchesJSON, err := ConvertPatchesToJSON(patches)
+	if err != nil {
+		return nil, err
+	}
+	var operations []map[string]interface{}
+	if err := json.Unmarshal(patchesJSON, &operations); err != nil {
+		// not a list of operations, it will be reported when the patch is applied
+		return nil, nil
+	}
+	for i, operation := range operations {
+		operations[i] = restoreVariables(operation, vars).(map[string]interface{})
+	}
+	return operations, nil
+}
+
+func restoreVariables(value interface{}, vars []string) interface{} {
+	switch typed := value.(type) {
+	case string:
+		return regexVariablePlaceholder.ReplaceAllStringFunc(typed, func(placeholder string) string {
+			index, err := strconv.Atoi(regexVariablePlaceholder.FindStringSubmatch(placeholder)[1])
+			if err != nil || index >= len(vars) {
+				return placeholder
+			}
+			// placeholders of nested variables are restored in the variable
+			return restoreVariables(vars[index], vars).(string)
+		})
+	case map[string]interface{}:
+		restored := make(map[string]interface{}, len(typed))
+		for key, value := range typed {
+			restored[restoreVariables(key, vars).(string)] = restoreVariables(value, vars)
+		}
+		return restored
+	case []interface{}:
+		for i, value := range typed {
+			typed[i] = restoreVariables(value, vars)
+		}
+		return typed
+	}
+	return value
+}
+
+func generateOperation(operation map[string]interface{}, element interface{}, index, nesting int, ctx context.Interface, logger logr.Logger) (interface{}, error) {
+	ctx.Checkpoint()
+	defer ctx.Restore()
+	if err := ctx.AddElement(element, index, nesting); err != nil {
+		return nil, err
+	}
+	apply, err := evaluateOperationCondition(operation, ctx)
+	if err != nil || !apply {
+		return nil, err
+	}
+	generated := make(map[string]interface{}, len(operation))
+	for key, value := range operation {
+		if key != OperationIf {
+			generated[key] = value
+		}
+	}
+	return variables.SubstituteAll(logger, ctx, generated)
+}
+
+func evaluateOperationCondition(operation map[string]interface{}, ctx context.Interface) (bool, error) {
+	condition, ok := operation[OperationIf]
+	if !ok {
+		return true, nil
+	}
+	expression, ok := condition.(string)
+	if !ok {
+		return false, fmt.Errorf("condition must be a JMESPath expression")
+	}
+	result, err := ctx.Query(expression)
+	if err != nil {
+		return false, err
+	}
+	apply, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("condition %s must evaluate to a boolean, got %T", expression, result)
+	}
+	return apply, nil
+}
+
+func evaluateOperationList(expression string, ctx context.Interface) ([]interface{}, error) {
+	result, err := ctx.Query(expression)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+	elements, ok := result.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expression must evaluate to a list, got %T", result)
+	}
+	return elements, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ghodss/yaml"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/logging"
 	assert "github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -375,3 +376,140 @@ spec:
 		}
 	}
 }
+
+func Test_ExpandPatchesJSON6902(t *testing.T) {
+	ctx := context.NewContext()
+	err := context.AddResource(ctx, []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "test"},
+		"spec": {
+			"tolerations": [{"key": "dedicated", "operator": "Exists"}],
+			"containers": [{"name": "nginx", "args": ["{{ request.object.metadata.name }}"]}, {"name": "sidecar"}]
+		}
+	}`))
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name     string
+		patches  string
+		expected string
+		expanded bool
+	}{
+		{
+			name: "no if or foreach",
+			patches: `
+- op: add
+  path: /metadata/labels/app
+  value: "{{ request.object.metadata.name }}"
+`,
+			expected: `
+- op: add
+  path: /metadata/labels/app
+  value: "{{ request.object.metadata.name }}"
+`,
+		},
+		{
+			name: "if",
+			patches: `
+- op: add
+  path: /spec/tolerations/-
+  value: {"key": "dedicated", "operator": "Exists"}
+  if: "length(request.object.spec.tolerations[?key=='dedicated']) == ` + "`0`" + `"
+- op: add
+  path: /spec/tolerations/-
+  value: {"key": "gpu", "operator": "Exists"}
+  if: "length(request.object.spec.tolerations[?key=='gpu']) == ` + "`0`" + `"
+`,
+			expected: `[{"op":"add","path":"/spec/tolerations/-","value":{"key":"gpu","operator":"Exists"}}]`,
+			expanded: true,
+		},
+		{
+			name: "foreach",
+			patches: `
+- op: add
+  path: /metadata/labels/{{ element.name }}
+  value: "{{ element.name }}-{{ elementIndex }}"
+  foreach: request.object.spec.containers
+  if: "element.name != 'sidecar'"
+`,
+			expected: `[{"op":"add","path":"/metadata/labels/nginx","value":"nginx-0"}]`,
+			expanded: true,
+		},
+		{
+			name: "foreach element with variables",
+			patches: `
+- op: add
+  path: /metadata/annotations/arg
+  value: "{{ element }}"
+  foreach: request.object.spec.containers[0].args
+`,
+			expected: `[{"op":"add","path":"/metadata/annotations/arg","value":"{{ request.object.metadata.name }}"}]`,
+			expanded: true,
+		},
+		{
+			name: "if with unquoted variables",
+			patches: `
+- op: add
+  path: /metadata/labels/app
+  value: {{ request.object.metadata.name }}
+  if: "request.object.metadata.name == 'test'"
+- op: add
+  path: /metadata/labels/other
+  value: {{ request.object.metadata.name }}
+  if: "` + "`false`" + `"
+`,
+			expected: `[{"op":"add","path":"/metadata/labels/app","value":"test"}]`,
+			expanded: true,
+		},
+		{
+			name: "foreach with unquoted variables",
+			patches: `
+- op: add
+  path: /metadata/labels/{{ element.name }}
+  value: {{ to_upper('{{ element.name }}') }}
+  foreach: request.object.spec.containers
+`,
+			expected: `[{"op":"add","path":"/metadata/labels/nginx","value":"NGINX"},{"op":"add","path":"/metadata/labels/sidecar","value":"SIDECAR"}]`,
+			expanded: true,
+		},
+		{
+			name: "unquoted variables without if or foreach",
+			patches: `
+- op: add
+  path: /metadata/labels/app
+  value: {{ request.object.metadata.name }}
+`,
+			expected: `
+- op: add
+  path: /metadata/labels/app
+  value: {{ request.object.metadata.name }}
+`,
+		},
+		{
+			name: "all operations skipped",
+			patches: `
+- op: remove
+  path: /spec/tolerations
+  if: "request.object.metadata.name == 'other'"
+`,
+			expected: `[]`,
+			expanded: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			patches, expanded, err := ExpandPatchesJSON6902(tc.patches, ctx, 0, logging.GlobalLogger())
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, patches)
+			assert.Equal(t, tc.expanded, expanded)
+		})
+	}
+
+	_, _, err = ExpandPatchesJSON6902(`[{"op": "remove", "path": "/spec", "if": "request.object.metadata.name"}]`, ctx, 0, logging.GlobalLogger())
+	assert.ErrorContains(t, err, "must evaluate to a boolean")
+	_, _, err = ExpandPatchesJSON6902(`[{"op": "remove", "path": "/spec", "foreach": "request.object.metadata"}]`, ctx, 0, logging.GlobalLogger())
+	assert.ErrorContains(t, err, "must evaluate to a list")
+	_, _, err = ExpandPatchesJSON6902("- op: add\n\tpath: /spec", ctx, 0, logging.GlobalLogger())
+	assert.ErrorContains(t, err, "failed to convert patchesJSON6902 to JSON")
+}
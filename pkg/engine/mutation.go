@@ -21,9 +21,8 @@ import (
 )
 
 // Mutate performs mutation. Overlay first and then mutation patches
-func Mutate(
+func (e *engine) Mutate(
 	ctx context.Context,
-	contextLoader ContextLoaderFactory,
 	policyContext engineapi.PolicyContext,
 ) (resp *engineapi.EngineResponse) {
 	startTime := time.Now()
@@ -43,6 +42,7 @@ func Mutate(
 	startMutateResultResponse(resp, policy, matchedResource)
 	defer endMutateResultResponse(logger, resp, startTime)
 
+	e.loadPolicyFunctions(logger, policyContext)
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
 
@@ -60,6 +60,8 @@ func Mutate(
 			"pkg/engine",
 			fmt.Sprintf("RULE %s", rule.Name),
 			func(ctx context.Context, span trace.Span) {
+				ctx, cancel := WithRuleTimeout(ctx, &rule)
+				defer cancel()
 				logger := logger.WithValues("rule", rule.Name)
 				var excludeResource []string
 				if len(policyContext.ExcludeGroupRole()) > 0 {
@@ -75,7 +77,7 @@ func Mutate(
 				}
 
 				// check if there is a corresponding policy exception
-				ruleResp := hasPolicyExceptions(policyContext, &computeRules[i], subresourceGVKToAPIResource, logger)
+				ruleResp := hasPolicyExceptions(e.exceptionSelector, policyContext, &computeRules[i], subresourceGVKToAPIResource, logger)
 				if ruleResp != nil {
 					resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResp)
 					return
@@ -92,12 +94,16 @@ func Mutate(
 					logger.Error(err, "failed to query resource object")
 				}
 
-				if err := LoadContext(ctx, contextLoader, rule.Context, policyContext, rule.Name); err != nil {
+				if err := LoadContext(ctx, e.contextLoader, rule.Context, policyContext, rule.Name); err != nil {
 					if _, ok := err.(gojmespath.NotFoundError); ok {
 						logger.V(3).Info("failed to load context", "reason", err.Error())
 					} else {
 						logger.Error(err, "failed to load context")
 					}
+					if isTimeout(err) {
+						resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleError(&rule, engineapi.Mutation, "failed to load context", err))
+						incrementErrorCount(resp)
+					}
 					return
 				}
 
@@ -145,7 +151,7 @@ func Mutate(
 							policyContext: policyContext,
 							resource:      patchedResource,
 							log:           logger,
-							contextLoader: contextLoader,
+							contextLoader: e.contextLoader,
 							nesting:       0,
 						}
 
@@ -155,11 +161,11 @@ func Mutate(
 					}
 
 					matchedResource = mutateResp.PatchedResource
-					ruleResponse := buildRuleResponse(ruleCopy, mutateResp, patchedResource)
+					ruleResponse := checkTimeout(ctx, buildRuleResponse(ruleCopy, mutateResp, patchedResource))
 
 					if ruleResponse != nil {
 						resp.PolicyResponse.Rules = append(resp.PolicyResponse.Rules, *ruleResponse)
-						if ruleResponse.Status == engineapi.RuleStatusError {
+						if ruleResponse.HasStatus(engineapi.RuleStatusError, engineapi.RuleStatusTimeout) {
 							incrementErrorCount(resp)
 						} else {
 							incrementAppliedCount(resp)
@@ -205,7 +211,7 @@ type forEachMutator struct {
 	foreach       []kyvernov1.ForEachMutation
 	resource      resourceInfo
 	nesting       int
-	contextLoader ContextLoaderFactory
+	contextLoader engineapi.ContextLoaderFactory
 	log           logr.Logger
 }
 
@@ -271,6 +277,10 @@ func (f *forEachMutator) mutateElements(ctx context.Context, foreach kyvernov1.F
 			continue
 		}
 
+		if err := ctx.Err(); err != nil {
+			return mutate.NewErrorResponse(fmt.Sprintf("failed to process mutate.foreach[%d]", index), err)
+		}
+
 		f.policyContext.JSONContext().Reset()
 		policyContext := f.policyContext.Copy()
 
@@ -312,7 +322,7 @@ func (f *forEachMutator) mutateElements(ctx context.Context, foreach kyvernov1.F
 
 			mutateResp = m.mutateForEach(ctx)
 		} else {
-			mutateResp = mutate.ForEach(f.rule.Name, foreach, policyContext.JSONContext(), patchedResource.unstructured, f.log)
+			mutateResp = mutate.ForEach(f.rule.Name, foreach, policyContext.JSONContext(), patchedResource.unstructured, f.nesting, f.log)
 		}
 
 		if mutateResp.Status == engineapi.RuleStatusFail || mutateResp.Status == engineapi.RuleStatusError {
@@ -25,9 +25,15 @@ func doMutate(
 	rclient registryclient.Client,
 	pContext *PolicyContext,
 ) *engineapi.EngineResponse {
-	return Mutate(
-		ctx,
+	e := NewEngine(
+		cfg,
+		rclient,
 		LegacyContextLoaderFactory(rclient),
+		nil,
+		nil,
+	)
+	return e.Mutate(
+		ctx,
 		pContext,
 	)
 }
@@ -2093,3 +2099,79 @@ func Test_SpecialCharacters(t *testing.T) {
 		})
 	}
 }
+
+func Test_mutate_patchesJson6902_if_foreach(t *testing.T) {
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "tolerations"},
+		"spec": {
+			"rules": [
+				{
+					"name": "add-tolerations",
+					"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+					"mutate": {
+						"patchesJson6902": "- op: add\n  path: /spec/tolerations/-\n  value: {\"key\": \"dedicated\", \"operator\": \"Exists\"}\n  if: \"!contains(request.object.spec.tolerations[].key, 'dedicated')\"\n- op: add\n  path: /spec/tolerations/-\n  value: {\"key\": \"gpu\", \"operator\": \"Exists\"}\n  if: \"!contains(request.object.spec.tolerations[].key, 'gpu')\"\n- op: add\n  path: /metadata/annotations/{{ element.name }}\n  value: \"container {{ elementIndex }}\"\n  foreach: request.object.spec.containers\n"
+					}
+				}
+			]
+		}
+	}`)
+	resourceRaw := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "test", "annotations": {}},
+		"spec": {
+			"tolerations": [{"key": "dedicated", "operator": "Exists"}],
+			"containers": [{"name": "nginx", "image": "nginx"}, {"name": "sidecar", "image": "busybox"}]
+		}
+	}`)
+
+	er := testApplyPolicyToResource(t, policyRaw, resourceRaw)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusPass)
+
+	tolerations, _, err := unstructured.NestedSlice(er.PatchedResource.Object, "spec", "tolerations")
+	assert.NilError(t, err)
+	assert.Equal(t, len(tolerations), 2)
+	assert.Equal(t, tolerations[1].(map[string]interface{})["key"], "gpu")
+	annotations := er.PatchedResource.GetAnnotations()
+	assert.Equal(t, annotations["nginx"], "container 0")
+	assert.Equal(t, annotations["sidecar"], "container 1")
+}
+
+func Test_mutate_patchesJson6902_foreach_element_variables(t *testing.T) {
+	policyRaw := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "args"},
+		"spec": {
+			"rules": [
+				{
+					"name": "annotate-args",
+					"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+					"mutate": {
+						"patchesJson6902": "- op: add\n  path: /metadata/annotations/arg-{{ elementIndex }}\n  value: \"{{ element }}\"\n  foreach: request.object.spec.containers[0].args\n"
+					}
+				}
+			]
+		}
+	}`)
+	resourceRaw := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "test", "annotations": {}},
+		"spec": {
+			"containers": [{"name": "nginx", "image": "nginx", "args": ["{{ request.object.metadata.name }}", "\\{{ escaped }}"]}]
+		}
+	}`)
+
+	er := testApplyPolicyToResource(t, policyRaw, resourceRaw)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusPass)
+
+	// element values are not evaluated
+	annotations := er.PatchedResource.GetAnnotations()
+	assert.Equal(t, annotations["arg-0"], "{{ request.object.metadata.name }}")
+	assert.Equal(t, annotations["arg-1"], "\\{{ escaped }}")
+}
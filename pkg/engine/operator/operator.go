@@ -2,6 +2,7 @@ package operator
 
 import (
 	"regexp"
+	"strings"
 )
 
 // Operator is string alias that represents selection operators enum
@@ -24,6 +25,10 @@ const (
 	InRange Operator = "-"
 	// NotInRange stands for !-
 	NotInRange Operator = "!-"
+	// Regex stands for ~=
+	Regex Operator = "~="
+	// NotRegex stands for !~=
+	NotRegex Operator = "!~="
 )
 
 var (
@@ -36,6 +41,12 @@ func GetOperatorFromStringPattern(pattern string) Operator {
 	if len(pattern) < 2 {
 		return Equal
 	}
+	if strings.HasPrefix(pattern, string(NotRegex)) {
+		return NotRegex
+	}
+	if strings.HasPrefix(pattern, string(Regex)) {
+		return Regex
+	}
 	if pattern[:len(MoreEqual)] == string(MoreEqual) {
 		return MoreEqual
 	}
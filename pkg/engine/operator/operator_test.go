@@ -52,3 +52,10 @@ func TestGetOperatorFromStringPattern_RangeOperator(t *testing.T) {
 	assert.Equal(t, GetOperatorFromStringPattern("+0Mi!-+1024Mi"), NotInRange)
 
 }
+
+func TestGetOperatorFromStringPattern_RegexOperator(t *testing.T) {
+	assert.Equal(t, GetOperatorFromStringPattern("~=^registry\\.corp/.*"), Regex)
+	assert.Equal(t, GetOperatorFromStringPattern("!~=:latest$"), NotRegex)
+	assert.Equal(t, GetOperatorFromStringPattern("!value"), NotEqual)
+	assert.Equal(t, GetOperatorFromStringPattern("value~=1"), Equal)
+}
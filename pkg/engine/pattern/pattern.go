@@ -12,6 +12,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/operator"
 	wildcard "github.com/kyverno/kyverno/pkg/utils/wildcard"
 	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
 )
 
 type quantity int
@@ -22,6 +23,14 @@ const (
 	greaterThan quantity = 1
 )
 
+const (
+	maxCachedRegexes = 1000
+	regexCacheTTL    = time.Hour
+)
+
+// regexCache holds the compiled regular expressions of regex patterns
+var regexCache = utilcache.NewLRUExpireCache(maxCachedRegexes)
+
 // Validate validates a value against a pattern
 func Validate(log logr.Logger, value, pattern interface{}) bool {
 	switch typedPattern := pattern.(type) {
@@ -153,6 +162,10 @@ func validateStringPatterns(log logr.Logger, value interface{}, pattern string)
 	if value == pattern {
 		return true
 	}
+	// regular expressions are not split on the | and & separators
+	if op, expr, ok := parseRegexPattern(pattern); ok {
+		return validateRegexPattern(log, value, expr, op)
+	}
 	for _, condition := range strings.Split(pattern, "|") {
 		condition = strings.Trim(condition, " ")
 		if checkForAndConditionsAndValidate(log, value, condition) {
@@ -196,6 +209,69 @@ func validateStringPattern(log logr.Logger, value interface{}, pattern string) b
 	}
 }
 
+// CheckRegex returns an error if the pattern uses a regex operator with an invalid regular expression
+func CheckRegex(pattern interface{}) error {
+	typedPattern, ok := pattern.(string)
+	if !ok {
+		return nil
+	}
+	if _, expr, ok := parseRegexPattern(typedPattern); ok {
+		if _, err := compileRegex(expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseRegexPattern(pattern string) (operator.Operator, string, bool) {
+	pattern = strings.TrimSpace(pattern)
+	op := operator.GetOperatorFromStringPattern(pattern)
+	if op != operator.Regex && op != operator.NotRegex {
+		return op, "", false
+	}
+	return op, strings.TrimSpace(pattern[len(op):]), true
+}
+
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	if cached, ok := regexCache.Get(expr); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	regex, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %v", expr, err)
+	}
+	regexCache.Add(expr, regex, regexCacheTTL)
+	return regex, nil
+}
+
+func validateRegexPattern(log logr.Logger, value interface{}, expr string, op operator.Operator) bool {
+	regex, err := compileRegex(expr)
+	if err != nil {
+		log.Error(err, "failed to compile regex pattern")
+		return false
+	}
+	var strValue string
+	switch typedValue := value.(type) {
+	case string:
+		strValue = typedValue
+	case float64:
+		strValue = strconv.FormatFloat(typedValue, 'f', -1, 64)
+	case int:
+		strValue = strconv.Itoa(typedValue)
+	case int64:
+		strValue = strconv.FormatInt(typedValue, 10)
+	case bool:
+		strValue = strconv.FormatBool(typedValue)
+	default:
+		log.V(4).Info("unexpected type", "got", value, "expect", expr)
+		return false
+	}
+	if op == operator.NotRegex {
+		return !regex.MatchString(strValue)
+	}
+	return regex.MatchString(strValue)
+}
+
 func split(pattern string, r *regexp.Regexp) (string, string, bool) {
 	match := r.FindStringSubmatch(pattern)
 	if len(match) == 0 {
@@ -404,3 +404,22 @@ func TestValidateKernelVersion_NotEquals(t *testing.T) {
 	assert.Assert(t, !validateStringPatterns(logger, "5.10.84-1", "!5.10.84-1 & !5.15.2-1"))
 	assert.Assert(t, !validateStringPatterns(logger, "5.15.2-1", "!5.10.84-1 & !5.15.2-1"))
 }
+
+func TestValidateValueWithPattern_Regex(t *testing.T) {
+	assert.Assert(t, Validate(logger, "registry.corp/nginx:v1", "~=^registry\\.corp/.*"))
+	assert.Assert(t, !Validate(logger, "docker.io/nginx:v1", "~=^registry\\.corp/.*"))
+	assert.Assert(t, Validate(logger, "nginx", " ~= ^(nginx|httpd)$ "))
+	assert.Assert(t, !Validate(logger, "nginx:latest", "!~=:latest$"))
+	assert.Assert(t, Validate(logger, "nginx:v1", "!~=:latest$"))
+	assert.Assert(t, Validate(logger, int64(8080), "~=^80[0-9]{2}$"))
+	assert.Assert(t, Validate(logger, 1.5, "~=^1\\.5$"))
+	assert.Assert(t, !Validate(logger, nil, "~=.*"))
+	assert.Assert(t, !Validate(logger, "nginx", "~=(nginx"))
+}
+
+func TestCheckRegex(t *testing.T) {
+	assert.NilError(t, CheckRegex("~=^registry\\.corp/.*"))
+	assert.NilError(t, CheckRegex("(not a regex"))
+	assert.NilError(t, CheckRegex(int64(1)))
+	assert.ErrorContains(t, CheckRegex("!~=(nginx"), "invalid regular expression")
+}
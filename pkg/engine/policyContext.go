@@ -17,7 +17,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/client-go/tools/cache"
 )
 
 type PolicyExceptionLister interface {
@@ -80,9 +79,6 @@ type PolicyContext struct {
 	// This is used to determine if a resource is a subresource. It is only used when the policy context is populated
 	// by kyverno CLI. In all other cases when connected to a cluster, this is empty.
 	subresourcesInPolicy []engineapi.SubResource
-
-	// peLister list all policy exceptions
-	peLister PolicyExceptionLister
 }
 
 // engineapi.PolicyContext interface
@@ -147,27 +143,6 @@ func (c PolicyContext) Copy() engineapi.PolicyContext {
 	return c.copy()
 }
 
-func (c *PolicyContext) FindExceptions(rule string) ([]*kyvernov2alpha1.PolicyException, error) {
-	if c.peLister == nil {
-		return nil, nil
-	}
-	polexs, err := c.peLister.List(labels.Everything())
-	if err != nil {
-		return nil, err
-	}
-	var result []*kyvernov2alpha1.PolicyException
-	policyName, err := cache.MetaNamespaceKeyFunc(c.policy)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to compute policy key")
-	}
-	for _, polex := range polexs {
-		if polex.Contains(policyName, rule) {
-			result = append(result, polex)
-		}
-	}
-	return result, nil
-}
-
 func (c *PolicyContext) ExcludeResourceFunc() engineapi.ExcludeFunc {
 	return c.excludeResourceFunc
 }
@@ -264,9 +239,11 @@ func (c *PolicyContext) WithSubresourcesInPolicy(subresourcesInPolicy []engineap
 	return copy
 }
 
-func (c *PolicyContext) WithExceptions(peLister PolicyExceptionLister) *PolicyContext {
+// Fork returns a copy of the policy context with its own JSON context, the JSON context data is shared
+// until it gets modified, forks can be used to process policies concurrently
+func (c *PolicyContext) Fork() *PolicyContext {
 	copy := c.copy()
-	copy.peLister = peLister
+	copy.jsonContext = c.jsonContext.Copy()
 	return copy
 }
 
@@ -295,7 +272,6 @@ func NewPolicyContextFromAdmissionRequest(
 	configuration config.Configuration,
 	client dclient.Interface,
 	informerCacheResolver engineapi.ConfigmapResolver,
-	polexLister PolicyExceptionLister,
 ) (*PolicyContext, error) {
 	ctx, err := newVariablesContext(request, &admissionInfo)
 	if err != nil {
@@ -318,8 +294,7 @@ func NewPolicyContextFromAdmissionRequest(
 		WithAdmissionOperation(true).
 		WithInformerCacheResolver(informerCacheResolver).
 		WithRequestResource(*requestResource).
-		WithSubresource(request.SubResource).
-		WithExceptions(polexLister)
+		WithSubresource(request.SubResource)
 	return policyContext, nil
 }
 
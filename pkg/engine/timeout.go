@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+// WithRuleTimeout returns a context bounded by the rule timeout, the context is returned as is
+// when the rule has no timeout
+func WithRuleTimeout(ctx context.Context, rule *kyvernov1.Rule) (context.Context, context.CancelFunc) {
+	if rule.Timeout == nil || rule.Timeout.Duration <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, rule.Timeout.Duration)
+}
+
+// loadWithTimeout loads a context entry within its time budget, the entry is not loaded if the
+// budget of the rule is already exhausted
+func loadWithTimeout(ctx context.Context, entry kyvernov1.ContextEntry, load func(context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("context entry %s was not loaded: %w", entry.Name, err)
+	}
+	if entry.Timeout != nil && entry.Timeout.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, entry.Timeout.Duration)
+		defer cancel()
+	}
+	if err := load(ctx); err != nil {
+		// loaders don't always wrap context errors, make sure a timeout can be identified
+		if ctxErr := ctx.Err(); ctxErr != nil && !errors.Is(err, ctxErr) {
+			return fmt.Errorf("context entry %s exceeded its time budget: %v: %w", entry.Name, err, ctxErr)
+		}
+		return err
+	}
+	return nil
+}
+
+func isTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// checkTimeout reports a rule that failed to be processed as timed out if its time budget is exhausted
+func checkTimeout(ctx context.Context, resp *engineapi.RuleResponse) *engineapi.RuleResponse {
+	if resp != nil && resp.Status == engineapi.RuleStatusError && isTimeout(ctx.Err()) {
+		resp.Status = engineapi.RuleStatusTimeout
+	}
+	return resp
+}
@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type blockingContextLoader struct{}
+
+func (blockingContextLoader) Load(ctx context.Context, _ []kyverno.ContextEntry, _ enginecontext.Interface) error {
+	<-ctx.Done()
+	return errors.New("request aborted")
+}
+
+func Test_loadWithTimeout(t *testing.T) {
+	entry := kyverno.ContextEntry{Name: "slow", Timeout: &metav1.Duration{Duration: 10 * time.Millisecond}}
+	err := loadWithTimeout(context.TODO(), entry, func(ctx context.Context) error {
+		<-ctx.Done()
+		return errors.New("request aborted")
+	})
+	assert.Assert(t, isTimeout(err))
+	assert.ErrorContains(t, err, "context entry slow exceeded its time budget")
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 0)
+	defer cancel()
+	loaded := false
+	err = loadWithTimeout(ctx, kyverno.ContextEntry{Name: "skipped"}, func(context.Context) error {
+		loaded = true
+		return nil
+	})
+	assert.Assert(t, isTimeout(err))
+	assert.Assert(t, !loaded)
+
+	err = loadWithTimeout(context.TODO(), kyverno.ContextEntry{Name: "fast"}, func(context.Context) error { return nil })
+	assert.NilError(t, err)
+}
+
+func Test_Validate_RuleTimeout(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "slow-policy"},
+		"spec": {
+			"rules": [
+				{
+					"name": "slow-rule",
+					"timeout": "10ms",
+					"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+					"context": [{"name": "data", "apiCall": {"urlPath": "/api/v1/namespaces"}}],
+					"validate": {"pattern": {"metadata": {"name": "?*"}}}
+				}
+			]
+		}
+	}`)
+	rawResource := []byte(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test"}}`)
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+	assert.NilError(t, err)
+	e := NewEngine(
+		config.NewDefaultConfiguration(),
+		registryclient.NewOrDie(),
+		func(engineapi.PolicyContext, string) engineapi.ContextLoader { return blockingContextLoader{} },
+		nil,
+		nil,
+	)
+	er := e.Validate(context.TODO(), &PolicyContext{policy: &policy, newResource: *resourceUnstructured, jsonContext: enginecontext.NewContext()})
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusTimeout)
+	assert.Assert(t, er.IsError())
+	assert.Assert(t, !er.IsSuccessful())
+}
+
+func Test_VerifyAndPatchImages_RuleTimeout(t *testing.T) {
+	policy := `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "slow-policy"},
+		"spec": {
+			"rules": [
+				{
+					"name": "slow-rule",
+					"timeout": "10ms",
+					"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+					"context": [{"name": "data", "apiCall": {"urlPath": "/api/v1/namespaces"}}],
+					"verifyImages": [{"imageReferences": ["*"], "attestors": [{"entries": [{"keys": {"publicKeys": "key"}}]}]}]
+				}
+			]
+		}
+	}`
+	resource := `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test"}, "spec": {"containers": [{"name": "nginx", "image": "nginx:latest"}]}}`
+	policyContext := buildContext(t, policy, resource, "")
+	e := NewEngine(
+		config.NewDefaultConfiguration(),
+		registryclient.NewOrDie(),
+		func(engineapi.PolicyContext, string) engineapi.ContextLoader { return blockingContextLoader{} },
+		nil,
+		nil,
+	)
+	er, _ := e.VerifyAndPatchImages(context.TODO(), policyContext)
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusTimeout)
+	assert.Assert(t, er.IsError())
+}
@@ -356,6 +356,9 @@ func evaluateList(jmesPath string, ctx context.EvalInterface) ([]interface{}, er
 
 func ruleError(rule *kyvernov1.Rule, ruleType engineapi.RuleType, msg string, err error) *engineapi.RuleResponse {
 	msg = fmt.Sprintf("%s: %s", msg, err.Error())
+	if isTimeout(err) {
+		return ruleResponse(*rule, ruleType, msg, engineapi.RuleStatusTimeout)
+	}
 	return ruleResponse(*rule, ruleType, msg, engineapi.RuleStatusError)
 }
 
@@ -91,6 +91,9 @@ func validateResourceElement(log logr.Logger, resourceElement, patternElement, o
 	// elementary values
 	case string, float64, int, int64, bool, nil:
 		/*Analyze pattern */
+		if err := pattern.CheckRegex(patternElement); err != nil {
+			return path, fmt.Errorf("invalid pattern '%v' at path %s: %v", patternElement, path, err)
+		}
 
 		switch resource := resourceElement.(type) {
 		case []interface{}:
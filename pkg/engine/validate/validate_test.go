@@ -1689,3 +1689,68 @@ func testMatchPattern(t *testing.T, testCase struct {
 		assert.Assert(t, err == nil, fmt.Sprintf("\nexpected error - test: %s\npattern: %s\nresource: %s\n", testCase.name, pattern, resource))
 	}
 }
+
+func Test_regex_pattern(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pattern  []byte
+		resource []byte
+		status   engineapi.RuleStatus
+	}{
+		{
+			name:     "check_regex_pass",
+			pattern:  []byte(`{"spec": {"containers": [{"image": "~=^registry\\.corp/(app|tools)/.*$"}]}}`),
+			resource: []byte(`{"spec": {"containers": [{"name": "nginx","image": "registry.corp/app/nginx:v1"},{"name": "curl","image": "registry.corp/tools/curl:v1"}]}}`),
+			status:   engineapi.RuleStatusPass,
+		},
+		{
+			name:     "check_regex_fail",
+			pattern:  []byte(`{"spec": {"containers": [{"image": "~=^registry\\.corp/(app|tools)/.*$"}]}}`),
+			resource: []byte(`{"spec": {"containers": [{"name": "nginx","image": "docker.io/nginx:v1"}]}}`),
+			status:   engineapi.RuleStatusFail,
+		},
+		{
+			name:     "check_not_regex_pass",
+			pattern:  []byte(`{"spec": {"containers": [{"image": "!~=:latest$"}]}}`),
+			resource: []byte(`{"spec": {"containers": [{"name": "nginx","image": "nginx:v1"}]}}`),
+			status:   engineapi.RuleStatusPass,
+		},
+		{
+			name:     "check_not_regex_fail",
+			pattern:  []byte(`{"spec": {"containers": [{"image": "!~=:latest$"}]}}`),
+			resource: []byte(`{"spec": {"containers": [{"name": "nginx","image": "nginx:latest"}]}}`),
+			status:   engineapi.RuleStatusFail,
+		},
+	}
+
+	for i := range testCases {
+		testMatchPattern(t, testCases[i])
+	}
+}
+
+func Test_regex_pattern_errors(t *testing.T) {
+	testCases := []struct {
+		pattern  []byte
+		resource []byte
+		err      string
+	}{
+		{
+			pattern:  []byte(`{"spec": {"containers": [{"image": "~=^registry\\.corp/.*"}]}}`),
+			resource: []byte(`{"spec": {"containers": [{"name": "nginx","image": "docker.io/nginx:v1"}]}}`),
+			err:      "resource value 'docker.io/nginx:v1' does not match '~=^registry\\.corp/.*' at path /spec/containers/0/image/",
+		},
+		{
+			pattern:  []byte(`{"spec": {"containers": [{"image": "~=^registry\\.corp/(app"}]}}`),
+			resource: []byte(`{"spec": {"containers": [{"name": "nginx","image": "registry.corp/app/nginx:v1"}]}}`),
+			err:      "invalid pattern '~=^registry\\.corp/(app' at path /spec/containers/0/image/: invalid regular expression \"^registry\\\\.corp/(app\": error parsing regexp: missing closing ): `^registry\\.corp/(app`",
+		},
+	}
+
+	for _, tc := range testCases {
+		var pattern, resource interface{}
+		assert.NilError(t, json.Unmarshal(tc.pattern, &pattern))
+		assert.NilError(t, json.Unmarshal(tc.resource, &resource))
+		err := MatchPattern(logging.GlobalLogger(), resource, pattern)
+		assert.Error(t, err, tc.err)
+	}
+}
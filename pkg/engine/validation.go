@@ -11,9 +11,7 @@ import (
 	"github.com/go-logr/logr"
 	gojmespath "github.com/jmespath/go-jmespath"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
-	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/autogen"
-	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/validate"
@@ -23,7 +21,6 @@ import (
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"github.com/kyverno/kyverno/pkg/utils/api"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
-	matched "github.com/kyverno/kyverno/pkg/utils/match"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
@@ -32,15 +29,12 @@ import (
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/client-go/tools/cache"
 )
 
 // Validate applies validation rules from policy on the resource
-func Validate(
+func (e *engine) Validate(
 	ctx context.Context,
-	contextLoader ContextLoaderFactory,
 	policyContext engineapi.PolicyContext,
-	cfg config.Configuration,
 ) (resp *engineapi.EngineResponse) {
 	resp = &engineapi.EngineResponse{}
 	startTime := time.Now()
@@ -52,7 +46,8 @@ func Validate(
 		logger.V(4).Info("finished policy processing", "processingTime", resp.PolicyResponse.ProcessingTime.String(), "validationRulesApplied", resp.PolicyResponse.RulesAppliedCount)
 	}()
 
-	resp = validateResource(ctx, contextLoader, logger, policyContext, cfg)
+	e.loadPolicyFunctions(logger, policyContext)
+	resp = e.validateResource(ctx, logger, policyContext)
 	resp.NamespaceLabels = policyContext.NamespaceLabels()
 	return
 }
@@ -103,12 +98,10 @@ func buildResponse(ctx engineapi.PolicyContext, resp *engineapi.EngineResponse,
 	resp.PolicyResponse.Timestamp = startTime.Unix()
 }
 
-func validateResource(
+func (e *engine) validateResource(
 	ctx context.Context,
-	contextLoader ContextLoaderFactory,
 	log logr.Logger,
 	enginectx engineapi.PolicyContext,
-	cfg config.Configuration,
 ) *engineapi.EngineResponse {
 	resp := &engineapi.EngineResponse{}
 
@@ -141,6 +134,8 @@ func validateResource(
 			"pkg/engine",
 			fmt.Sprintf("RULE %s", rule.Name),
 			func(ctx context.Context, span trace.Span) *engineapi.RuleResponse {
+				ctx, cancel := WithRuleTimeout(ctx, rule)
+				defer cancel()
 				hasValidate := rule.HasValidate()
 				hasValidateImage := rule.HasImagesValidationChecks()
 				hasYAMLSignatureVerify := rule.HasYAMLSignatureVerify()
@@ -155,16 +150,16 @@ func validateResource(
 					return nil
 				}
 				// check if there is a corresponding policy exception
-				ruleResp := hasPolicyExceptions(enginectx, rule, subresourceGVKToAPIResource, log)
+				ruleResp := hasPolicyExceptions(e.exceptionSelector, enginectx, rule, subresourceGVKToAPIResource, log)
 				if ruleResp != nil {
 					return ruleResp
 				}
 				log.V(3).Info("processing validation rule", "matchCount", matchCount, "applyRules", applyRules)
 				enginectx.JSONContext().Reset()
 				if hasValidate && !hasYAMLSignatureVerify {
-					return processValidationRule(ctx, contextLoader, log, enginectx, rule)
+					return checkTimeout(ctx, processValidationRule(ctx, e.contextLoader, log, enginectx, rule))
 				} else if hasValidateImage {
-					return processImageValidationRule(ctx, contextLoader, log, enginectx, rule, cfg)
+					return checkTimeout(ctx, processImageValidationRule(ctx, e.contextLoader, log, enginectx, rule, e.configuration))
 				} else if hasYAMLSignatureVerify {
 					return processYAMLValidationRule(log, enginectx, rule)
 				}
@@ -184,7 +179,7 @@ func validateResource(
 
 func processValidationRule(
 	ctx context.Context,
-	contextLoader ContextLoaderFactory,
+	contextLoader engineapi.ContextLoaderFactory,
 	log logr.Logger,
 	policyContext engineapi.PolicyContext,
 	rule *kyvernov1.Rule,
@@ -200,7 +195,7 @@ func addRuleResponse(log logr.Logger, resp *engineapi.EngineResponse, ruleResp *
 
 	if ruleResp.Status == engineapi.RuleStatusPass || ruleResp.Status == engineapi.RuleStatusFail {
 		incrementAppliedCount(resp)
-	} else if ruleResp.Status == engineapi.RuleStatusError {
+	} else if ruleResp.HasStatus(engineapi.RuleStatusError, engineapi.RuleStatusTimeout) {
 		incrementErrorCount(resp)
 	}
 
@@ -218,11 +213,11 @@ type validator struct {
 	deny             *kyvernov1.Deny
 	podSecurity      *kyvernov1.PodSecurity
 	forEach          []kyvernov1.ForEachValidation
-	contextLoader    ContextLoaderFactory
+	contextLoader    engineapi.ContextLoaderFactory
 	nesting          int
 }
 
-func newValidator(log logr.Logger, contextLoader ContextLoaderFactory, ctx engineapi.PolicyContext, rule *kyvernov1.Rule) *validator {
+func newValidator(log logr.Logger, contextLoader engineapi.ContextLoaderFactory, ctx engineapi.PolicyContext, rule *kyvernov1.Rule) *validator {
 	ruleCopy := rule.DeepCopy()
 	return &validator{
 		log:              log,
@@ -241,7 +236,7 @@ func newValidator(log logr.Logger, contextLoader ContextLoaderFactory, ctx engin
 
 func newForEachValidator(
 	foreach kyvernov1.ForEachValidation,
-	contextLoader ContextLoaderFactory,
+	contextLoader engineapi.ContextLoaderFactory,
 	nesting int,
 	rule *kyvernov1.Rule,
 	ctx engineapi.PolicyContext,
@@ -349,6 +344,9 @@ func (v *validator) validateElements(ctx context.Context, foreach kyvernov1.ForE
 		if element == nil {
 			continue
 		}
+		if err := ctx.Err(); err != nil {
+			return ruleError(v.rule, engineapi.Validation, "failed to process foreach", err), applyCount
+		}
 
 		v.policyContext.JSONContext().Reset()
 		policyContext := v.policyContext.Copy()
@@ -630,7 +628,9 @@ func (v *validator) validatePatterns(resource unstructured.Unstructured) *engine
 					return ruleResponse(*v.rule, engineapi.Validation, v.buildErrorMessage(err, ""), engineapi.RuleStatusError)
 				}
 
-				return ruleResponse(*v.rule, engineapi.Validation, v.buildErrorMessage(err, pe.Path), engineapi.RuleStatusFail)
+				resp := ruleResponse(*v.rule, engineapi.Validation, v.buildErrorMessage(err, pe.Path), engineapi.RuleStatusFail)
+				resp.Path = pe.Path
+				return resp
 			}
 
 			return ruleResponse(*v.rule, engineapi.Validation, v.buildErrorMessage(err, pe.Path), engineapi.RuleStatusError)
@@ -792,57 +792,3 @@ func (v *validator) substituteDeny() error {
 	v.deny = i.(*kyvernov1.Deny)
 	return nil
 }
-
-// matchesException checks if an exception applies to the resource being admitted
-func matchesException(
-	policyContext engineapi.PolicyContext,
-	rule *kyvernov1.Rule,
-	subresourceGVKToAPIResource map[string]*metav1.APIResource,
-) (*kyvernov2alpha1.PolicyException, error) {
-	candidates, err := policyContext.FindExceptions(rule.Name)
-	if err != nil {
-		return nil, err
-	}
-	for _, candidate := range candidates {
-		err := matched.CheckMatchesResources(
-			policyContext.NewResource(),
-			candidate.Spec.Match,
-			policyContext.NamespaceLabels(),
-			subresourceGVKToAPIResource,
-			policyContext.SubResource(),
-			policyContext.AdmissionInfo(),
-			policyContext.ExcludeGroupRole(),
-		)
-		// if there's no error it means a match
-		if err == nil {
-			return candidate, nil
-		}
-	}
-	return nil, nil
-}
-
-// hasPolicyExceptions returns nil when there are no matching exceptions.
-// A rule response is returned when an exception is matched, or there is an error.
-func hasPolicyExceptions(ctx engineapi.PolicyContext, rule *kyvernov1.Rule, subresourceGVKToAPIResource map[string]*metav1.APIResource, log logr.Logger) *engineapi.RuleResponse {
-	// if matches, check if there is a corresponding policy exception
-	exception, err := matchesException(ctx, rule, subresourceGVKToAPIResource)
-	// if we found an exception
-	if err == nil && exception != nil {
-		key, err := cache.MetaNamespaceKeyFunc(exception)
-		if err != nil {
-			log.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
-			return &engineapi.RuleResponse{
-				Name:    rule.Name,
-				Message: "failed to find matched exception " + key,
-				Status:  engineapi.RuleStatusError,
-			}
-		}
-		log.V(3).Info("policy rule skipped due to policy exception", "exception", key)
-		return &engineapi.RuleResponse{
-			Name:    rule.Name,
-			Message: "rule skipped due to policy exception " + key,
-			Status:  engineapi.RuleStatusSkip,
-		}
-	}
-	return nil
-}
@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 
@@ -17,14 +18,21 @@ import (
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"gotest.tools/assert"
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 func doValidate(ctx context.Context, rclient registryclient.Client, pContext *PolicyContext, cfg config.Configuration) *engineapi.EngineResponse {
-	return Validate(
-		ctx,
+	e := NewEngine(
+		cfg,
+		rclient,
 		LegacyContextLoaderFactory(rclient),
+		nil,
+		nil,
+	)
+	return e.Validate(
+		ctx,
 		pContext,
-		cfg,
 	)
 }
 
@@ -561,6 +569,7 @@ func TestValidate_anchor_arraymap_fail(t *testing.T) {
 
 	for index, r := range er.PolicyResponse.Rules {
 		assert.Equal(t, r.Message, msgs[index])
+		assert.Equal(t, r.Path, "/spec/volumes/0/hostPath/path/")
 	}
 	assert.Assert(t, !er.IsSuccessful())
 }
@@ -3224,3 +3233,119 @@ func Test_ValidatePattern_anyPattern(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateDeny_userFunctions(t *testing.T) {
+	rawPolicy := []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"require-qualified-image"},"spec":{"validationFailureAction":"enforce","functions":[{"name":"registry","parameters":["image"],"expression":"split(image, '/')[0]"},{"name":"trusted","parameters":["image"],"expression":"registry(image) == 'ghcr.io'"}],"rules":[{"name":"check-registry","match":{"resources":{"kinds":["Pod"]}},"validate":{"message":"image {{ request.object.spec.containers[0].image }} is pulled from {{ registry(request.object.spec.containers[0].image) }}","deny":{"conditions":{"any":[{"key":"{{ trusted(request.object.spec.containers[0].image) }}","operator":"Equals","value":false}]}}}}]}}`)
+	testCases := []struct {
+		description    string
+		rawResource    []byte
+		expectedFailed bool
+		expectedMsg    string
+	}{
+		{
+			description: "trusted registry",
+			rawResource: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod"},"spec":{"containers":[{"name":"nginx","image":"ghcr.io/nginx:1.23"}]}}`),
+		},
+		{
+			description:    "untrusted registry",
+			rawResource:    []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod"},"spec":{"containers":[{"name":"nginx","image":"docker.io/nginx:1.23"}]}}`),
+			expectedFailed: true,
+			expectedMsg:    "image docker.io/nginx:1.23 is pulled from docker.io",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var policy kyverno.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			resourceUnstructured, err := kubeutils.BytesToUnstructured(tc.rawResource)
+			assert.NilError(t, err)
+			ctx := enginecontext.NewContext()
+			assert.NilError(t, enginecontext.AddResource(ctx, tc.rawResource))
+			er := doValidate(context.TODO(), registryclient.NewOrDie(), &PolicyContext{policy: &policy, newResource: *resourceUnstructured, jsonContext: ctx}, cfg)
+			if tc.expectedFailed {
+				assert.Assert(t, er.IsFailed())
+				assert.Equal(t, er.PolicyResponse.Rules[0].Message, tc.expectedMsg)
+			} else {
+				assert.Assert(t, er.IsSuccessful())
+			}
+		})
+	}
+}
+
+type ingressLister []*unstructured.Unstructured
+
+func (l ingressLister) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (l ingressLister) List(apiVersion, kind, namespace string, selector labels.Selector) ([]*unstructured.Unstructured, error) {
+	return l, nil
+}
+
+func Test_ValidateDeny_lookupFunctions(t *testing.T) {
+	rawPolicy := []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"unique-ingress-host"},"spec":{"validationFailureAction":"enforce","rules":[{"name":"check-host","match":{"resources":{"kinds":["Ingress"]}},"context":[{"name":"hosts","variable":{"jmesPath":"list('networking.k8s.io/v1', 'Ingress', '', null)[].spec.rules[].host"}}],"validate":{"message":"host {{ request.object.spec.rules[0].host }} is already used","deny":{"conditions":{"any":[{"key":"{{ request.object.spec.rules[0].host }}","operator":"AnyIn","value":"{{ hosts }}"}]}}}}]}}`)
+	existing, err := kubeutils.BytesToUnstructured([]byte(`{"apiVersion":"networking.k8s.io/v1","kind":"Ingress","metadata":{"name":"web","namespace":"default"},"spec":{"rules":[{"host":"web.example.com"}]}}`))
+	assert.NilError(t, err)
+	e := NewEngine(
+		cfg,
+		registryclient.NewOrDie(),
+		LegacyContextLoaderFactory(registryclient.NewOrDie()),
+		nil,
+		ingressLister{existing},
+	)
+	testCases := []struct {
+		description    string
+		rawResource    []byte
+		expectedFailed bool
+	}{
+		{
+			description: "unique host",
+			rawResource: []byte(`{"apiVersion":"networking.k8s.io/v1","kind":"Ingress","metadata":{"name":"api","namespace":"default"},"spec":{"rules":[{"host":"api.example.com"}]}}`),
+		},
+		{
+			description:    "duplicated host",
+			rawResource:    []byte(`{"apiVersion":"networking.k8s.io/v1","kind":"Ingress","metadata":{"name":"api","namespace":"default"},"spec":{"rules":[{"host":"web.example.com"}]}}`),
+			expectedFailed: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var policy kyverno.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+			resourceUnstructured, err := kubeutils.BytesToUnstructured(tc.rawResource)
+			assert.NilError(t, err)
+			ctx := enginecontext.NewContext()
+			assert.NilError(t, enginecontext.AddResource(ctx, tc.rawResource))
+			er := e.Validate(context.TODO(), &PolicyContext{policy: &policy, newResource: *resourceUnstructured, jsonContext: ctx})
+			if tc.expectedFailed {
+				assert.Assert(t, er.IsFailed())
+				assert.Equal(t, er.PolicyResponse.Rules[0].Message, "host web.example.com is already used")
+			} else {
+				assert.Assert(t, er.IsSuccessful())
+			}
+		})
+	}
+}
+
+func Test_ValidateDeny_lookupFunctionsNamespacedPolicy(t *testing.T) {
+	rawPolicy := []byte(`{"apiVersion":"kyverno.io/v1","kind":"Policy","metadata":{"name":"unique-ingress-host","namespace":"default"},"spec":{"validationFailureAction":"enforce","rules":[{"name":"check-host","match":{"resources":{"kinds":["Ingress"]}},"context":[{"name":"hosts","variable":{"jmesPath":"list('networking.k8s.io/v1', 'Ingress', 'other', null)[].spec.rules[].host"}}],"validate":{"message":"host {{ request.object.spec.rules[0].host }} is already used","deny":{"conditions":{"any":[{"key":"{{ request.object.spec.rules[0].host }}","operator":"AnyIn","value":"{{ hosts }}"}]}}}}]}}`)
+	rawResource := []byte(`{"apiVersion":"networking.k8s.io/v1","kind":"Ingress","metadata":{"name":"api","namespace":"default"},"spec":{"rules":[{"host":"api.example.com"}]}}`)
+	e := NewEngine(
+		cfg,
+		registryclient.NewOrDie(),
+		LegacyContextLoaderFactory(registryclient.NewOrDie()),
+		nil,
+		ingressLister{},
+	)
+	var policy kyverno.Policy
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	resourceUnstructured, err := kubeutils.BytesToUnstructured(rawResource)
+	assert.NilError(t, err)
+	ctx := enginecontext.NewContext()
+	assert.NilError(t, enginecontext.AddResource(ctx, rawResource))
+	er := e.Validate(context.TODO(), &PolicyContext{policy: &policy, newResource: *resourceUnstructured, jsonContext: ctx})
+	assert.Equal(t, len(er.PolicyResponse.Rules), 1)
+	// the list function fails as the namespace of the policy is not the listed namespace
+	assert.Equal(t, er.PolicyResponse.Rules[0].Status, engineapi.RuleStatusError)
+	assert.Assert(t, strings.Contains(er.PolicyResponse.Rules[0].Message, "variable hosts"))
+}
@@ -120,6 +120,12 @@ func TestEvaluate(t *testing.T) {
 		{kyverno.Condition{RawKey: kyverno.ToJSON("1h"), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON("30m")}, true},
 		{kyverno.Condition{RawKey: kyverno.ToJSON("1h"), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON("1h")}, false},
 		{kyverno.Condition{RawKey: kyverno.ToJSON("1Gi"), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON("1Gi")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("500Mi"), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON("1Gi")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("1Gi"), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON("500Mi")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(2048), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON("1Ki")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(0.5), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON("1k")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("2Ki"), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON(1024)}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("1Ki"), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON(1024)}, false},
 		{kyverno.Condition{RawKey: kyverno.ToJSON("10"), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON(1)}, true},
 		{kyverno.Condition{RawKey: kyverno.ToJSON(100), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON("10")}, true},
 		{kyverno.Condition{RawKey: kyverno.ToJSON("100"), Operator: kyverno.ConditionOperators["GreaterThan"], RawValue: kyverno.ToJSON("10")}, true},
@@ -374,6 +380,19 @@ func TestEvaluate(t *testing.T) {
 		{kyverno.Condition{RawKey: kyverno.ToJSON("5"), Operator: kyverno.ConditionOperators["AnyNotIn"], RawValue: kyverno.ToJSON("1-3")}, true},
 		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{1, 5, 11}), Operator: kyverno.ConditionOperators["AnyNotIn"], RawValue: kyverno.ToJSON("0-10")}, true},
 		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{1, 5, 7}), Operator: kyverno.ConditionOperators["AnyNotIn"], RawValue: kyverno.ToJSON("0-10")}, false},
+
+		// CIDR Contains
+		{kyverno.Condition{RawKey: kyverno.ToJSON("10.0.0.0/8"), Operator: kyverno.ConditionOperators["CIDRContains"], RawValue: kyverno.ToJSON("10.1.2.3")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("10.0.0.0/8"), Operator: kyverno.ConditionOperators["CIDRContains"], RawValue: kyverno.ToJSON("10.1.0.0/16")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"10.0.0.0/8", "192.168.0.0/16"}), Operator: kyverno.ConditionOperators["CIDRContains"], RawValue: kyverno.ToJSON([]interface{}{"10.1.2.3", "192.168.1.1"})}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("10.0.0.0/8"), Operator: kyverno.ConditionOperators["CIDRContains"], RawValue: kyverno.ToJSON([]interface{}{"10.1.2.3", "192.168.1.1"})}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("10.1.0.0/16"), Operator: kyverno.ConditionOperators["CIDRContains"], RawValue: kyverno.ToJSON("10.0.0.0/8")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("invalid"), Operator: kyverno.ConditionOperators["CIDRContains"], RawValue: kyverno.ToJSON("10.0.0.1")}, false},
+
+		// Any CIDR Overlaps
+		{kyverno.Condition{RawKey: kyverno.ToJSON("10.0.0.0/16"), Operator: kyverno.ConditionOperators["AnyCIDROverlaps"], RawValue: kyverno.ToJSON("10.0.128.0/17")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"172.16.0.0/12", "10.0.0.0/16"}), Operator: kyverno.ConditionOperators["AnyCIDROverlaps"], RawValue: kyverno.ToJSON([]interface{}{"192.168.0.0/16", "10.0.0.0/8"})}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{"172.16.0.0/12", "10.0.0.0/16"}), Operator: kyverno.ConditionOperators["AnyCIDROverlaps"], RawValue: kyverno.ToJSON("192.168.0.0/16")}, false},
 	}
 
 	ctx := context.NewContext()
@@ -0,0 +1,141 @@
+package operator
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	netutils "github.com/kyverno/kyverno/pkg/utils/net"
+)
+
+// NewCIDROperatorHandler returns handler to manage the provided CIDR operations (CIDRContains, AnyCIDROverlaps)
+func NewCIDROperatorHandler(log logr.Logger, ctx context.EvalInterface, op kyvernov1.ConditionOperator) OperatorHandler {
+	return CIDROperatorHandler{
+		ctx:       ctx,
+		log:       log,
+		condition: op,
+	}
+}
+
+// CIDROperatorHandler provides implementation to handle CIDR Operations associated with policies
+type CIDROperatorHandler struct {
+	ctx       context.EvalInterface
+	log       logr.Logger
+	condition kyvernov1.ConditionOperator
+}
+
+// Evaluate evaluates expression with CIDR Operators, the key and the value can be a CIDR, an IP address
+// or a list of CIDRs and IP addresses
+func (coh CIDROperatorHandler) Evaluate(key, value interface{}) bool {
+	switch typedKey := key.(type) {
+	case string:
+		return coh.validateValueWithStringPattern(typedKey, value)
+	case []interface{}:
+		return coh.validateValueWithSlicePattern(typedKey, value)
+	default:
+		coh.log.V(2).Info("Unsupported type", "value", typedKey, "type", fmt.Sprintf("%T", typedKey))
+		return false
+	}
+}
+
+func (coh CIDROperatorHandler) validateValueWithStringPattern(key string, value interface{}) bool {
+	return coh.validateValueWithSlicePattern([]interface{}{key}, value)
+}
+
+func (coh CIDROperatorHandler) validateValueWithSlicePattern(key []interface{}, value interface{}) bool {
+	keyPrefixes, err := parsePrefixes(key)
+	if err != nil {
+		coh.log.V(2).Info("Invalid CIDR in key", "key", key, "error", err.Error())
+		return false
+	}
+	var values []interface{}
+	switch typedValue := value.(type) {
+	case string:
+		values = []interface{}{typedValue}
+	case []interface{}:
+		values = typedValue
+	default:
+		coh.log.V(2).Info("Unexpected type", "value", value, "type", fmt.Sprintf("%T", value))
+		return false
+	}
+	valuePrefixes, err := parsePrefixes(values)
+	if err != nil {
+		coh.log.V(2).Info("Invalid CIDR in value", "value", value, "error", err.Error())
+		return false
+	}
+	switch coh.condition {
+	case kyvernov1.ConditionOperators["CIDRContains"]:
+		return allPrefixesContained(keyPrefixes, valuePrefixes)
+	case kyvernov1.ConditionOperators["AnyCIDROverlaps"]:
+		return anyPrefixesOverlap(keyPrefixes, valuePrefixes)
+	default:
+		coh.log.V(2).Info(fmt.Sprintf("Expected operator, one of [CIDRContains, AnyCIDROverlaps], found %s", coh.condition))
+		return false
+	}
+}
+
+func parsePrefixes(values []interface{}) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string, found %T", value)
+		}
+		prefix, err := netutils.ParsePrefix(s)
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+// allPrefixesContained checks if each inner prefix is contained in one of the outer prefixes
+func allPrefixesContained(outer, inner []netip.Prefix) bool {
+	if len(outer) == 0 || len(inner) == 0 {
+		return false
+	}
+	for _, i := range inner {
+		contained := false
+		for _, o := range outer {
+			if netutils.PrefixContains(o, i) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			return false
+		}
+	}
+	return true
+}
+
+// anyPrefixesOverlap checks if any prefix of a overlaps any prefix of b
+func anyPrefixesOverlap(a, b []netip.Prefix) bool {
+	for _, i := range a {
+		for _, j := range b {
+			if i.Overlaps(j) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (coh CIDROperatorHandler) validateValueWithBoolPattern(_ bool, _ interface{}) bool {
+	return false
+}
+
+func (coh CIDROperatorHandler) validateValueWithIntPattern(_ int64, _ interface{}) bool {
+	return false
+}
+
+func (coh CIDROperatorHandler) validateValueWithFloatPattern(_ float64, _ interface{}) bool {
+	return false
+}
+
+func (coh CIDROperatorHandler) validateValueWithMapPattern(_ map[string]interface{}, _ interface{}) bool {
+	return false
+}
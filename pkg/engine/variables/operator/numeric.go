@@ -98,7 +98,12 @@ func (noh NumericOperatorHandler) validateValueWithIntPattern(key int64, value i
 		if err == nil {
 			return compareByCondition(float64(key), float64(int64val), noh.condition, noh.log)
 		}
-		noh.log.Error(fmt.Errorf("parse error: "), "Failed to parse both float64 and int64 from the string value")
+		// the value can still be a resource quantity like "1Ki"
+		resourceKey, resourceValue, err := parseQuantity(key, value)
+		if err == nil {
+			return compareByCondition(float64(resourceKey.Cmp(resourceValue)), 0, noh.condition, noh.log)
+		}
+		noh.log.Error(fmt.Errorf("parse error: "), "Failed to parse float64, int64 or resource quantity from the string value")
 		return false
 	default:
 		noh.log.V(2).Info("Expected type int", "value", value, "type", fmt.Sprintf("%T", value))
@@ -127,7 +132,12 @@ func (noh NumericOperatorHandler) validateValueWithFloatPattern(key float64, val
 		if err == nil {
 			return compareByCondition(key, float64(int64val), noh.condition, noh.log)
 		}
-		noh.log.Error(fmt.Errorf("parse error: "), "Failed to parse both float64 and int64 from the string value")
+		// the value can still be a resource quantity like "1Ki"
+		resourceKey, resourceValue, err := parseQuantity(key, value)
+		if err == nil {
+			return compareByCondition(float64(resourceKey.Cmp(resourceValue)), 0, noh.condition, noh.log)
+		}
+		noh.log.Error(fmt.Errorf("parse error: "), "Failed to parse float64, int64 or resource quantity from the string value")
 		return false
 	default:
 		noh.log.V(2).Info("Expected type float", "value", value, "type", fmt.Sprintf("%T", value))
@@ -181,30 +191,37 @@ func (noh NumericOperatorHandler) validateValueWithStringPattern(key string, val
 	return false
 }
 
+// parseQuantity parses the key and the value as resource quantities, numbers are accepted so that
+// they can be compared with quantities given as strings (e.g. 1 and "500m")
 func parseQuantity(key, value interface{}) (parsedKey, parsedValue resource.Quantity, err error) {
-	switch typedKey := key.(type) {
-	case string:
-		parsedKey, err = resource.ParseQuantity(typedKey)
-		if err != nil {
-			return
-		}
-	default:
-		err = fmt.Errorf("key is not a quantity")
+	parsedKey, err = toQuantity(key)
+	if err != nil {
+		err = fmt.Errorf("key is not a quantity: %w", err)
 		return
 	}
-	switch typedValue := value.(type) {
-	case string:
-		parsedValue, err = resource.ParseQuantity(typedValue)
-		if err != nil {
-			return
-		}
-	default:
-		err = fmt.Errorf("value is not a quantity")
+	parsedValue, err = toQuantity(value)
+	if err != nil {
+		err = fmt.Errorf("value is not a quantity: %w", err)
 		return
 	}
 	return
 }
 
+func toQuantity(value interface{}) (resource.Quantity, error) {
+	switch typed := value.(type) {
+	case string:
+		return resource.ParseQuantity(typed)
+	case int:
+		return *resource.NewQuantity(int64(typed), resource.DecimalSI), nil
+	case int64:
+		return *resource.NewQuantity(typed, resource.DecimalSI), nil
+	case float64:
+		return resource.ParseQuantity(strconv.FormatFloat(typed, 'f', -1, 64))
+	default:
+		return resource.Quantity{}, fmt.Errorf("unsupported type %T", value)
+	}
+}
+
 // the following functions are unreachable because the key is strictly supposed to be numeric
 // still the following functions are just created to make NumericOperatorHandler struct implement OperatorHandler interface
 func (noh NumericOperatorHandler) validateValueWithBoolPattern(key bool, value interface{}) bool {
@@ -69,6 +69,10 @@ func CreateOperatorHandler(log logr.Logger, ctx context.EvalInterface, op kyvern
 		log.V(2).Info("DEPRECATED: The Duration* operators have been replaced with the other existing operators that now also support duration values", "operator", str)
 		return NewDurationOperatorHandler(log, ctx, op)
 
+	case strings.ToLower(string(kyvernov1.ConditionOperators["CIDRContains"])),
+		strings.ToLower(string(kyvernov1.ConditionOperators["AnyCIDROverlaps"])):
+		return NewCIDROperatorHandler(log, ctx, op)
+
 	default:
 		log.V(2).Info("operator not supported", "operator", str)
 	}
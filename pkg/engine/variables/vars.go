@@ -265,7 +265,8 @@ func validateElementInForEach(log logr.Logger) jsonUtils.Action {
 
 			variable := replaceBracesAndTrimSpaces(v)
 			isElementVar := strings.HasPrefix(variable, "element") || variable == "elementIndex"
-			if isElementVar && !strings.Contains(data.Path, "/foreach/") {
+			// element variables in JSON patches are validated per operation as operations can declare a foreach
+			if isElementVar && !strings.Contains(data.Path, "/foreach/") && !strings.HasSuffix(data.Path, "/patchesJson6902") {
 				return nil, fmt.Errorf("variable '%v' present outside of foreach at path %s", variable, data.Path)
 			}
 		}
@@ -34,11 +34,12 @@ const (
 type RuleResult string
 
 const (
-	Pass  RuleResult = "pass"
-	Fail  RuleResult = "fail"
-	Warn  RuleResult = "warn"
-	Error RuleResult = "error"
-	Skip  RuleResult = "skip"
+	Pass    RuleResult = "pass"
+	Fail    RuleResult = "fail"
+	Warn    RuleResult = "warn"
+	Error   RuleResult = "error"
+	Skip    RuleResult = "skip"
+	Timeout RuleResult = "timeout"
 )
 
 type RuleExecutionCause string
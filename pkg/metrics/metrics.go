@@ -35,6 +35,8 @@ type MetricsConfig struct {
 	policyResultsMetric           syncint64.Counter
 	policyExecutionDurationMetric syncfloat64.Histogram
 	clientQueriesMetric           syncint64.Counter
+	policyExceptionMatchesMetric  syncint64.Counter
+	shadowPolicyResultsMetric     syncint64.Counter
 
 	// config
 	config kconfig.MetricsConfiguration
@@ -47,6 +49,8 @@ type MetricsConfigManager interface {
 	RecordPolicyChanges(ctx context.Context, policyValidationMode PolicyValidationMode, policyType PolicyType, policyBackgroundMode PolicyBackgroundMode, policyNamespace string, policyName string, policyChangeType string)
 	RecordPolicyExecutionDuration(ctx context.Context, policyValidationMode PolicyValidationMode, policyType PolicyType, policyBackgroundMode PolicyBackgroundMode, policyNamespace string, policyName string, ruleName string, ruleResult RuleResult, ruleType RuleType, ruleExecutionCause RuleExecutionCause, ruleExecutionLatency float64)
 	RecordClientQueries(ctx context.Context, clientQueryOperation ClientQueryOperation, clientType ClientType, resourceKind string, resourceNamespace string)
+	RecordPolicyExceptionMatches(ctx context.Context, exceptionNamespace string, exceptionName string, policyType PolicyType, policyNamespace string, policyName string, ruleName string, resourceKind string, resourceNamespace string, ruleExecutionCause RuleExecutionCause)
+	RecordShadowPolicyResults(ctx context.Context, policyNamespace string, policyName string, shadowOf string, resourceKind string, resourceNamespace string, resourceRequestOperation ResourceRequestOperation, liveResult string, shadowResult string)
 }
 
 func (m *MetricsConfig) Config() kconfig.MetricsConfiguration {
@@ -76,6 +80,16 @@ func (m *MetricsConfig) initializeMetrics(meterProvider metric.MeterProvider) er
 		m.Log.Error(err, "Failed to create instrument, kyverno_client_queries")
 		return err
 	}
+	m.policyExceptionMatchesMetric, err = meter.SyncInt64().Counter("kyverno_policy_exception_matches", instrument.WithDescription("can be used to track the number of times policy exceptions caused policy rules to be skipped"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_policy_exception_matches")
+		return err
+	}
+	m.shadowPolicyResultsMetric, err = meter.SyncInt64().Counter("kyverno_shadow_policy_results", instrument.WithDescription("can be used to track the agreements and disagreements between shadow policies and the live policies they shadow during admission requests"))
+	if err != nil {
+		m.Log.Error(err, "Failed to create instrument, kyverno_shadow_policy_results")
+		return err
+	}
 	return nil
 }
 
@@ -230,3 +244,41 @@ func (m *MetricsConfig) RecordClientQueries(ctx context.Context, clientQueryOper
 	}
 	m.clientQueriesMetric.Add(ctx, 1, commonLabels...)
 }
+
+func (m *MetricsConfig) RecordPolicyExceptionMatches(ctx context.Context, exceptionNamespace string, exceptionName string, policyType PolicyType, policyNamespace string, policyName string,
+	ruleName string, resourceKind string, resourceNamespace string, ruleExecutionCause RuleExecutionCause,
+) {
+	commonLabels := []attribute.KeyValue{
+		attribute.String("exception_namespace", exceptionNamespace),
+		attribute.String("exception_name", exceptionName),
+		attribute.String("policy_type", string(policyType)),
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("rule_name", ruleName),
+		attribute.String("resource_kind", resourceKind),
+		attribute.String("resource_namespace", resourceNamespace),
+		attribute.String("rule_execution_cause", string(ruleExecutionCause)),
+	}
+	m.policyExceptionMatchesMetric.Add(ctx, 1, commonLabels...)
+}
+
+func (m *MetricsConfig) RecordShadowPolicyResults(ctx context.Context, policyNamespace string, policyName string, shadowOf string,
+	resourceKind string, resourceNamespace string, resourceRequestOperation ResourceRequestOperation, liveResult string, shadowResult string,
+) {
+	agreement := "agree"
+	if liveResult != shadowResult {
+		agreement = "disagree"
+	}
+	commonLabels := []attribute.KeyValue{
+		attribute.String("policy_namespace", policyNamespace),
+		attribute.String("policy_name", policyName),
+		attribute.String("shadow_of", shadowOf),
+		attribute.String("resource_kind", resourceKind),
+		attribute.String("resource_namespace", resourceNamespace),
+		attribute.String("resource_request_operation", string(resourceRequestOperation)),
+		attribute.String("live_result", liveResult),
+		attribute.String("shadow_result", shadowResult),
+		attribute.String("agreement", agreement),
+	}
+	m.shadowPolicyResultsMetric.Add(ctx, 1, commonLabels...)
+}
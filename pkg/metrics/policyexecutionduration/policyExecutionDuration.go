@@ -55,6 +55,8 @@ func ProcessEngineResponse(ctx context.Context, m metrics.MetricsConfigManager,
 			ruleResult = metrics.Error
 		case engineapi.RuleStatusSkip:
 			ruleResult = metrics.Skip
+		case engineapi.RuleStatusTimeout:
+			ruleResult = metrics.Timeout
 		default:
 			ruleResult = metrics.Fail
 		}
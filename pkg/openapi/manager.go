@@ -24,6 +24,7 @@ import (
 type ValidateInterface interface {
 	ValidateResource(unstructured.Unstructured, string, string) error
 	ValidatePolicyMutation(kyvernov1.PolicyInterface) error
+	ValidatePolicyVariables(kyvernov1.PolicyInterface) ([]string, error)
 }
 
 type Manager interface {
@@ -0,0 +1,309 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"golang.org/x/exp/slices"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+)
+
+// requestObjectPath matches the fields of the admitted resource referenced by a JMESPath expression,
+// the first group holds the path relative to the resource
+var requestObjectPath = regexp.MustCompile(`request\.(?:object|oldObject)((?:\.[A-Za-z_][A-Za-z0-9_]*|\."[^"]*"|\[[^\]]*\])*)`)
+
+var pathIndex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// fieldType is the type of a field inferred from a schema, an empty type means the type is unknown
+type fieldType string
+
+const (
+	typeString  fieldType = "string"
+	typeInteger fieldType = "integer"
+	typeNumber  fieldType = "number"
+	typeBoolean fieldType = "boolean"
+	typeObject  fieldType = "object"
+	typeArray   fieldType = "array"
+)
+
+// ValidatePolicyVariables checks the fields of the admitted resource referenced by the variables of the policy
+// against the schemas of the matched kinds. Fields not defined in the schemas are reported as warnings,
+// conditions comparing a field with a value of an incompatible type are reported as errors.
+func (o *manager) ValidatePolicyVariables(policy kyvernov1.PolicyInterface) ([]string, error) {
+	var warnings []string
+	for _, rule := range autogen.ComputeRules(policy) {
+		for _, kind := range rule.MatchResources.GetKinds() {
+			schema := o.kindSchema(kind)
+			if schema == nil {
+				continue
+			}
+			ruleWarnings, err := o.validateRuleVariables(rule, kind, schema)
+			for _, warning := range ruleWarnings {
+				if !slices.Contains(warnings, warning) {
+					warnings = append(warnings, warning)
+				}
+			}
+			if err != nil {
+				return warnings, err
+			}
+		}
+	}
+	return warnings, nil
+}
+
+func (o *manager) validateRuleVariables(rule kyvernov1.Rule, kind string, schema *openapiv2.Schema) ([]string, error) {
+	var warnings []string
+	ruleRaw, err := json.Marshal(rule)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range variables.RegexVariables.FindAllStringSubmatch(string(ruleRaw), -1) {
+		// variables are extracted from the JSON encoded rule
+		var variable string
+		if err := json.Unmarshal([]byte(`"`+v[2]+`"`), &variable); err != nil {
+			variable = v[2]
+		}
+		for _, match := range requestObjectPath.FindAllStringSubmatch(variable, -1) {
+			if _, found := o.fieldSchema(schema, match[1]); !found {
+				warnings = append(warnings, fmt.Sprintf("rule %s: field %s is not defined in the schema of %s", rule.Name, match[0], kind))
+			}
+		}
+	}
+	conditions := []apiextensions.JSON{rule.GetAnyAllConditions()}
+	if rule.Validation.Deny != nil {
+		conditions = append(conditions, rule.Validation.Deny.GetAnyAllConditions())
+	}
+	for _, raw := range conditions {
+		if raw == nil {
+			continue
+		}
+		converted, err := apiutils.ApiextensionsJsonToKyvernoConditions(raw)
+		if err != nil {
+			continue
+		}
+		var list []kyvernov1.Condition
+		switch typed := converted.(type) {
+		case kyvernov1.AnyAllConditions:
+			list = append(list, typed.AnyConditions...)
+			list = append(list, typed.AllConditions...)
+		case []kyvernov1.Condition:
+			list = typed
+		}
+		for _, condition := range list {
+			if err := o.validateConditionTypes(condition, kind, schema); err != nil {
+				return warnings, fmt.Errorf("rule %s: %v", rule.Name, err)
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// validateConditionTypes checks that a condition whose key is a field of the admitted resource doesn't compare
+// the field with a value of an incompatible type, conditions that can't be typed statically are ignored
+func (o *manager) validateConditionTypes(condition kyvernov1.Condition, kind string, schema *openapiv2.Schema) error {
+	key, ok := condition.GetKey().(string)
+	if !ok {
+		return nil
+	}
+	variable := strings.TrimSpace(key)
+	if !strings.HasPrefix(variable, "{{") || !strings.HasSuffix(variable, "}}") || strings.Count(variable, "{{") != 1 {
+		return nil
+	}
+	path := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(variable, "{{"), "}}"))
+	match := requestObjectPath.FindStringSubmatch(path)
+	if match == nil || match[0] != path || projected(match[1]) {
+		return nil
+	}
+	fieldSchema, _ := o.fieldSchema(schema, match[1])
+	keyType := o.schemaType(fieldSchema)
+	if keyType == "" {
+		return nil
+	}
+	value := condition.GetValue()
+	if value == nil {
+		return nil
+	}
+	if s, ok := value.(string); ok && strings.Contains(s, "{{") {
+		return nil
+	}
+	if !compatible(condition.Operator, keyType, value) {
+		return fmt.Errorf("%s is of type %s in the schema of %s and can not be compared with %v using %s", path, keyType, kind, value, condition.Operator)
+	}
+	return nil
+}
+
+// compatible returns false if the operator always evaluates to false for a key of the given type and the value
+func compatible(operator kyvernov1.ConditionOperator, keyType fieldType, value interface{}) bool {
+	switch operator {
+	case kyvernov1.ConditionOperators["Equals"], kyvernov1.ConditionOperators["Equal"],
+		kyvernov1.ConditionOperators["NotEquals"], kyvernov1.ConditionOperators["NotEqual"]:
+		switch keyType {
+		case typeBoolean:
+			_, ok := value.(bool)
+			return ok
+		case typeInteger, typeNumber:
+			switch typed := value.(type) {
+			case int64, float64:
+				return true
+			case string:
+				_, err := strconv.ParseFloat(typed, 64)
+				return err == nil
+			}
+			return false
+		case typeString:
+			switch value.(type) {
+			case string, int64, float64:
+				return true
+			}
+			return false
+		case typeObject:
+			_, ok := value.(map[string]interface{})
+			return ok
+		case typeArray:
+			_, ok := value.([]interface{})
+			return ok
+		}
+	case kyvernov1.ConditionOperators["GreaterThanOrEquals"], kyvernov1.ConditionOperators["GreaterThan"],
+		kyvernov1.ConditionOperators["LessThanOrEquals"], kyvernov1.ConditionOperators["LessThan"]:
+		switch keyType {
+		case typeBoolean, typeObject, typeArray:
+			return false
+		}
+		switch value.(type) {
+		case bool, map[string]interface{}, []interface{}:
+			return false
+		}
+	}
+	return true
+}
+
+func (o *manager) kindSchema(kind string) *openapiv2.Schema {
+	if strings.ContainsAny(kind, "*?") {
+		return nil
+	}
+	groupVersion, kind := kubeutils.GetKindFromGVK(kind)
+	kind, subresource := kubeutils.SplitSubresource(kind)
+	if subresource != "" {
+		return nil
+	}
+	if groupVersion != "" {
+		kind = groupVersion + "/" + kind
+	}
+	definitionName, ok := o.gvkToDefinitionName.Get(kind)
+	if !ok {
+		return nil
+	}
+	schema, _ := o.definitions.Get(definitionName)
+	return schema
+}
+
+// fieldSchema resolves the schema of a field path relative to a resource, the schema is nil if the type
+// of the field is unknown and false is returned if the field is not defined in the schema
+func (o *manager) fieldSchema(schema *openapiv2.Schema, path string) (*openapiv2.Schema, bool) {
+	root := true
+	for path != "" && schema != nil {
+		schema = o.resolveRef(schema)
+		if schema == nil || preservesUnknownFields(schema) {
+			return nil, true
+		}
+		switch path[0] {
+		case '.':
+			var field string
+			if strings.HasPrefix(path, `."`) {
+				end := strings.Index(path[2:], `"`) + 2
+				field, path = path[2:end], path[end+1:]
+			} else {
+				end := strings.IndexAny(path[1:], ".[") + 1
+				if end == 0 {
+					end = len(path)
+				}
+				field, path = path[1:end], path[end:]
+			}
+			if root && (field == "apiVersion" || field == "kind") {
+				return &openapiv2.Schema{Type: &openapiv2.TypeItem{Value: []string{string(typeString)}}}, true
+			}
+			next, found := o.property(schema, field)
+			if !found {
+				return nil, false
+			}
+			schema = next
+		case '[':
+			end := strings.Index(path, "]")
+			path = path[end+1:]
+			items := schema.GetItems().GetSchema()
+			if len(items) == 0 {
+				return nil, true
+			}
+			schema = items[0]
+		}
+		root = false
+	}
+	return o.resolveRef(schema), true
+}
+
+func (o *manager) property(schema *openapiv2.Schema, name string) (*openapiv2.Schema, bool) {
+	properties := schema.GetProperties().GetAdditionalProperties()
+	for _, property := range properties {
+		if property.GetName() == name {
+			return property.GetValue(), true
+		}
+	}
+	if additional := schema.GetAdditionalProperties(); additional != nil {
+		return additional.GetSchema(), true
+	}
+	// objects without properties accept any field
+	return nil, len(properties) == 0
+}
+
+func (o *manager) resolveRef(schema *openapiv2.Schema) *openapiv2.Schema {
+	for schema != nil && schema.GetXRef() != "" {
+		schema, _ = o.definitions.Get(strings.TrimPrefix(schema.GetXRef(), "#/definitions/"))
+	}
+	return schema
+}
+
+func (o *manager) schemaType(schema *openapiv2.Schema) fieldType {
+	schema = o.resolveRef(schema)
+	if schema == nil || schema.GetFormat() == "int-or-string" || preservesUnknownFields(schema) {
+		return ""
+	}
+	for _, extension := range schema.GetVendorExtension() {
+		if extension.GetName() == "x-kubernetes-int-or-string" {
+			return ""
+		}
+	}
+	types := schema.GetType().GetValue()
+	if len(types) != 1 {
+		return ""
+	}
+	return fieldType(types[0])
+}
+
+func preservesUnknownFields(schema *openapiv2.Schema) bool {
+	for _, extension := range schema.GetVendorExtension() {
+		if extension.GetName() == "x-kubernetes-preserve-unknown-fields" {
+			return strings.TrimSpace(extension.GetValue().GetYaml()) == "true"
+		}
+	}
+	return false
+}
+
+// projected returns true if the path contains a projection, the result of the expression is then a list
+func projected(path string) bool {
+	for _, segment := range pathIndex.FindAllString(path, -1) {
+		index := strings.TrimSpace(segment[1 : len(segment)-1])
+		if index == "" || strings.ContainsAny(index, "*?:") {
+			return true
+		}
+	}
+	return false
+}
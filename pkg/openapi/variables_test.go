@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	openapiv2 "github.com/google/gnostic/openapiv2"
+	v1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+var podDocument = []byte(`{"swagger":"2.0","info":{"title":"Kubernetes","version":"v1"},"paths":{},"definitions":{
+"io.k8s.api.core.v1.Pod":{"type":"object","properties":{"apiVersion":{"type":"string"},"kind":{"type":"string"},"metadata":{"$ref":"#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"},"spec":{"$ref":"#/definitions/io.k8s.api.core.v1.PodSpec"}}},
+"io.k8s.api.core.v1.PodSpec":{"type":"object","properties":{"hostNetwork":{"type":"boolean"},"priority":{"type":"integer","format":"int32"},"containers":{"type":"array","items":{"$ref":"#/definitions/io.k8s.api.core.v1.Container"}}}},
+"io.k8s.api.core.v1.Container":{"type":"object","properties":{"name":{"type":"string"},"image":{"type":"string"},"ports":{"type":"array","items":{"$ref":"#/definitions/io.k8s.api.core.v1.ContainerPort"}}}},
+"io.k8s.api.core.v1.ContainerPort":{"type":"object","properties":{"containerPort":{"type":"integer","format":"int32"}}},
+"io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta":{"type":"object","properties":{"name":{"type":"string"},"labels":{"type":"object","additionalProperties":{"type":"string"}}}}}}`)
+
+func Test_ValidatePolicyVariables(t *testing.T) {
+	o, err := NewManager()
+	assert.NilError(t, err)
+	doc, err := openapiv2.ParseDocument(podDocument)
+	assert.NilError(t, err)
+	assert.NilError(t, o.UseOpenAPIDocument(doc))
+
+	tcs := []struct {
+		description string
+		policy      []byte
+		warnings    []string
+		err         string
+	}{
+		{
+			description: "known fields",
+			policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"pods","annotations":{"pod-policies.kyverno.io/autogen-controllers":"none"}},"spec":{"rules":[{"name":"check","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"message":"{{ request.object.kind }} {{ request.object.metadata.labels.team }} {{ request.object.metadata.labels.\"app.kubernetes.io/name\" }} {{ request.object.spec.containers[0].ports[].containerPort }}","deny":{"conditions":{"any":[{"key":"{{ request.object.spec.priority }}","operator":"GreaterThan","value":3},{"key":"{{ request.object.spec.hostNetwork }}","operator":"Equals","value":true},{"key":"{{ request.object.spec.containers[*].image }}","operator":"AnyIn","value":["nginx"]}]}}}}]}}`),
+		},
+		{
+			description: "unknown fields",
+			policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"pods","annotations":{"pod-policies.kyverno.io/autogen-controllers":"none"}},"spec":{"rules":[{"name":"check","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"message":"invalid image {{ request.object.spec.contianers[0].image }}","deny":{"conditions":{"any":[{"key":"{{ request.object.spec.containers[0].ports[0].port }}","operator":"Equals","value":22}]}}}}]}}`),
+			warnings: []string{
+				"rule check: field request.object.spec.contianers[0].image is not defined in the schema of Pod",
+				"rule check: field request.object.spec.containers[0].ports[0].port is not defined in the schema of Pod",
+			},
+		},
+		{
+			description: "type mismatch",
+			policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"pods","annotations":{"pod-policies.kyverno.io/autogen-controllers":"none"}},"spec":{"rules":[{"name":"check","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"deny":{"conditions":{"any":[{"key":"{{ request.object.spec.hostNetwork }}","operator":"Equals","value":"true"}]}}}}]}}`),
+			err:         "rule check: request.object.spec.hostNetwork is of type boolean in the schema of Pod and can not be compared with true using Equals",
+		},
+		{
+			description: "numeric operator on an array",
+			policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"pods","annotations":{"pod-policies.kyverno.io/autogen-controllers":"none"}},"spec":{"rules":[{"name":"check","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"preconditions":{"all":[{"key":"{{ request.object.spec.containers }}","operator":"GreaterThan","value":1}]},"validate":{"deny":{}}}]}}`),
+			err:         "rule check: request.object.spec.containers is of type array in the schema of Pod and can not be compared with 1 using GreaterThan",
+		},
+		{
+			description: "unknown kind",
+			policy:      []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"gadgets"},"spec":{"rules":[{"name":"check","match":{"any":[{"resources":{"kinds":["Gadget"]}}]},"validate":{"message":"{{ request.object.spec.imgae }}","deny":{}}}]}}`),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.description, func(t *testing.T) {
+			var policy v1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(tc.policy, &policy))
+			warnings, err := o.ValidatePolicyVariables(&policy)
+			if tc.err != "" {
+				assert.Error(t, err, tc.err)
+			} else {
+				assert.NilError(t, err)
+			}
+			assert.DeepEqual(t, warnings, tc.warnings)
+		})
+	}
+}
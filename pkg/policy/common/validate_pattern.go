@@ -3,8 +3,10 @@ package common
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/kyverno/kyverno/pkg/engine/anchor"
+	"github.com/kyverno/kyverno/pkg/engine/pattern"
 )
 
 // ValidatePattern validates the pattern
@@ -14,7 +16,16 @@ func ValidatePattern(patternElement interface{}, path string, isSupported func(a
 		return validateMap(typedPatternElement, path, isSupported)
 	case []interface{}:
 		return validateArray(typedPatternElement, path, isSupported)
-	case string, float64, int, int64, bool, nil:
+	case string:
+		// patterns with variables are checked once substituted
+		if strings.Contains(typedPatternElement, "{{") {
+			return "", nil
+		}
+		if err := pattern.CheckRegex(typedPatternElement); err != nil {
+			return path, err
+		}
+		return "", nil
+	case float64, int, int64, bool, nil:
 		// TODO: check operator
 		return "", nil
 	default:
@@ -0,0 +1,41 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+var shapes = map[NodeType]string{
+	NodePolicy:    "box",
+	NodeKind:      "ellipse",
+	NodeNamespace: "folder",
+	NodeSelector:  "note",
+	NodeConfigMap: "cylinder",
+	NodeAPICall:   "component",
+	NodeRegistry:  "tab",
+}
+
+// WriteDOT writes the graph in the DOT language, edges are labelled with the relation and the rule name
+func (g *Graph) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph policies {"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "  rankdir=LR;"); err != nil {
+		return err
+	}
+	for _, node := range g.Nodes {
+		label := fmt.Sprintf("%s\n%s", node.Type, node.Name)
+		if _, err := fmt.Fprintf(w, "  %s [label=%s, shape=%s];\n", strconv.Quote(node.ID), strconv.Quote(label), shapes[node.Type]); err != nil {
+			return err
+		}
+	}
+	for _, edge := range g.Edges {
+		label := fmt.Sprintf("%s (%s)", edge.Relation, edge.Rule)
+		if _, err := fmt.Fprintf(w, "  %s -> %s [label=%s];\n", strconv.Quote(edge.From), strconv.Quote(edge.To), strconv.Quote(label)); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
@@ -0,0 +1,303 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeType is the type of a node of the graph
+type NodeType string
+
+const (
+	NodePolicy    NodeType = "policy"
+	NodeKind      NodeType = "kind"
+	NodeNamespace NodeType = "namespace"
+	NodeSelector  NodeType = "selector"
+	NodeConfigMap NodeType = "configmap"
+	NodeAPICall   NodeType = "apicall"
+	NodeRegistry  NodeType = "registry"
+)
+
+// Relation is the relation between a policy and a node
+type Relation string
+
+const (
+	RelationMatches   Relation = "matches"
+	RelationReads     Relation = "reads"
+	RelationGenerates Relation = "generates"
+)
+
+type Node struct {
+	ID   string   `json:"id"`
+	Type NodeType `json:"type"`
+	Name string   `json:"name"`
+}
+
+// Edge links a policy to a node, Rule is the name of the rule the edge comes from
+type Edge struct {
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	Relation Relation `json:"relation"`
+	Rule     string   `json:"rule"`
+}
+
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+	nodes map[string]Node
+}
+
+// Dependencies are the resources read, matched and generated by the rules of a policy. They are found with a
+// static analysis of the rules, values depending on variables are kept unresolved.
+type Dependencies struct {
+	ConfigMaps      []string
+	APICalls        []string
+	ImageRegistries []string
+	Kinds           []string
+	Namespaces      []string
+	Selectors       []string
+	GeneratedKinds  []string
+}
+
+// RuleDependencies returns the dependencies of a rule, ConfigMaps are given as namespace/name
+func RuleDependencies(rule kyvernov1.Rule) Dependencies {
+	var deps Dependencies
+	entries := append([]kyvernov1.ContextEntry{}, rule.Context...)
+	for _, foreach := range rule.Validation.ForEachValidation {
+		entries = append(entries, foreach.Context...)
+	}
+	for _, foreach := range rule.Mutation.ForEachMutation {
+		entries = append(entries, foreach.Context...)
+	}
+	for _, entry := range entries {
+		if entry.ConfigMap != nil {
+			namespace := entry.ConfigMap.Namespace
+			if namespace == "" {
+				namespace = "default"
+			}
+			deps.ConfigMaps = append(deps.ConfigMaps, namespace+"/"+entry.ConfigMap.Name)
+		}
+		if entry.APICall != nil {
+			if entry.APICall.URLPath != "" {
+				deps.APICalls = append(deps.APICalls, entry.APICall.URLPath)
+			}
+			if entry.APICall.Service != nil {
+				deps.APICalls = append(deps.APICalls, entry.APICall.Service.URL)
+			}
+		}
+		if entry.ImageRegistry != nil {
+			deps.ImageRegistries = append(deps.ImageRegistries, registry(entry.ImageRegistry.Reference))
+		}
+	}
+	for _, verification := range rule.VerifyImages {
+		if verification.Image != "" {
+			deps.ImageRegistries = append(deps.ImageRegistries, registry(verification.Image))
+		}
+		for _, reference := range verification.ImageReferences {
+			deps.ImageRegistries = append(deps.ImageRegistries, registry(reference))
+		}
+	}
+	for _, description := range matchedDescriptions(rule.MatchResources) {
+		deps.Kinds = append(deps.Kinds, description.Kinds...)
+		deps.Namespaces = append(deps.Namespaces, description.Namespaces...)
+		if description.Selector != nil {
+			deps.Selectors = append(deps.Selectors, metav1.FormatLabelSelector(description.Selector))
+		}
+		if description.NamespaceSelector != nil {
+			deps.Selectors = append(deps.Selectors, "namespace: "+metav1.FormatLabelSelector(description.NamespaceSelector))
+		}
+	}
+	if rule.HasGenerate() {
+		if rule.Generation.Kind != "" {
+			deps.GeneratedKinds = append(deps.GeneratedKinds, qualifiedKind(rule.Generation.APIVersion, rule.Generation.Kind))
+		}
+		deps.GeneratedKinds = append(deps.GeneratedKinds, rule.Generation.CloneList.Kinds...)
+	}
+	return deps.normalize()
+}
+
+// PolicyDependencies returns the dependencies of the rules of a policy, rules generated for pod controllers included
+func PolicyDependencies(policy kyvernov1.PolicyInterface) Dependencies {
+	var deps Dependencies
+	for _, rule := range autogen.ComputeRules(policy) {
+		ruleDeps := RuleDependencies(rule)
+		deps.ConfigMaps = append(deps.ConfigMaps, ruleDeps.ConfigMaps...)
+		deps.APICalls = append(deps.APICalls, ruleDeps.APICalls...)
+		deps.ImageRegistries = append(deps.ImageRegistries, ruleDeps.ImageRegistries...)
+		deps.Kinds = append(deps.Kinds, ruleDeps.Kinds...)
+		deps.Namespaces = append(deps.Namespaces, ruleDeps.Namespaces...)
+		deps.Selectors = append(deps.Selectors, ruleDeps.Selectors...)
+		deps.GeneratedKinds = append(deps.GeneratedKinds, ruleDeps.GeneratedKinds...)
+	}
+	return deps.normalize()
+}
+
+func (d Dependencies) normalize() Dependencies {
+	return Dependencies{
+		ConfigMaps:      unique(d.ConfigMaps),
+		APICalls:        unique(d.APICalls),
+		ImageRegistries: unique(d.ImageRegistries),
+		Kinds:           unique(d.Kinds),
+		Namespaces:      unique(d.Namespaces),
+		Selectors:       unique(d.Selectors),
+		GeneratedKinds:  unique(d.GeneratedKinds),
+	}
+}
+
+func unique(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool, len(values))
+	var out []string
+	for _, value := range values {
+		if value != "" && !seen[value] {
+			seen[value] = true
+			out = append(out, value)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func matchedDescriptions(match kyvernov1.MatchResources) []kyvernov1.ResourceDescription {
+	descriptions := []kyvernov1.ResourceDescription{match.ResourceDescription}
+	for _, filter := range match.Any {
+		descriptions = append(descriptions, filter.ResourceDescription)
+	}
+	for _, filter := range match.All {
+		descriptions = append(descriptions, filter.ResourceDescription)
+	}
+	return descriptions
+}
+
+func qualifiedKind(apiVersion, kind string) string {
+	if apiVersion == "" {
+		return kind
+	}
+	return apiVersion + "/" + kind
+}
+
+// registry returns the registry of an image reference or pattern, references depending on variables are returned as is
+func registry(reference string) string {
+	if variables.RegexVariables.MatchString(reference) {
+		return reference
+	}
+	first, _, found := strings.Cut(reference, "/")
+	// a wildcard in the first segment can match images of any registry, unless the segment is a registry pattern,
+	// wildcards in the tag or digest of an image without registry don't match other registries
+	if found && strings.ContainsAny(first, "*?") && !strings.ContainsAny(first, ".:") {
+		return "*"
+	}
+	if name, _, _ := strings.Cut(strings.SplitN(first, "@", 2)[0], ":"); !found && strings.ContainsAny(name, "*?") {
+		return "*"
+	}
+	if !found || (!strings.ContainsAny(first, ".:") && first != "localhost") {
+		return "docker.io"
+	}
+	return first
+}
+
+// PolicyName returns the name of a policy, namespaced policies are prefixed with their namespace
+func PolicyName(policy kyvernov1.PolicyInterface) string {
+	if policy.IsNamespaced() {
+		return policy.GetNamespace() + "/" + policy.GetName()
+	}
+	return policy.GetName()
+}
+
+func nodeID(nodeType NodeType, name string) string {
+	return fmt.Sprintf("%s:%s", nodeType, name)
+}
+
+// New builds the graph of policies, nodes and edges are sorted
+func New(policies ...kyvernov1.PolicyInterface) *Graph {
+	g := &Graph{nodes: map[string]Node{}}
+	for _, policy := range policies {
+		from := g.addNode(NodePolicy, PolicyName(policy))
+		for _, rule := range autogen.ComputeRules(policy) {
+			deps := RuleDependencies(rule)
+			link := func(nodeType NodeType, relation Relation, names []string) {
+				for _, name := range names {
+					g.Edges = append(g.Edges, Edge{From: from, To: g.addNode(nodeType, name), Relation: relation, Rule: rule.Name})
+				}
+			}
+			link(NodeKind, RelationMatches, deps.Kinds)
+			link(NodeNamespace, RelationMatches, deps.Namespaces)
+			link(NodeSelector, RelationMatches, deps.Selectors)
+			link(NodeConfigMap, RelationReads, deps.ConfigMaps)
+			link(NodeAPICall, RelationReads, deps.APICalls)
+			link(NodeRegistry, RelationReads, deps.ImageRegistries)
+			link(NodeKind, RelationGenerates, deps.GeneratedKinds)
+		}
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		if g.Edges[i].Rule != g.Edges[j].Rule {
+			return g.Edges[i].Rule < g.Edges[j].Rule
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	return g
+}
+
+func (g *Graph) addNode(nodeType NodeType, name string) string {
+	id := nodeID(nodeType, name)
+	if _, ok := g.nodes[id]; !ok {
+		node := Node{ID: id, Type: nodeType, Name: name}
+		g.nodes[id] = node
+		g.Nodes = append(g.Nodes, node)
+	}
+	return id
+}
+
+// Lookup returns the edges leading to the nodes of the given type matching name. Node names are used as patterns,
+// wildcards and variables they contain match any value. Kinds are compared without their group and version.
+func (g *Graph) Lookup(nodeType NodeType, name string, relations ...Relation) []Edge {
+	var edges []Edge
+	for _, edge := range g.Edges {
+		node := g.nodes[edge.To]
+		if node.Type != nodeType {
+			continue
+		}
+		if len(relations) > 0 && !containsRelation(relations, edge.Relation) {
+			continue
+		}
+		if matches(nodeType, node.Name, name) {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+func containsRelation(relations []Relation, relation Relation) bool {
+	for _, r := range relations {
+		if r == relation {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(nodeType NodeType, pattern, name string) bool {
+	if nodeType == NodeKind {
+		pattern, name = kindName(pattern), kindName(name)
+	}
+	pattern = variables.RegexVariables.ReplaceAllString(pattern, "${1}*")
+	return wildcard.Match(pattern, name)
+}
+
+// kindName returns the kind of a kind given as group/version/kind
+func kindName(kind string) string {
+	return kind[strings.LastIndex(kind, "/")+1:]
+}
@@ -0,0 +1,235 @@
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
+	"gotest.tools/assert"
+)
+
+const policies = `
+apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: check-registries
+spec:
+  rules:
+  - name: allowed-registries
+    match:
+      any:
+      - resources:
+          kinds: [Pod]
+          namespaces: ["team-*"]
+    exclude:
+      any:
+      - resources:
+          namespaces: [team-infra]
+    context:
+    - name: settings
+      configMap:
+        name: registries
+        namespace: kyverno
+    - name: image
+      imageRegistry:
+        reference: "{{ request.object.spec.containers[0].image }}"
+    validate:
+      deny: {}
+  - name: verify
+    match:
+      any:
+      - resources:
+          kinds: [Pod]
+    verifyImages:
+    - imageReferences:
+      - ghcr.io/kyverno/*
+      - nginx:*
+      attestors: []
+---
+apiVersion: kyverno.io/v1
+kind: Policy
+metadata:
+  name: quota
+  namespace: apps
+spec:
+  rules:
+  - name: generate-quota
+    match:
+      any:
+      - resources:
+          kinds: [Namespace]
+          selector:
+            matchLabels:
+              quota: "true"
+    context:
+    - name: pods
+      apiCall:
+        urlPath: "/api/v1/namespaces/{{ request.namespace }}/pods"
+        jmesPath: length(items)
+    - name: defaults
+      configMap:
+        name: "quota-{{ request.object.metadata.name }}"
+    generate:
+      apiVersion: v1
+      kind: ResourceQuota
+      name: default
+      namespace: "{{ request.object.metadata.name }}"
+      data: {}
+  - name: jobs
+    match:
+      all:
+      - resources:
+          kinds: [batch/v1/Job]
+      - resources:
+          namespaces: [apps]
+    mutate:
+      patchStrategicMerge:
+        metadata:
+          labels:
+            team: apps
+`
+
+func loadPolicies(t *testing.T) []kyvernov1.PolicyInterface {
+	loaded, err := yamlutils.GetPolicy([]byte(policies))
+	assert.NilError(t, err)
+	return loaded
+}
+
+func Test_PolicyDependencies(t *testing.T) {
+	loaded := loadPolicies(t)
+	deps := PolicyDependencies(loaded[0])
+	assert.DeepEqual(t, deps.ConfigMaps, []string{"kyverno/registries"})
+	// variables of autogen rules are updated to the paths of pod controllers
+	assert.DeepEqual(t, deps.ImageRegistries, []string{
+		"docker.io",
+		"ghcr.io",
+		"{{ request.object.spec.containers[0].image }}",
+		"{{ request.object.spec.jobTemplate.spec.template.spec.containers[0].image }}",
+		"{{ request.object.spec.template.spec.containers[0].image }}",
+	})
+	assert.DeepEqual(t, deps.Namespaces, []string{"team-*"})
+	assert.Assert(t, deps.APICalls == nil)
+	// autogen rules match pod controllers
+	assert.Assert(t, len(deps.Kinds) > 1)
+	assert.Equal(t, deps.Kinds[0], "CronJob")
+
+	deps = PolicyDependencies(loaded[1])
+	assert.DeepEqual(t, deps.ConfigMaps, []string{"default/quota-{{ request.object.metadata.name }}"})
+	assert.DeepEqual(t, deps.APICalls, []string{"/api/v1/namespaces/{{ request.namespace }}/pods"})
+	assert.DeepEqual(t, deps.Kinds, []string{"Namespace", "batch/v1/Job"})
+	assert.DeepEqual(t, deps.Namespaces, []string{"apps"})
+	assert.DeepEqual(t, deps.Selectors, []string{"quota=true"})
+	assert.DeepEqual(t, deps.GeneratedKinds, []string{"v1/ResourceQuota"})
+}
+
+func refs(edges []Edge) []string {
+	var out []string
+	for _, edge := range edges {
+		out = append(out, edge.From+" "+edge.Rule)
+	}
+	return out
+}
+
+func Test_Lookup(t *testing.T) {
+	g := New(loadPolicies(t)...)
+	assert.DeepEqual(t, refs(g.Lookup(NodeConfigMap, "kyverno/registries")), []string{
+		"policy:check-registries allowed-registries",
+		"policy:check-registries autogen-allowed-registries",
+		"policy:check-registries autogen-cronjob-allowed-registries",
+	})
+	assert.DeepEqual(t, refs(g.Lookup(NodeConfigMap, "default/quota-team-a")), []string{"policy:apps/quota generate-quota"})
+	assert.Assert(t, g.Lookup(NodeConfigMap, "kube-system/registries") == nil)
+	assert.DeepEqual(t, refs(g.Lookup(NodeAPICall, "/api/v1/namespaces/apps/pods")), []string{"policy:apps/quota generate-quota"})
+	// references depending on variables can point to any registry
+	assert.DeepEqual(t, refs(g.Lookup(NodeRegistry, "ghcr.io")), []string{
+		"policy:check-registries allowed-registries",
+		"policy:check-registries autogen-allowed-registries",
+		"policy:check-registries autogen-cronjob-allowed-registries",
+		"policy:check-registries autogen-cronjob-verify",
+		"policy:check-registries autogen-verify",
+		"policy:check-registries verify",
+	})
+	assert.DeepEqual(t, refs(g.Lookup(NodeKind, "v1/ResourceQuota", RelationGenerates)), []string{"policy:apps/quota generate-quota"})
+	assert.Assert(t, g.Lookup(NodeKind, "ResourceQuota", RelationMatches) == nil)
+}
+
+func Test_MatchingRules(t *testing.T) {
+	loaded := loadPolicies(t)
+	testCases := []struct {
+		kind      string
+		namespace string
+		expected  []RuleRef
+	}{
+		{
+			kind:      "Pod",
+			namespace: "team-a",
+			expected: []RuleRef{
+				{Policy: "check-registries", Rule: "allowed-registries"},
+				{Policy: "check-registries", Rule: "verify"},
+			},
+		},
+		{
+			kind:      "Pod",
+			namespace: "team-infra",
+			expected: []RuleRef{
+				{Policy: "check-registries", Rule: "verify"},
+			},
+		},
+		{
+			kind:      "Job",
+			namespace: "apps",
+			expected: []RuleRef{
+				{Policy: "check-registries", Rule: "autogen-verify"},
+				{Policy: "apps/quota", Rule: "jobs"},
+			},
+		},
+		{
+			kind:      "Job",
+			namespace: "default",
+			expected: []RuleRef{
+				{Policy: "check-registries", Rule: "autogen-verify"},
+			},
+		},
+		{
+			kind: "Namespace",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.kind+"/"+tc.namespace, func(t *testing.T) {
+			assert.DeepEqual(t, MatchingRules(tc.kind, tc.namespace, loaded...), tc.expected)
+		})
+	}
+}
+
+func Test_WriteDOT(t *testing.T) {
+	g := New(loadPolicies(t)[1])
+	var out bytes.Buffer
+	assert.NilError(t, g.WriteDOT(&out))
+	dot := out.String()
+	assert.Assert(t, strings.HasPrefix(dot, "digraph policies {\n"))
+	assert.Assert(t, strings.Contains(dot, `  "policy:apps/quota" [label="policy\napps/quota", shape=box];`))
+	assert.Assert(t, strings.Contains(dot, `  "policy:apps/quota" -> "kind:v1/ResourceQuota" [label="generates (generate-quota)"];`))
+	assert.Assert(t, strings.HasSuffix(dot, "}\n"))
+}
+
+func Test_registry(t *testing.T) {
+	testCases := map[string]string{
+		"nginx":                           "docker.io",
+		"library/nginx:1.23":              "docker.io",
+		"ghcr.io/kyverno/kyverno":         "ghcr.io",
+		"localhost/app":                   "localhost",
+		"registry:5000/app":               "registry:5000",
+		"*":                               "*",
+		"*.azurecr.io/*":                  "*.azurecr.io",
+		"*/nginx":                         "*",
+		"team-*/app":                      "*",
+		"ngin?":                           "*",
+		"nginx:*":                         "docker.io",
+		"{{ request.object.spec.image }}": "{{ request.object.spec.image }}",
+	}
+	for reference, expected := range testCases {
+		assert.Equal(t, registry(reference), expected, reference)
+	}
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"reflect"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+)
+
+// RuleRef identifies a rule of a policy
+type RuleRef struct {
+	Policy string `json:"policy"`
+	Rule   string `json:"rule"`
+}
+
+// MatchingRules returns the rules matching resources of a kind in a namespace, the namespace is empty for cluster
+// wide resources. Only kinds and namespaces are evaluated, filters on names, selectors or user information are
+// considered matching and exclude blocks using them are ignored.
+func MatchingRules(kind, namespace string, policies ...kyvernov1.PolicyInterface) []RuleRef {
+	var refs []RuleRef
+	for _, policy := range policies {
+		if policy.IsNamespaced() && policy.GetNamespace() != namespace {
+			continue
+		}
+		for _, rule := range autogen.ComputeRules(policy) {
+			if matchesResource(rule.MatchResources, kind, namespace) && !excludesResource(rule.ExcludeResources, kind, namespace) {
+				refs = append(refs, RuleRef{Policy: PolicyName(policy), Rule: rule.Name})
+			}
+		}
+	}
+	return refs
+}
+
+func matchesResource(match kyvernov1.MatchResources, kind, namespace string) bool {
+	if len(match.Any) > 0 {
+		for _, filter := range match.Any {
+			if acceptsResource(filter.ResourceDescription, kind, namespace, true) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(match.All) > 0 {
+		for _, filter := range match.All {
+			if !acceptsResource(filter.ResourceDescription, kind, namespace, false) {
+				return false
+			}
+		}
+		return true
+	}
+	return acceptsResource(match.ResourceDescription, kind, namespace, true)
+}
+
+func excludesResource(exclude kyvernov1.MatchResources, kind, namespace string) bool {
+	descriptions := []kyvernov1.ResourceDescription{exclude.ResourceDescription}
+	userInfos := []kyvernov1.UserInfo{exclude.UserInfo}
+	for _, filter := range exclude.Any {
+		descriptions = append(descriptions, filter.ResourceDescription)
+		userInfos = append(userInfos, filter.UserInfo)
+	}
+	for i, description := range descriptions {
+		if len(description.Kinds) == 0 && len(description.Namespaces) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(userInfos[i], kyvernov1.UserInfo{}) {
+			continue
+		}
+		if description.Name != "" || len(description.Names) > 0 || description.Selector != nil ||
+			description.NamespaceSelector != nil || len(description.Annotations) > 0 {
+			continue
+		}
+		if acceptsResource(description, kind, namespace, false) {
+			return true
+		}
+	}
+	return false
+}
+
+func acceptsResource(description kyvernov1.ResourceDescription, kind, namespace string, requireKinds bool) bool {
+	if len(description.Kinds) == 0 {
+		if requireKinds {
+			return false
+		}
+	} else {
+		found := false
+		for _, pattern := range description.Kinds {
+			if wildcard.Match(kindName(pattern), kindName(kind)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(description.Namespaces) == 0 {
+		return true
+	}
+	for _, pattern := range description.Namespaces {
+		if wildcard.Match(pattern, namespace) {
+			return true
+		}
+	}
+	return false
+}
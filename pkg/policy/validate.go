@@ -19,6 +19,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	openapicontroller "github.com/kyverno/kyverno/pkg/controllers/openapi"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	enginejmespath "github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/openapi"
@@ -111,6 +112,58 @@ func validateJSONPatch(patch string, ruleIdx int) error {
 	return nil
 }
 
+// validateJSONPatchOperations checks the if and foreach fields of JSON patch operations, element
+// variables can only be used in operations declaring a foreach
+func validateJSONPatchOperations(patch string, ruleIdx int) error {
+	if patch == "" {
+		return nil
+	}
+	patch = variables.ReplaceAllVars(patch, func(s string) string {
+		if isElementVariable(s) {
+			return elementPlaceholder
+		}
+		return "kyvernojsonpatchvariable"
+	})
+	jsonPatch, err := yaml.ToJSON([]byte(patch))
+	if err != nil {
+		return err
+	}
+	var operations []map[string]interface{}
+	if err := json.Unmarshal(jsonPatch, &operations); err != nil {
+		return err
+	}
+	for i, operation := range operations {
+		for _, field := range []string{"if", "foreach"} {
+			if value, ok := operation[field]; ok {
+				if _, ok := value.(string); !ok {
+					return fmt.Errorf("%s must be a JMESPath expression: spec.rules[%d].mutate.patchesJson6902[%d]", field, ruleIdx, i)
+				}
+			}
+		}
+		if _, ok := operation["foreach"]; ok {
+			continue
+		}
+		data, err := json.Marshal(operation)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(string(data), elementPlaceholder) {
+			return fmt.Errorf("element variables can only be used in operations declaring a foreach: spec.rules[%d].mutate.patchesJson6902[%d]", ruleIdx, i)
+		}
+	}
+	return nil
+}
+
+const elementPlaceholder = "kyvernojsonpatchelement"
+
+func isElementVariable(variable string) bool {
+	if i := strings.Index(variable, "{{"); i >= 0 {
+		variable = variable[i+2:]
+	}
+	variable = strings.TrimSpace(strings.TrimSuffix(variable, "}}"))
+	return strings.HasPrefix(variable, "element")
+}
+
 func checkValidationFailureAction(spec *kyvernov1.Spec) []string {
 	msg := "Validation failure actions enforce/audit are deprecated, use Enforce/Audit instead."
 	if spec.ValidationFailureAction == "enforce" || spec.ValidationFailureAction == "audit" {
@@ -139,6 +192,10 @@ func Validate(policy kyvernov1.PolicyInterface, client dclient.Interface, mock b
 	var errs field.ErrorList
 	specPath := field.NewPath("spec")
 
+	if err := validateFunctions(policy); err != nil {
+		return warnings, err
+	}
+
 	err := ValidateVariables(policy, background)
 	if err != nil {
 		return warnings, err
@@ -197,6 +254,9 @@ func Validate(policy kyvernov1.PolicyInterface, client dclient.Interface, mock b
 		if err := validateJSONPatch(rule.Mutation.PatchesJSON6902, i); err != nil {
 			return warnings, fmt.Errorf("%s", err)
 		}
+		if err := validateJSONPatchOperations(rule.Mutation.PatchesJSON6902, i); err != nil {
+			return warnings, err
+		}
 
 		if jsonPatchOnPod(rule) {
 			msg := "Pods managed by workload controllers should not be directly mutated using policies. " +
@@ -426,6 +486,11 @@ func Validate(policy kyvernov1.PolicyInterface, client dclient.Interface, mock b
 		if err := openApiManager.ValidatePolicyMutation(policy); err != nil {
 			return warnings, err
 		}
+		variableWarnings, err := openApiManager.ValidatePolicyVariables(policy)
+		warnings = append(warnings, variableWarnings...)
+		if err != nil {
+			return warnings, err
+		}
 	}
 	return warnings, nil
 }
@@ -479,6 +544,33 @@ func ValidateVariables(p kyvernov1.PolicyInterface, backgroundMode bool) error {
 	return nil
 }
 
+// validateFunctions checks the functions defined by the policy and the calls to these functions in the policy variables
+func validateFunctions(policy kyvernov1.PolicyInterface) error {
+	functions := policy.GetSpec().Functions
+	if len(functions) == 0 {
+		return nil
+	}
+	userFunctions := make([]enginejmespath.UserFunction, 0, len(functions))
+	for _, function := range functions {
+		userFunctions = append(userFunctions, enginejmespath.UserFunction(function))
+	}
+	if _, err := enginejmespath.NewUserFunctions(userFunctions...); err != nil {
+		return fmt.Errorf("invalid functions: spec.functions: %v", err)
+	}
+	for _, v := range hasVariables(policy) {
+		// variables are extracted from the JSON encoded policy
+		var variable string
+		if err := json.Unmarshal([]byte(`"`+v[2]+`"`), &variable); err != nil {
+			variable = v[2]
+		}
+		expression := strings.TrimSuffix(strings.TrimPrefix(variable, "{{"), "}}")
+		if err := enginejmespath.CheckUserFunctionCalls(expression, userFunctions...); err != nil {
+			return fmt.Errorf("invalid variable %s: %v", variable, err)
+		}
+	}
+	return nil
+}
+
 // hasInvalidVariables - checks for unexpected variables in the policy
 func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) error {
 	for _, r := range autogen.ComputeRules(policy) {
@@ -573,7 +665,11 @@ func jsonPatchPathHasVariables(patch string) error {
 		}
 
 		vars := variables.RegexVariables.FindAllString(path, -1)
-		if len(vars) > 0 {
+		for _, v := range vars {
+			// operations generated by a foreach can reference the element in their path
+			if _, ok := operation["foreach"]; ok && isElementVariable(v) {
+				continue
+			}
 			return errOperationForbidden
 		}
 	}
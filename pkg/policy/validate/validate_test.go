@@ -379,3 +379,27 @@ func Test_Validate_Validate_Unsupported(t *testing.T) {
 	}
 
 }
+
+func Test_Validate_Validate_InvalidRegex(t *testing.T) {
+	rawValidate := []byte(`
+	{
+		"message": "Images must be pulled from the corporate registry.",
+		"pattern": {
+		   "spec": {
+			  "containers": [
+				 {
+					"image": "~=^registry\\.corp/(app"
+				 }
+			  ]
+		   }
+		}
+	 }`)
+
+	var validate kyverno.Validation
+	err := json.Unmarshal(rawValidate, &validate)
+	assert.NilError(t, err)
+	checker := NewValidateFactory(&validate)
+	path, err := checker.Validate()
+	assert.ErrorContains(t, err, "invalid regular expression")
+	assert.Equal(t, path, "pattern.//spec/containers0//image")
+}
@@ -1719,6 +1719,59 @@ func Test_ValidateJSON6902(t *testing.T) {
 	assert.NilError(t, err)
 }
 
+func Test_validateJSONPatchOperations(t *testing.T) {
+	patch := `- op: add
+  path: /metadata/annotations/{{ element.name }}
+  value: "{{ elementIndex }}"
+  foreach: request.object.spec.containers
+  if: "element.name != 'sidecar'"`
+	assert.NilError(t, validateJSONPatchOperations(patch, 0))
+	assert.NilError(t, jsonPatchPathHasVariables(patch))
+
+	patch = `- op: add
+  path: /metadata/labels/app
+  value: "{{ element.name }}"`
+	err := validateJSONPatchOperations(patch, 0)
+	assert.Error(t, err, "element variables can only be used in operations declaring a foreach: spec.rules[0].mutate.patchesJson6902[0]")
+
+	patch = `- op: add
+  path: /metadata/labels/app
+  value: "nginx"
+  if: true`
+	err = validateJSONPatchOperations(patch, 1)
+	assert.Error(t, err, "if must be a JMESPath expression: spec.rules[1].mutate.patchesJson6902[0]")
+
+	patch = `- op: add
+  path: /metadata/labels/{{ request.object.metadata.name }}
+  value: "nginx"
+  foreach: request.object.spec.containers`
+	assert.Error(t, jsonPatchPathHasVariables(patch), errOperationForbidden.Error())
+}
+
+func Test_validateFunctions(t *testing.T) {
+	newPolicy := func(functions string, value string) *kyverno.ClusterPolicy {
+		var policy kyverno.ClusterPolicy
+		raw := `{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"functions"},"spec":{"functions":` + functions + `,"rules":[{"name":"check","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"deny":{"conditions":{"any":[{"key":"` + value + `","operator":"Equals","value":false}]}}}}]}}`
+		assert.NilError(t, json.Unmarshal([]byte(raw), &policy))
+		return &policy
+	}
+	functions := `[{"name":"registry","parameters":["image"],"expression":"split(image, '/')[0]"}]`
+	assert.NilError(t, validateFunctions(newPolicy(functions, `{{ registry(request.object.spec.containers[0].image) }}`)))
+	assert.NilError(t, validateFunctions(newPolicy(functions, `{{ registry(\"request\".object.metadata.name) }}`)))
+
+	err := validateFunctions(newPolicy(functions, `{{ registry(request.object.metadata.name, 'docker.io') }}`))
+	assert.Error(t, err, "invalid variable {{ registry(request.object.metadata.name, 'docker.io') }}: function registry expects 1 arguments, 2 given")
+
+	err = validateFunctions(newPolicy(`[{"name":"to_upper","expression":"@"}]`, `{{ request.object.metadata.name }}`))
+	assert.Error(t, err, "invalid functions: spec.functions: function to_upper conflicts with a built-in function")
+
+	err = validateFunctions(newPolicy(`[{"name":"f","parameters":["x"],"expression":"g(x)"},{"name":"g","parameters":["x"],"expression":"f(x)"}]`, `{{ f(request.object) }}`))
+	assert.Error(t, err, "invalid functions: spec.functions: function f is recursive: f -> g -> f")
+
+	err = validateFunctions(newPolicy(`[{"name":"pods","parameters":["ns"],"expression":"list('v1', 'Pod', ns, null)"}]`, `{{ pods(request.namespace) }}`))
+	assert.Error(t, err, "invalid functions: spec.functions: function pods calls list, lookup functions can only be called from the variables of the policy rules")
+}
+
 func Test_ValidateNamespace(t *testing.T) {
 	testcases := []struct {
 		description   string
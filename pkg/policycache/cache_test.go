@@ -1050,6 +1050,20 @@ func Test_Add_Validate_Enforce(t *testing.T) {
 	}
 }
 
+func Test_Add_Validate_Shadow(t *testing.T) {
+	pCache := newPolicyCache()
+	policy := newValidateEnforcePolicy(t)
+	policy.SetName("check-label-app-shadow")
+	policy.Spec.ShadowOf = "check-label-app-enforce"
+	setPolicy(pCache, policy)
+	assert.Equal(t, len(pCache.get(ValidateShadow, "Pod", "")), 1)
+	// shadow policies never block nor are audited as regular policies
+	assert.Equal(t, len(pCache.get(ValidateEnforce, "Pod", "")), 0)
+	assert.Equal(t, len(pCache.get(ValidateAudit, "Pod", "")), 0)
+	unsetPolicy(pCache, policy)
+	assert.Equal(t, len(pCache.get(ValidateShadow, "Pod", "")), 0)
+}
+
 func Test_Ns_Add_Remove_User(t *testing.T) {
 	pCache := newPolicyCache()
 	policy := newUserTestPolicy(t)
@@ -95,6 +95,8 @@ func set(set sets.Set[string], item string, value bool) sets.Set[string] {
 
 func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, subresourceGVKToKind map[string]string) {
 	enforcePolicy := computeEnforcePolicy(policy.GetSpec())
+	// shadow policies are only evaluated alongside their live policy and never block
+	shadowPolicy := policy.GetSpec().IsShadow()
 	m.policies[key] = policy
 	type state struct {
 		hasMutate, hasValidate, hasGenerate, hasVerifyImages, hasImagesValidationChecks, hasVerifyYAML bool
@@ -125,15 +127,17 @@ func (m *policyMap) set(key string, policy kyvernov1.PolicyInterface, subresourc
 				VerifyImagesMutate:   sets.New[string](),
 				VerifyImagesValidate: sets.New[string](),
 				VerifyYAML:           sets.New[string](),
+				ValidateShadow:       sets.New[string](),
 			}
 		}
-		m.kindType[kind][Mutate] = set(m.kindType[kind][Mutate], key, state.hasMutate)
-		m.kindType[kind][ValidateEnforce] = set(m.kindType[kind][ValidateEnforce], key, state.hasValidate && enforcePolicy)
-		m.kindType[kind][ValidateAudit] = set(m.kindType[kind][ValidateAudit], key, state.hasValidate && !enforcePolicy)
-		m.kindType[kind][Generate] = set(m.kindType[kind][Generate], key, state.hasGenerate)
-		m.kindType[kind][VerifyImagesMutate] = set(m.kindType[kind][VerifyImagesMutate], key, state.hasVerifyImages)
-		m.kindType[kind][VerifyImagesValidate] = set(m.kindType[kind][VerifyImagesValidate], key, state.hasVerifyImages && state.hasImagesValidationChecks)
-		m.kindType[kind][VerifyYAML] = set(m.kindType[kind][VerifyYAML], key, state.hasVerifyYAML)
+		m.kindType[kind][Mutate] = set(m.kindType[kind][Mutate], key, state.hasMutate && !shadowPolicy)
+		m.kindType[kind][ValidateEnforce] = set(m.kindType[kind][ValidateEnforce], key, state.hasValidate && enforcePolicy && !shadowPolicy)
+		m.kindType[kind][ValidateAudit] = set(m.kindType[kind][ValidateAudit], key, state.hasValidate && !enforcePolicy && !shadowPolicy)
+		m.kindType[kind][Generate] = set(m.kindType[kind][Generate], key, state.hasGenerate && !shadowPolicy)
+		m.kindType[kind][VerifyImagesMutate] = set(m.kindType[kind][VerifyImagesMutate], key, state.hasVerifyImages && !shadowPolicy)
+		m.kindType[kind][VerifyImagesValidate] = set(m.kindType[kind][VerifyImagesValidate], key, state.hasVerifyImages && state.hasImagesValidationChecks && !shadowPolicy)
+		m.kindType[kind][VerifyYAML] = set(m.kindType[kind][VerifyYAML], key, state.hasVerifyYAML && !shadowPolicy)
+		m.kindType[kind][ValidateShadow] = set(m.kindType[kind][ValidateShadow], key, state.hasValidate && shadowPolicy)
 	}
 }
 
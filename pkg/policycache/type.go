@@ -12,4 +12,5 @@ const (
 	VerifyImagesMutate
 	VerifyImagesValidate
 	VerifyYAML
+	ValidateShadow
 )
@@ -0,0 +1,242 @@
+package sinks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultBatchSize     = 100
+	defaultFlushInterval = 5 * time.Second
+	defaultQueueSize     = 10000
+	defaultMaxRetries    = 5
+	defaultTimeout       = 10 * time.Second
+	defaultBlockTimeout  = time.Second
+)
+
+// Backpressure defines what happens when a sink queue is full
+type Backpressure string
+
+const (
+	// BackpressureDrop drops records when the sink queue is full
+	BackpressureDrop Backpressure = "Drop"
+	// BackpressureBlock waits for the sink queue to accept records, at most for the block timeout
+	BackpressureBlock Backpressure = "Block"
+)
+
+// Configuration is the result sinks configuration
+type Configuration struct {
+	// Sinks is the list of sinks results are sent to
+	Sinks []SinkConfiguration `json:"sinks,omitempty"`
+}
+
+// SinkConfiguration configures a single sink, exactly one of webhook, kafka, otlp or file must be set
+type SinkConfiguration struct {
+	// Name identifies the sink in logs and metrics
+	Name string `json:"name"`
+	// Webhook sends batches of records to an http endpoint
+	Webhook *WebhookConfiguration `json:"webhook,omitempty"`
+	// Kafka produces batches of records to a topic through a Kafka REST proxy
+	Kafka *KafkaConfiguration `json:"kafka,omitempty"`
+	// OTLP sends batches of records to an OTLP logs collector
+	OTLP *OTLPConfiguration `json:"otlp,omitempty"`
+	// File writes records as json lines to a file or stdout
+	File *FileConfiguration `json:"file,omitempty"`
+	// Filter selects the records sent to the sink
+	Filter Filter `json:"filter,omitempty"`
+	// BatchSize is the maximum number of records sent at once
+	BatchSize int `json:"batchSize,omitempty"`
+	// FlushInterval is the maximum time records are kept before being sent
+	FlushInterval metav1.Duration `json:"flushInterval,omitempty"`
+	// QueueSize is the maximum number of records waiting to be sent
+	QueueSize int `json:"queueSize,omitempty"`
+	// MaxRetries is the number of times a batch is retried before being dropped
+	MaxRetries *int `json:"maxRetries,omitempty"`
+	// Backpressure defines what happens when the queue is full, Drop (default) or Block
+	Backpressure Backpressure `json:"backpressure,omitempty"`
+	// BlockTimeout is the maximum time a record waits for the queue when backpressure is Block, the record
+	// is dropped after it. It bounds the latency added to admission requests by a slow sink (default 1s).
+	BlockTimeout metav1.Duration `json:"blockTimeout,omitempty"`
+}
+
+// WebhookConfiguration configures an http sink, records are posted as a json array
+type WebhookConfiguration struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Timeout metav1.Duration   `json:"timeout,omitempty"`
+}
+
+// KafkaConfiguration configures a sink producing records to a topic with the Kafka REST proxy v2 API,
+// records are keyed by policy
+type KafkaConfiguration struct {
+	// URL is the base URL of the REST proxy
+	URL     string            `json:"url"`
+	Topic   string            `json:"topic"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Timeout metav1.Duration   `json:"timeout,omitempty"`
+}
+
+// OTLPConfiguration configures an OTLP logs sink using gRPC
+type OTLPConfiguration struct {
+	Endpoint string            `json:"endpoint"`
+	Insecure bool              `json:"insecure,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Timeout  metav1.Duration   `json:"timeout,omitempty"`
+}
+
+// FileConfiguration configures a json lines sink, an empty path or "-" writes to stdout
+type FileConfiguration struct {
+	Path string `json:"path,omitempty"`
+}
+
+// Filter selects records by policy, severity and result, empty lists match everything
+type Filter struct {
+	// Policies are policy keys (namespace/name for namespaced policies), wildcards are supported
+	Policies []string `json:"policies,omitempty"`
+	// Severities are policy severities (critical, high, medium, low, info)
+	Severities []string `json:"severities,omitempty"`
+	// Results are rule results (pass, fail, warn, error, skip)
+	Results []string `json:"results,omitempty"`
+}
+
+// Matches returns true if the record is selected by the filter
+func (f Filter) Matches(record Record) bool {
+	if len(f.Policies) != 0 && !wildcard.CheckPatterns(f.Policies, record.Policy) {
+		return false
+	}
+	if len(f.Severities) != 0 && !contains(f.Severities, record.Severity) {
+		return false
+	}
+	if len(f.Results) != 0 && !contains(f.Results, record.Result) {
+		return false
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadConfiguration reads a sinks configuration from a yaml or json file
+func LoadConfiguration(path string) (*Configuration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Configuration
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse result sinks configuration: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate checks the configuration is valid
+func (c Configuration) Validate() error {
+	names := map[string]bool{}
+	for i, sink := range c.Sinks {
+		if sink.Name == "" {
+			return fmt.Errorf("sinks[%d]: name is required", i)
+		}
+		if names[sink.Name] {
+			return fmt.Errorf("sinks[%d]: duplicate sink name %s", i, sink.Name)
+		}
+		names[sink.Name] = true
+		if err := sink.validate(); err != nil {
+			return fmt.Errorf("sinks[%d] (%s): %w", i, sink.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c SinkConfiguration) validate() error {
+	count := 0
+	if c.Webhook != nil {
+		count++
+		if c.Webhook.URL == "" {
+			return errors.New("webhook url is required")
+		}
+	}
+	if c.Kafka != nil {
+		count++
+		if c.Kafka.URL == "" || c.Kafka.Topic == "" {
+			return errors.New("kafka url and topic are required")
+		}
+	}
+	if c.OTLP != nil {
+		count++
+		if c.OTLP.Endpoint == "" {
+			return errors.New("otlp endpoint is required")
+		}
+	}
+	if c.File != nil {
+		count++
+	}
+	if count != 1 {
+		return errors.New("exactly one of webhook, kafka, otlp or file must be set")
+	}
+	switch c.Backpressure {
+	case "", BackpressureDrop, BackpressureBlock:
+	default:
+		return fmt.Errorf("invalid backpressure %s, must be %s or %s", c.Backpressure, BackpressureDrop, BackpressureBlock)
+	}
+	if c.BatchSize < 0 || c.QueueSize < 0 || c.FlushInterval.Duration < 0 || c.BlockTimeout.Duration < 0 || (c.MaxRetries != nil && *c.MaxRetries < 0) {
+		return errors.New("batchSize, queueSize, flushInterval, blockTimeout and maxRetries must not be negative")
+	}
+	return nil
+}
+
+func (c SinkConfiguration) batchSize() int {
+	if c.BatchSize == 0 {
+		return defaultBatchSize
+	}
+	return c.BatchSize
+}
+
+func (c SinkConfiguration) flushInterval() time.Duration {
+	if c.FlushInterval.Duration == 0 {
+		return defaultFlushInterval
+	}
+	return c.FlushInterval.Duration
+}
+
+func (c SinkConfiguration) queueSize() int {
+	if c.QueueSize == 0 {
+		return defaultQueueSize
+	}
+	return c.QueueSize
+}
+
+func (c SinkConfiguration) blockTimeout() time.Duration {
+	if c.BlockTimeout.Duration == 0 {
+		return defaultBlockTimeout
+	}
+	return c.BlockTimeout.Duration
+}
+
+func (c SinkConfiguration) maxRetries() int {
+	if c.MaxRetries == nil {
+		return defaultMaxRetries
+	}
+	return *c.MaxRetries
+}
+
+func timeout(d metav1.Duration) time.Duration {
+	if d.Duration == 0 {
+		return defaultTimeout
+	}
+	return d.Duration
+}
@@ -0,0 +1,62 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+)
+
+type fileSink struct {
+	file   io.WriteCloser
+	writer io.Writer
+	// remaining holds the bytes of a partially written batch, they are written instead of the batch when it is
+	// retried so that records are not duplicated, and before the next batch if it is dropped so that lines stay complete
+	remaining []byte
+	sequence  uint64
+}
+
+func newFileSink(config FileConfiguration) (Sink, error) {
+	if config.Path == "" || config.Path == "-" {
+		return &fileSink{writer: os.Stdout}, nil
+	}
+	file, err := os.OpenFile(config.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{file: file, writer: file}, nil
+}
+
+func (s *fileSink) Send(_ context.Context, sequence uint64, records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	data := s.remaining
+	if data == nil || s.sequence != sequence {
+		var buffer bytes.Buffer
+		buffer.Write(s.remaining)
+		encoder := json.NewEncoder(&buffer)
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+		data = buffer.Bytes()
+	}
+	n, err := s.writer.Write(data)
+	if err != nil {
+		s.remaining = data[n:]
+		s.sequence = sequence
+		return err
+	}
+	s.remaining = nil
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	if s.file != nil {
+		return s.file.Close()
+	}
+	return nil
+}
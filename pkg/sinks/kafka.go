@@ -0,0 +1,94 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	kafkaContentType = "application/vnd.kafka.json.v2+json"
+	kafkaAccept      = "application/vnd.kafka.v2+json"
+)
+
+type kafkaSink struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key,omitempty"`
+	Value Record `json:"value"`
+}
+
+type kafkaRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaResponse struct {
+	Offsets []struct {
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+func newKafkaSink(config KafkaConfiguration) Sink {
+	return &kafkaSink{
+		client:  &http.Client{Timeout: timeout(config.Timeout)},
+		url:     strings.TrimSuffix(config.URL, "/") + "/topics/" + url.PathEscape(config.Topic),
+		headers: config.Headers,
+	}
+}
+
+func (s *kafkaSink) Send(ctx context.Context, _ uint64, records []Record) error {
+	request := kafkaRequest{Records: make([]kafkaRecord, 0, len(records))}
+	for _, record := range records {
+		request.Records = append(request.Records, kafkaRecord{Key: record.Policy, Value: record})
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", kafkaAccept)
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	var response kafkaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	// the batch is retried as a whole, records produced before the failure can be duplicated
+	for _, offset := range response.Offsets {
+		if offset.ErrorCode != nil || offset.Error != nil {
+			message := ""
+			if offset.Error != nil {
+				message = *offset.Error
+			}
+			return fmt.Errorf("failed to produce records: %s", message)
+		}
+	}
+	return nil
+}
+
+func (s *kafkaSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,115 @@
+package sinks
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"time"
+
+	collectorlogsv1 "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	logsv1 "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+type otlpSink struct {
+	conn    *grpc.ClientConn
+	client  collectorlogsv1.LogsServiceClient
+	headers metadata.MD
+	timeout time.Duration
+}
+
+func newOTLPSink(config OTLPConfiguration) (Sink, error) {
+	creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if config.Insecure {
+		creds = insecure.NewCredentials()
+	}
+	conn, err := grpc.Dial(config.Endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &otlpSink{
+		conn:    conn,
+		client:  collectorlogsv1.NewLogsServiceClient(conn),
+		headers: metadata.New(config.Headers),
+		timeout: timeout(config.Timeout),
+	}, nil
+}
+
+func (s *otlpSink) Send(ctx context.Context, _ uint64, records []Record) error {
+	logRecords := make([]*logsv1.LogRecord, 0, len(records))
+	for _, record := range records {
+		logRecord, err := toLogRecord(record)
+		if err != nil {
+			return err
+		}
+		logRecords = append(logRecords, logRecord)
+	}
+	request := &collectorlogsv1.ExportLogsServiceRequest{
+		ResourceLogs: []*logsv1.ResourceLogs{{
+			Resource: &resourcev1.Resource{
+				Attributes: []*commonv1.KeyValue{stringAttribute("service.name", "kyverno")},
+			},
+			ScopeLogs: []*logsv1.ScopeLogs{{
+				Scope:      &commonv1.InstrumentationScope{Name: "kyverno.io/results"},
+				LogRecords: logRecords,
+			}},
+		}},
+	}
+	ctx = metadata.NewOutgoingContext(ctx, s.headers)
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	_, err := s.client.Export(ctx, request)
+	return err
+}
+
+func (s *otlpSink) Close() error {
+	return s.conn.Close()
+}
+
+func toLogRecord(record Record) (*logsv1.LogRecord, error) {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	severityNumber, severityText := logSeverity(record.Result)
+	timestamp := uint64(record.Timestamp.UnixNano())
+	return &logsv1.LogRecord{
+		TimeUnixNano:         timestamp,
+		ObservedTimeUnixNano: timestamp,
+		SeverityNumber:       severityNumber,
+		SeverityText:         severityText,
+		Body:                 &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: string(body)}},
+		Attributes: []*commonv1.KeyValue{
+			stringAttribute("kyverno.source", record.Source),
+			stringAttribute("kyverno.policy", record.Policy),
+			stringAttribute("kyverno.rule", record.Rule),
+			stringAttribute("kyverno.result", record.Result),
+			stringAttribute("kyverno.severity", record.Severity),
+			stringAttribute("kyverno.category", record.Category),
+			stringAttribute("k8s.resource.kind", record.Resource.Kind),
+			stringAttribute("k8s.namespace.name", record.Resource.Namespace),
+			stringAttribute("k8s.resource.name", record.Resource.Name),
+		},
+	}, nil
+}
+
+// logSeverity maps a rule result to a log severity, failures are reported as warnings
+func logSeverity(result string) (logsv1.SeverityNumber, string) {
+	switch result {
+	case "error":
+		return logsv1.SeverityNumber_SEVERITY_NUMBER_ERROR, "ERROR"
+	case "fail", "warn":
+		return logsv1.SeverityNumber_SEVERITY_NUMBER_WARN, "WARN"
+	default:
+		return logsv1.SeverityNumber_SEVERITY_NUMBER_INFO, "INFO"
+	}
+}
+
+func stringAttribute(key, value string) *commonv1.KeyValue {
+	return &commonv1.KeyValue{Key: key, Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: value}}}
+}
@@ -0,0 +1,66 @@
+package sinks
+
+import (
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+)
+
+// Record is a single rule result as sent to sinks
+type Record struct {
+	Timestamp  time.Time         `json:"timestamp"`
+	Source     string            `json:"source"`
+	Policy     string            `json:"policy"`
+	Rule       string            `json:"rule,omitempty"`
+	Result     string            `json:"result"`
+	Severity   string            `json:"severity,omitempty"`
+	Category   string            `json:"category,omitempty"`
+	Message    string            `json:"message,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+	Resource   Resource          `json:"resource"`
+}
+
+// Resource identifies the resource a rule was applied to
+type Resource struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name,omitempty"`
+	UID        string `json:"uid,omitempty"`
+}
+
+// NewRecords converts engine responses to records, results are computed the same way as in policy reports
+func NewRecords(cause metrics.RuleExecutionCause, responses ...*engineapi.EngineResponse) []Record {
+	now := time.Now()
+	var records []Record
+	for _, response := range responses {
+		if response == nil || response.Policy == nil {
+			continue
+		}
+		spec := response.PolicyResponse.Resource
+		resource := Resource{
+			APIVersion: spec.APIVersion,
+			Kind:       spec.Kind,
+			Namespace:  spec.Namespace,
+			Name:       spec.Name,
+			UID:        spec.UID,
+		}
+		for _, result := range reportutils.EngineResponseToReportResults(response) {
+			records = append(records, Record{
+				Timestamp:  now,
+				Source:     string(cause),
+				Policy:     result.Policy,
+				Rule:       result.Rule,
+				Result:     string(result.Result),
+				Severity:   string(result.Severity),
+				Category:   result.Category,
+				Message:    result.Message,
+				Properties: result.Properties,
+				Resource:   resource,
+			})
+		}
+	}
+	return records
+}
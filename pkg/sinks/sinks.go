@@ -0,0 +1,261 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+const (
+	// Workers is the number of workers for this controller, every sink is processed by its own worker
+	Workers        = 1
+	ControllerName = "result-sinks-controller"
+	// time allowed to send pending records when shutting down
+	shutdownTimeout = 10 * time.Second
+	// initial delay between retries, doubled on every attempt
+	retryDelay = 500 * time.Millisecond
+)
+
+// Sink sends batches of records to an external system
+type Sink interface {
+	// Send sends a batch of records, the batch is retried with the same sequence number if an error is returned
+	Send(ctx context.Context, sequence uint64, records []Record) error
+	// Close releases the resources held by the sink
+	Close() error
+}
+
+// Publisher publishes policy results to sinks
+type Publisher interface {
+	// Record publishes the results of the given engine responses
+	Record(ctx context.Context, cause metrics.RuleExecutionCause, responses ...*engineapi.EngineResponse)
+}
+
+type Controller interface {
+	controllers.Controller
+	Publisher
+}
+
+type controller struct {
+	workers []*worker
+}
+
+// NewController creates the configured sinks and returns a controller publishing results to them,
+// records are queued in memory and sent in batches by the controller when it runs
+func NewController(logger logr.Logger, meterProvider metric.MeterProvider, config Configuration) (Controller, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	recordsCounter, err := meterProvider.Meter(metrics.MeterName).SyncInt64().Counter(
+		"kyverno_result_sink_records",
+		instrument.WithDescription("can be used to track the number of policy results sent, dropped or failed per result sink"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_result_sink_records")
+	}
+	var workers []*worker
+	for _, sinkConfig := range config.Sinks {
+		sink, err := newSink(sinkConfig)
+		if err != nil {
+			for _, w := range workers {
+				_ = w.sink.Close()
+			}
+			return nil, fmt.Errorf("failed to create result sink %s: %w", sinkConfig.Name, err)
+		}
+		workers = append(workers, newWorker(logger.WithValues("sink", sinkConfig.Name), sinkConfig, sink, recordsCounter))
+	}
+	return &controller{workers: workers}, nil
+}
+
+func newSink(config SinkConfiguration) (Sink, error) {
+	switch {
+	case config.Webhook != nil:
+		return newWebhookSink(*config.Webhook), nil
+	case config.Kafka != nil:
+		return newKafkaSink(*config.Kafka), nil
+	case config.OTLP != nil:
+		return newOTLPSink(*config.OTLP)
+	case config.File != nil:
+		return newFileSink(*config.File)
+	}
+	return nil, fmt.Errorf("no sink type configured")
+}
+
+func (c *controller) Run(ctx context.Context, _ int) {
+	var wg sync.WaitGroup
+	for _, w := range c.workers {
+		wg.Add(1)
+		go func(w *worker) {
+			defer wg.Done()
+			w.run(ctx)
+		}(w)
+	}
+	wg.Wait()
+}
+
+func (c *controller) Record(ctx context.Context, cause metrics.RuleExecutionCause, responses ...*engineapi.EngineResponse) {
+	if len(c.workers) == 0 {
+		return
+	}
+	records := NewRecords(cause, responses...)
+	// deadlines are computed from the same start so that a request waits at most for the longest block timeout
+	start := time.Now()
+	for _, w := range c.workers {
+		c.enqueue(ctx, w, start, records)
+	}
+}
+
+func (c *controller) enqueue(ctx context.Context, w *worker, start time.Time, records []Record) {
+	if w.block {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, start.Add(w.blockTimeout))
+		defer cancel()
+	}
+	for _, record := range records {
+		if w.filter.Matches(record) {
+			w.enqueue(ctx, record)
+		}
+	}
+}
+
+type worker struct {
+	logger        logr.Logger
+	name          string
+	sink          Sink
+	filter        Filter
+	queue         chan Record
+	batchSize     int
+	flushInterval time.Duration
+	maxRetries    int
+	block         bool
+	blockTimeout  time.Duration
+	counter       syncint64.Counter
+	// sequence number of the last batch sent
+	sequence uint64
+}
+
+func newWorker(logger logr.Logger, config SinkConfiguration, sink Sink, counter syncint64.Counter) *worker {
+	return &worker{
+		logger:        logger,
+		name:          config.Name,
+		sink:          sink,
+		filter:        config.Filter,
+		queue:         make(chan Record, config.queueSize()),
+		batchSize:     config.batchSize(),
+		flushInterval: config.flushInterval(),
+		maxRetries:    config.maxRetries(),
+		block:         config.Backpressure == BackpressureBlock,
+		blockTimeout:  config.blockTimeout(),
+		counter:       counter,
+	}
+}
+
+// enqueue queues a record, it is called from admission requests and waits for the queue until the context is
+// done when backpressure is Block, the context deadline is shared by all the records of a request
+func (w *worker) enqueue(ctx context.Context, record Record) {
+	if w.block {
+		select {
+		case w.queue <- record:
+		case <-ctx.Done():
+			w.count(ctx, "dropped", 1)
+		}
+		return
+	}
+	select {
+	case w.queue <- record:
+	default:
+		w.count(ctx, "dropped", 1)
+	}
+}
+
+func (w *worker) run(ctx context.Context) {
+	defer func() {
+		if err := w.sink.Close(); err != nil {
+			w.logger.Error(err, "failed to close result sink")
+		}
+	}()
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+	batch := make([]Record, 0, w.batchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			w.shutdown(batch)
+			return
+		case record := <-w.queue:
+			batch = append(batch, record)
+			if len(batch) >= w.batchSize {
+				w.flush(ctx, batch)
+				batch = make([]Record, 0, w.batchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				w.flush(ctx, batch)
+				batch = make([]Record, 0, w.batchSize)
+			}
+		}
+	}
+}
+
+// shutdown sends the pending records, without retries, before the worker stops
+func (w *worker) shutdown(batch []Record) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	w.maxRetries = 0
+	for {
+		select {
+		case record := <-w.queue:
+			batch = append(batch, record)
+			if len(batch) >= w.batchSize {
+				w.flush(ctx, batch)
+				batch = make([]Record, 0, w.batchSize)
+			}
+		default:
+			if len(batch) > 0 {
+				w.flush(ctx, batch)
+			}
+			return
+		}
+	}
+}
+
+func (w *worker) flush(ctx context.Context, batch []Record) {
+	w.sequence++
+	delay := retryDelay
+	for attempt := 0; ; attempt++ {
+		err := w.sink.Send(ctx, w.sequence, batch)
+		if err == nil {
+			w.count(ctx, "sent", len(batch))
+			return
+		}
+		if attempt >= w.maxRetries {
+			w.logger.Error(err, "failed to send records to result sink, dropping batch", "records", len(batch), "attempts", attempt+1)
+			w.count(ctx, "failed", len(batch))
+			return
+		}
+		w.logger.V(3).Info("failed to send records to result sink, retrying", "error", err.Error(), "after", delay)
+		select {
+		case <-ctx.Done():
+			w.count(ctx, "failed", len(batch))
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (w *worker) count(ctx context.Context, status string, count int) {
+	if w.counter == nil {
+		return
+	}
+	w.counter.Add(ctx, int64(count), attribute.String("sink", w.name), attribute.String("status", status))
+}
@@ -0,0 +1,329 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeSink struct {
+	lock     sync.Mutex
+	failures int
+	batches  [][]Record
+	closed   bool
+}
+
+func (s *fakeSink) Send(_ context.Context, _ uint64, records []Record) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("failed")
+	}
+	s.batches = append(s.batches, records)
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *fakeSink) sent() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	count := 0
+	for _, batch := range s.batches {
+		count += len(batch)
+	}
+	return count
+}
+
+func Test_Filter(t *testing.T) {
+	record := Record{Policy: "ns/require-labels", Result: "fail", Severity: "high"}
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{{
+		name: "empty filter",
+		want: true,
+	}, {
+		name:   "policy wildcard",
+		filter: Filter{Policies: []string{"ns/require-*"}},
+		want:   true,
+	}, {
+		name:   "policy mismatch",
+		filter: Filter{Policies: []string{"disallow-*"}},
+	}, {
+		name:   "severity and result",
+		filter: Filter{Severities: []string{"high", "critical"}, Results: []string{"fail"}},
+		want:   true,
+	}, {
+		name:   "result mismatch",
+		filter: Filter{Severities: []string{"high"}, Results: []string{"pass"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.filter.Matches(record), tt.want)
+		})
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Configuration
+		wantErr string
+	}{{
+		name:   "valid",
+		config: Configuration{Sinks: []SinkConfiguration{{Name: "stdout", File: &FileConfiguration{}}}},
+	}, {
+		name:    "missing name",
+		config:  Configuration{Sinks: []SinkConfiguration{{File: &FileConfiguration{}}}},
+		wantErr: "sinks[0]: name is required",
+	}, {
+		name:    "duplicate name",
+		config:  Configuration{Sinks: []SinkConfiguration{{Name: "a", File: &FileConfiguration{}}, {Name: "a", File: &FileConfiguration{}}}},
+		wantErr: "sinks[1]: duplicate sink name a",
+	}, {
+		name:    "no type",
+		config:  Configuration{Sinks: []SinkConfiguration{{Name: "a"}}},
+		wantErr: "sinks[0] (a): exactly one of webhook, kafka, otlp or file must be set",
+	}, {
+		name:    "kafka without topic",
+		config:  Configuration{Sinks: []SinkConfiguration{{Name: "a", Kafka: &KafkaConfiguration{URL: "http://proxy:8082"}}}},
+		wantErr: "sinks[0] (a): kafka url and topic are required",
+	}, {
+		name:    "invalid backpressure",
+		config:  Configuration{Sinks: []SinkConfiguration{{Name: "a", File: &FileConfiguration{}, Backpressure: "Wait"}}},
+		wantErr: "sinks[0] (a): invalid backpressure Wait, must be Drop or Block",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_LoadConfiguration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sinks.yaml")
+	assert.NilError(t, os.WriteFile(path, []byte(`
+sinks:
+- name: siem
+  webhook:
+    url: https://siem.example.com/ingest
+    headers:
+      Authorization: Bearer token
+  filter:
+    results: [fail, error]
+  batchSize: 50
+  flushInterval: 2s
+  backpressure: Block
+`), 0o600))
+	config, err := LoadConfiguration(path)
+	assert.NilError(t, err)
+	assert.Equal(t, len(config.Sinks), 1)
+	sink := config.Sinks[0]
+	assert.Equal(t, sink.Webhook.URL, "https://siem.example.com/ingest")
+	assert.Equal(t, sink.batchSize(), 50)
+	assert.Equal(t, sink.flushInterval(), 2*time.Second)
+	assert.Equal(t, sink.queueSize(), defaultQueueSize)
+	assert.Equal(t, sink.maxRetries(), defaultMaxRetries)
+	assert.DeepEqual(t, sink.Filter.Results, []string{"fail", "error"})
+}
+
+func Test_worker(t *testing.T) {
+	sink := &fakeSink{failures: 1}
+	w := newWorker(logr.Discard(), SinkConfiguration{Name: "fake", BatchSize: 2, FlushInterval: metav1.Duration{Duration: time.Hour}}, sink, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.run(ctx)
+	}()
+	for i := 0; i < 5; i++ {
+		w.enqueue(ctx, Record{Policy: "policy"})
+	}
+	// two full batches are sent, the first one after a retry
+	assert.NilError(t, waitFor(func() bool { return sink.sent() == 4 }))
+	// the last record is flushed on shutdown
+	cancel()
+	<-done
+	assert.Equal(t, sink.sent(), 5)
+	assert.Equal(t, len(sink.batches), 3)
+	assert.Assert(t, sink.closed)
+}
+
+func Test_worker_backpressure(t *testing.T) {
+	sink := &fakeSink{}
+	w := newWorker(logr.Discard(), SinkConfiguration{Name: "fake", QueueSize: 2}, sink, nil)
+	for i := 0; i < 5; i++ {
+		w.enqueue(context.Background(), Record{Policy: "policy"})
+	}
+	// records are dropped when the queue is full
+	assert.Equal(t, len(w.queue), 2)
+	blocking := newWorker(logr.Discard(), SinkConfiguration{Name: "fake", QueueSize: 1, Backpressure: BackpressureBlock}, sink, nil)
+	blocking.enqueue(context.Background(), Record{Policy: "policy"})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	// blocks until the context is done
+	blocking.enqueue(ctx, Record{Policy: "policy"})
+	assert.Equal(t, len(blocking.queue), 1)
+}
+
+func Test_controller_blockTimeout(t *testing.T) {
+	sink := &fakeSink{}
+	config := SinkConfiguration{Name: "fake", QueueSize: 1, Backpressure: BackpressureBlock, BlockTimeout: metav1.Duration{Duration: 200 * time.Millisecond}}
+	c := &controller{workers: []*worker{newWorker(logr.Discard(), config, sink, nil), newWorker(logr.Discard(), config, sink, nil)}}
+	records := make([]Record, 10)
+	start := time.Now()
+	c.enqueue(context.Background(), c.workers[0], start, records)
+	c.enqueue(context.Background(), c.workers[1], start, records)
+	// all the records of a request share the same deadline, whatever the number of records and sinks
+	elapsed := time.Since(start)
+	assert.Assert(t, elapsed >= 200*time.Millisecond)
+	assert.Assert(t, elapsed < time.Second, elapsed)
+	assert.Equal(t, len(c.workers[0].queue), 1)
+	assert.Equal(t, len(c.workers[1].queue), 1)
+}
+
+func Test_webhookSink(t *testing.T) {
+	var received []Record
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+	records := []Record{{Policy: "a", Result: "fail"}, {Policy: "b", Result: "pass"}}
+	sink := newWebhookSink(WebhookConfiguration{URL: server.URL})
+	assert.Error(t, sink.Send(context.Background(), 1, records), "unexpected response status 401 Unauthorized")
+	sink = newWebhookSink(WebhookConfiguration{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+	assert.NilError(t, sink.Send(context.Background(), 2, records))
+	assert.Equal(t, len(received), 2)
+	assert.Equal(t, received[0].Policy, "a")
+	assert.NilError(t, sink.Close())
+}
+
+func Test_fileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.jsonl")
+	sink, err := newFileSink(FileConfiguration{Path: path})
+	assert.NilError(t, err)
+	assert.NilError(t, sink.Send(context.Background(), 1, []Record{{Policy: "a"}, {Policy: "b"}}))
+	assert.NilError(t, sink.Close())
+	data, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Equal(t, len(lines), 2)
+	var record Record
+	assert.NilError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, record.Policy, "b")
+}
+
+type partialWriter struct {
+	data  []byte
+	limit int
+}
+
+func (w *partialWriter) Write(p []byte) (int, error) {
+	if w.limit >= 0 && len(p) > w.limit {
+		n := w.limit
+		w.data = append(w.data, p[:n]...)
+		w.limit = -1
+		return n, errors.New("short write")
+	}
+	w.data = append(w.data, p...)
+	return len(p), nil
+}
+
+func Test_fileSink_retry(t *testing.T) {
+	writer := &partialWriter{limit: 5}
+	sink := &fileSink{writer: writer}
+	batch := []Record{{Policy: "a"}, {Policy: "b"}}
+	assert.Assert(t, sink.Send(context.Background(), 1, batch) != nil)
+	// the retried batch only writes the remaining bytes
+	assert.NilError(t, sink.Send(context.Background(), 1, batch))
+	lines := strings.Split(strings.TrimSpace(string(writer.data)), "\n")
+	assert.Equal(t, len(lines), 2)
+	var record Record
+	assert.NilError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, record.Policy, "a")
+	// the remaining bytes of a dropped batch are written before the next batch
+	writer.limit = 5
+	assert.Assert(t, sink.Send(context.Background(), 2, []Record{{Policy: "c"}}) != nil)
+	assert.NilError(t, sink.Send(context.Background(), 3, []Record{{Policy: "d"}}))
+	lines = strings.Split(strings.TrimSpace(string(writer.data)), "\n")
+	assert.Equal(t, len(lines), 4)
+	for _, line := range lines {
+		assert.NilError(t, json.Unmarshal([]byte(line), &record))
+	}
+	assert.Equal(t, record.Policy, "d")
+}
+
+func Test_kafkaSink(t *testing.T) {
+	var received kafkaRequest
+	fail := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/topics/policy-results")
+		assert.Equal(t, r.Header.Get("Content-Type"), kafkaContentType)
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Header().Set("Content-Type", kafkaAccept)
+		if fail {
+			_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":1},{"error_code":50003,"error":"leader not available"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":1},{"partition":0,"offset":2}]}`))
+	}))
+	defer server.Close()
+	records := []Record{{Policy: "a", Result: "fail"}, {Policy: "b", Result: "pass"}}
+	sink := newKafkaSink(KafkaConfiguration{URL: server.URL + "/", Topic: "policy-results"})
+	assert.Error(t, sink.Send(context.Background(), 1, records), "failed to produce records: leader not available")
+	fail = false
+	assert.NilError(t, sink.Send(context.Background(), 1, records))
+	assert.Equal(t, len(received.Records), 2)
+	assert.Equal(t, received.Records[1].Key, "b")
+	assert.Equal(t, received.Records[1].Value.Result, "pass")
+	assert.NilError(t, sink.Close())
+}
+
+func Test_logSeverity(t *testing.T) {
+	_, text := logSeverity("fail")
+	assert.Equal(t, text, "WARN")
+	_, text = logSeverity("error")
+	assert.Equal(t, text, "ERROR")
+	_, text = logSeverity("pass")
+	assert.Equal(t, text, "INFO")
+}
+
+func waitFor(condition func() bool) error {
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return errors.New("timed out")
+}
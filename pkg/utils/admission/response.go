@@ -23,6 +23,15 @@ func Response(uid types.UID, err error, warnings ...string) *admissionv1.Admissi
 	return response
 }
 
+// ResponseWithCauses returns a response denying the request with the given causes in the status details
+func ResponseWithCauses(uid types.UID, err error, causes []metav1.StatusCause, warnings ...string) *admissionv1.AdmissionResponse {
+	response := Response(uid, err, warnings...)
+	if response.Result != nil && len(causes) != 0 {
+		response.Result.Details = &metav1.StatusDetails{Causes: causes}
+	}
+	return response
+}
+
 func ResponseSuccess(uid types.UID, warnings ...string) *admissionv1.AdmissionResponse {
 	return Response(uid, nil, warnings...)
 }
@@ -0,0 +1,71 @@
+package net
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// ParseAddr parses an IP address, IPv4-mapped IPv6 addresses are converted to IPv4 addresses
+func ParseAddr(s string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(s))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return addr.Unmap(), nil
+}
+
+// ParsePrefix parses a CIDR, a single IP address is parsed as the prefix containing only this address
+func ParsePrefix(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "/") {
+		addr, err := ParseAddr(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return prefix.Masked(), nil
+}
+
+// PrefixContains checks if all addresses of the inner prefix belong to the outer prefix
+func PrefixContains(outer, inner netip.Prefix) bool {
+	return outer.Bits() <= inner.Bits() && outer.Contains(inner.Addr())
+}
+
+// ParsePortRange parses a port range like 8000-9000, a single port is parsed as the range containing only this port
+func ParsePortRange(s string) (int, int, error) {
+	from, to, found := strings.Cut(strings.TrimSpace(s), "-")
+	min, err := ParsePort(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !found {
+		return min, min, nil
+	}
+	max, err := ParsePort(to)
+	if err != nil {
+		return 0, 0, err
+	}
+	if min > max {
+		return 0, 0, fmt.Errorf("invalid port range %s", s)
+	}
+	return min, max, nil
+}
+
+// ParsePort parses a port number
+func ParsePort(s string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %s", s)
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("port %d is out of range", port)
+	}
+	return port, nil
+}
@@ -0,0 +1,83 @@
+package net
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    netip.Prefix
+		wantErr bool
+	}{
+		{in: "10.0.0.0/8", want: netip.MustParsePrefix("10.0.0.0/8")},
+		{in: "10.1.2.3/8", want: netip.MustParsePrefix("10.0.0.0/8")},
+		{in: "10.1.2.3", want: netip.MustParsePrefix("10.1.2.3/32")},
+		{in: "::ffff:10.1.2.3", want: netip.MustParsePrefix("10.1.2.3/32")},
+		{in: "fd00::/8", want: netip.MustParsePrefix("fd00::/8")},
+		{in: "10.0.0.0/33", wantErr: true},
+		{in: "foo", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParsePrefix(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPrefixContains(t *testing.T) {
+	tests := []struct {
+		outer string
+		inner string
+		want  bool
+	}{
+		{"10.0.0.0/8", "10.1.0.0/16", true},
+		{"10.0.0.0/8", "10.0.0.0/8", true},
+		{"10.1.0.0/16", "10.0.0.0/8", false},
+		{"10.0.0.0/8", "11.0.0.0/16", false},
+		{"10.0.0.0/8", "fd00::/16", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.outer+" "+tt.inner, func(t *testing.T) {
+			assert.Equal(t, tt.want, PrefixContains(netip.MustParsePrefix(tt.outer), netip.MustParsePrefix(tt.inner)))
+		})
+	}
+}
+
+func TestParsePortRange(t *testing.T) {
+	tests := []struct {
+		in      string
+		min     int
+		max     int
+		wantErr bool
+	}{
+		{in: "80", min: 80, max: 80},
+		{in: "8000-9000", min: 8000, max: 9000},
+		{in: " 8000 - 9000 ", min: 8000, max: 9000},
+		{in: "9000-8000", wantErr: true},
+		{in: "0", wantErr: true},
+		{in: "1-65536", wantErr: true},
+		{in: "http", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			min, max, err := ParsePortRange(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.min, min)
+			assert.Equal(t, tt.max, max)
+		})
+	}
+}
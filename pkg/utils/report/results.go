@@ -59,7 +59,7 @@ func toPolicyResult(status engineapi.RuleStatus) policyreportv1alpha2.PolicyResu
 		return policyreportv1alpha2.StatusPass
 	case engineapi.RuleStatusFail:
 		return policyreportv1alpha2.StatusFail
-	case engineapi.RuleStatusError:
+	case engineapi.RuleStatusError, engineapi.RuleStatusTimeout:
 		return policyreportv1alpha2.StatusError
 	case engineapi.RuleStatusWarn:
 		return policyreportv1alpha2.StatusWarn
@@ -115,6 +115,12 @@ func EngineResponseToReportResults(response *engineapi.EngineResponse) []policyr
 				}
 			}
 		}
+		if shadowOf := response.Policy.GetSpec().ShadowOf; shadowOf != "" {
+			if result.Properties == nil {
+				result.Properties = map[string]string{}
+			}
+			result.Properties["shadowOf"] = shadowOf
+		}
 		if result.Result == "fail" && !result.Scored {
 			result.Result = "warn"
 		}
@@ -2,19 +2,31 @@ package exception
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	"golang.org/x/exp/slices"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 )
 
 const (
 	namespacesDontMatch = "PolicyException resource namespace must match the defined namespace."
 	disabledPolex       = "PolicyException resources would not be processed until it is enabled."
+	pendingApprovals    = "PolicyException resources would not be processed until they are approved by %d user(s)."
 )
 
 type ValidationOptions struct {
 	Enabled   bool
 	Namespace string
+	// ApproverGroups are the groups allowed to approve policy exceptions, any user can approve if empty.
+	// It should not be empty when approvals are required, otherwise any user can approve an exception
+	// authored by someone else.
+	ApproverGroups []string
+	// RequiredApprovals is the number of approvals required for a policy exception to take effect,
+	// the author of an exception must be recorded in its annotations when approvals are required
+	RequiredApprovals int
 }
 
 // Validate checks policy exception is valid
@@ -25,6 +37,83 @@ func Validate(ctx context.Context, logger logr.Logger, polex *kyvernov2alpha1.Po
 	} else if opts.Namespace != "" && opts.Namespace != polex.Namespace {
 		warnings = append(warnings, namespacesDontMatch)
 	}
+	if opts.RequiredApprovals > 0 && !polex.IsApproved(opts.RequiredApprovals) {
+		warnings = append(warnings, fmt.Sprintf(pendingApprovals, opts.RequiredApprovals))
+	}
 	errs := polex.Validate()
 	return warnings, errs.ToAggregate()
 }
+
+// ValidateAuthor checks the author recorded in the annotations of the policy exception when approvals are required.
+// The user creating the exception or changing its spec must be recorded as its author, and the author cannot be
+// changed without changing the spec.
+func ValidateAuthor(polex, oldPolex *kyvernov2alpha1.PolicyException, userInfo authenticationv1.UserInfo, opts ValidationOptions) error {
+	if opts.RequiredApprovals <= 0 {
+		return nil
+	}
+	author := polex.GetAuthor()
+	if oldPolex == nil || !equality.Semantic.DeepEqual(polex.Spec, oldPolex.Spec) {
+		if author != userInfo.Username {
+			return fmt.Errorf("policy exceptions require approvals, the %s annotation must be set to %s, the user creating or changing the exception", kyvernov2alpha1.AnnotationPolicyExceptionAuthor, userInfo.Username)
+		}
+		return nil
+	}
+	if author != oldPolex.GetAuthor() {
+		return fmt.Errorf("the %s annotation can only be changed along with the policy exception spec", kyvernov2alpha1.AnnotationPolicyExceptionAuthor)
+	}
+	return nil
+}
+
+// ValidateApprovals checks approvals changes made by the given user on the policy exception status are allowed.
+// Users can only add approvals in their own name for the current generation of the exception, and only remove
+// their own approvals or approvals granted for a previous generation. The author of the exception cannot approve it.
+func ValidateApprovals(polex, oldPolex *kyvernov2alpha1.PolicyException, userInfo authenticationv1.UserInfo, opts ValidationOptions) error {
+	var oldApprovals []kyvernov2alpha1.Approval
+	if oldPolex != nil {
+		oldApprovals = oldPolex.Status.Approvals
+	}
+	for _, approval := range polex.Status.Approvals {
+		if containsApproval(oldApprovals, approval) {
+			continue
+		}
+		if approval.User != userInfo.Username {
+			return fmt.Errorf("user %s cannot grant an approval on behalf of %s", userInfo.Username, approval.User)
+		}
+		if approval.Generation != polex.Generation {
+			return fmt.Errorf("approval must be granted for the current generation of the policy exception (%d)", polex.Generation)
+		}
+		if approval.User == polex.GetAuthor() {
+			return fmt.Errorf("user %s cannot approve a policy exception they authored", userInfo.Username)
+		}
+		if !isApprover(userInfo, opts.ApproverGroups) {
+			return fmt.Errorf("user %s is not allowed to approve policy exceptions", userInfo.Username)
+		}
+	}
+	for _, approval := range oldApprovals {
+		if containsApproval(polex.Status.Approvals, approval) {
+			continue
+		}
+		if approval.User != userInfo.Username && approval.Generation == polex.Generation {
+			return fmt.Errorf("user %s cannot revoke an approval granted by %s", userInfo.Username, approval.User)
+		}
+	}
+	return nil
+}
+
+func containsApproval(approvals []kyvernov2alpha1.Approval, approval kyvernov2alpha1.Approval) bool {
+	return slices.ContainsFunc(approvals, func(a kyvernov2alpha1.Approval) bool {
+		return a.User == approval.User && a.Generation == approval.Generation
+	})
+}
+
+func isApprover(userInfo authenticationv1.UserInfo, approverGroups []string) bool {
+	if len(approverGroups) == 0 {
+		return true
+	}
+	for _, group := range userInfo.Groups {
+		if slices.Contains(approverGroups, group) {
+			return true
+		}
+	}
+	return false
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"testing"
 
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/logging"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	"gotest.tools/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_Validate(t *testing.T) {
@@ -63,6 +66,17 @@ func Test_Validate(t *testing.T) {
 			},
 			want: 0,
 		},
+		{
+			name: "PolicyExceptions enabled. Approvals required but not granted",
+			args: args{
+				opts: ValidationOptions{
+					Enabled:           true,
+					RequiredApprovals: 1,
+				},
+				resource: []byte(`{"apiVersion":"kyverno.io/v2alpha1","kind":"PolicyException","metadata":{"name":"enforce-label-exception","namespace":"kyverno"},"spec":{"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]}}}`),
+			},
+			want: 1,
+		},
 	}
 	for _, c := range tc {
 		t.Run(c.name, func(t *testing.T) {
@@ -74,3 +88,176 @@ func Test_Validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateApprovals(t *testing.T) {
+	newPolex := func(generation int64, approvals ...kyvernov2alpha1.Approval) *kyvernov2alpha1.PolicyException {
+		return &kyvernov2alpha1.PolicyException{
+			ObjectMeta: metav1.ObjectMeta{Name: "polex", Namespace: "default", Generation: generation},
+			Status:     kyvernov2alpha1.PolicyExceptionStatus{Approvals: approvals},
+		}
+	}
+	alice := authenticationv1.UserInfo{Username: "alice", Groups: []string{"security"}}
+	bob := authenticationv1.UserInfo{Username: "bob", Groups: []string{"developers"}}
+	opts := ValidationOptions{Enabled: true, ApproverGroups: []string{"security"}, RequiredApprovals: 1}
+	tc := []struct {
+		name     string
+		polex    *kyvernov2alpha1.PolicyException
+		oldPolex *kyvernov2alpha1.PolicyException
+		userInfo authenticationv1.UserInfo
+		opts     ValidationOptions
+		wantErr  bool
+	}{{
+		name:     "approver grants approval",
+		polex:    newPolex(1, kyvernov2alpha1.Approval{User: "alice", Generation: 1}),
+		oldPolex: newPolex(1),
+		userInfo: alice,
+		opts:     opts,
+	}, {
+		name:     "user not in approver groups",
+		polex:    newPolex(1, kyvernov2alpha1.Approval{User: "bob", Generation: 1}),
+		oldPolex: newPolex(1),
+		userInfo: bob,
+		opts:     opts,
+		wantErr:  true,
+	}, {
+		name:     "any user can approve without approver groups",
+		polex:    newPolex(1, kyvernov2alpha1.Approval{User: "bob", Generation: 1}),
+		oldPolex: newPolex(1),
+		userInfo: bob,
+		opts:     ValidationOptions{Enabled: true, RequiredApprovals: 1},
+	}, {
+		name:     "approval on behalf of another user",
+		polex:    newPolex(1, kyvernov2alpha1.Approval{User: "alice", Generation: 1}),
+		oldPolex: newPolex(1),
+		userInfo: bob,
+		opts:     ValidationOptions{Enabled: true, RequiredApprovals: 1},
+		wantErr:  true,
+	}, {
+		name:     "approval for a previous generation",
+		polex:    newPolex(2, kyvernov2alpha1.Approval{User: "alice", Generation: 1}),
+		oldPolex: newPolex(2),
+		userInfo: alice,
+		opts:     opts,
+		wantErr:  true,
+	}, {
+		name:     "user revokes its own approval",
+		polex:    newPolex(1),
+		oldPolex: newPolex(1, kyvernov2alpha1.Approval{User: "alice", Generation: 1}),
+		userInfo: alice,
+		opts:     opts,
+	}, {
+		name:     "user revokes approval of another user",
+		polex:    newPolex(1),
+		oldPolex: newPolex(1, kyvernov2alpha1.Approval{User: "alice", Generation: 1}),
+		userInfo: bob,
+		opts:     opts,
+		wantErr:  true,
+	}, {
+		name:     "user removes stale approval of another user",
+		polex:    newPolex(2),
+		oldPolex: newPolex(2, kyvernov2alpha1.Approval{User: "alice", Generation: 1}),
+		userInfo: bob,
+		opts:     opts,
+	}, {
+		name: "author approves its own exception",
+		polex: func() *kyvernov2alpha1.PolicyException {
+			polex := newPolex(1, kyvernov2alpha1.Approval{User: "alice", Generation: 1})
+			polex.Annotations = map[string]string{kyvernov2alpha1.AnnotationPolicyExceptionAuthor: "alice"}
+			return polex
+		}(),
+		oldPolex: newPolex(1),
+		userInfo: alice,
+		opts:     opts,
+		wantErr:  true,
+	}, {
+		name:     "existing approvals are left untouched",
+		polex:    newPolex(1, kyvernov2alpha1.Approval{User: "alice", Generation: 1}),
+		oldPolex: newPolex(1, kyvernov2alpha1.Approval{User: "alice", Generation: 1}),
+		userInfo: bob,
+		opts:     opts,
+	}}
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateApprovals(c.polex, c.oldPolex, c.userInfo, c.opts)
+			if c.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ValidateAuthor(t *testing.T) {
+	newPolex := func(author string, policyName string) *kyvernov2alpha1.PolicyException {
+		polex := &kyvernov2alpha1.PolicyException{
+			ObjectMeta: metav1.ObjectMeta{Name: "polex", Namespace: "default"},
+			Spec: kyvernov2alpha1.PolicyExceptionSpec{
+				Exceptions: []kyvernov2alpha1.Exception{{PolicyName: policyName, RuleNames: []string{"rule"}}},
+			},
+		}
+		if author != "" {
+			polex.Annotations = map[string]string{kyvernov2alpha1.AnnotationPolicyExceptionAuthor: author}
+		}
+		return polex
+	}
+	bob := authenticationv1.UserInfo{Username: "bob"}
+	opts := ValidationOptions{Enabled: true, ApproverGroups: []string{"security"}, RequiredApprovals: 1}
+	tc := []struct {
+		name     string
+		polex    *kyvernov2alpha1.PolicyException
+		oldPolex *kyvernov2alpha1.PolicyException
+		opts     ValidationOptions
+		wantErr  bool
+	}{{
+		name:  "creator recorded as author",
+		polex: newPolex("bob", "policy"),
+		opts:  opts,
+	}, {
+		name:    "missing author",
+		polex:   newPolex("", "policy"),
+		opts:    opts,
+		wantErr: true,
+	}, {
+		name:    "another user recorded as author",
+		polex:   newPolex("alice", "policy"),
+		opts:    opts,
+		wantErr: true,
+	}, {
+		name:  "author not required without approvals",
+		polex: newPolex("", "policy"),
+		opts:  ValidationOptions{Enabled: true},
+	}, {
+		name:     "spec change records the new author",
+		polex:    newPolex("bob", "other"),
+		oldPolex: newPolex("alice", "policy"),
+		opts:     opts,
+	}, {
+		name:     "spec change keeping the previous author",
+		polex:    newPolex("alice", "other"),
+		oldPolex: newPolex("alice", "policy"),
+		opts:     opts,
+		wantErr:  true,
+	}, {
+		name:     "metadata change keeping the author",
+		polex:    newPolex("alice", "policy"),
+		oldPolex: newPolex("alice", "policy"),
+		opts:     opts,
+	}, {
+		name:     "author change without spec change",
+		polex:    newPolex("bob", "policy"),
+		oldPolex: newPolex("alice", "policy"),
+		opts:     opts,
+		wantErr:  true,
+	}}
+	for _, c := range tc {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateAuthor(c.polex, c.oldPolex, bob, c.opts)
+			if c.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
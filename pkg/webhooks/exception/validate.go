@@ -23,11 +23,22 @@ func NewHandlers(validationOptions validation.ValidationOptions) webhooks.Except
 
 // Validate performs the validation check on policy exception resources
 func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
-	polex, _, err := admissionutils.GetPolicyExceptions(request)
+	polex, oldPolex, err := admissionutils.GetPolicyExceptions(request)
 	if err != nil {
 		logger.Error(err, "failed to unmarshal policy exceptions from admission request")
 		return admissionutils.Response(request.UID, err)
 	}
+	if request.SubResource == "status" {
+		if err := validation.ValidateApprovals(polex, oldPolex, request.UserInfo, h.validationOptions); err != nil {
+			logger.Error(err, "policy exception approval errors")
+			return admissionutils.Response(request.UID, err)
+		}
+		return admissionutils.ResponseSuccess(request.UID)
+	}
+	if err := validation.ValidateAuthor(polex, oldPolex, request.UserInfo, h.validationOptions); err != nil {
+		logger.Error(err, "policy exception author errors")
+		return admissionutils.Response(request.UID, err)
+	}
 	warnings, err := validation.Validate(ctx, logger, polex, h.validationOptions)
 	if err != nil {
 		logger.Error(err, "policy exception validation errors")
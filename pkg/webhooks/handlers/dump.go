@@ -2,7 +2,12 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -12,6 +17,7 @@ import (
 	authenticationv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -108,3 +114,105 @@ func redactPayload(payload *admissionRequestPayload) (*admissionRequestPayload,
 	}
 	return payload, nil
 }
+
+const (
+	// DumpFormatReview writes every admission review in its own file
+	DumpFormatReview = "review"
+	// DumpFormatJSONLines appends admission reviews to a JSON lines file, one review per line
+	DumpFormatJSONLines = "jsonl"
+)
+
+// PayloadWriter persists dumped admission reviews
+type PayloadWriter interface {
+	Write(*admissionv1.AdmissionReview) error
+}
+
+// WithPayloadWriter writes the redacted admission request and its response to the given writer
+func (inner AdmissionHandler) WithPayloadWriter(writer PayloadWriter) AdmissionHandler {
+	if writer == nil {
+		return inner
+	}
+	return inner.withPayloadWriter(writer).WithTrace("DUMP")
+}
+
+func (inner AdmissionHandler) withPayloadWriter(writer PayloadWriter) AdmissionHandler {
+	return func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
+		response := inner(ctx, logger, request, startTime)
+		review, err := newAdmissionReview(request, response)
+		if err != nil {
+			logger.Error(err, "Failed to extract resources")
+		} else if err := writer.Write(review); err != nil {
+			logger.Error(err, "Failed to write admission review")
+		}
+		return response
+	}
+}
+
+// newAdmissionReview builds an admission review holding the redacted request and the response
+func newAdmissionReview(request *admissionv1.AdmissionRequest, response *admissionv1.AdmissionResponse) (*admissionv1.AdmissionReview, error) {
+	payload, err := newAdmissionRequestPayload(request)
+	if err != nil {
+		return nil, err
+	}
+	redacted := request.DeepCopy()
+	if payload.Object.Object != nil {
+		raw, err := payload.Object.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		redacted.Object = runtime.RawExtension{Raw: raw}
+	}
+	if payload.OldObject.Object != nil {
+		raw, err := payload.OldObject.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		redacted.OldObject = runtime.RawExtension{Raw: raw}
+	}
+	return &admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: admissionv1.SchemeGroupVersion.String(),
+			Kind:       "AdmissionReview",
+		},
+		Request:  redacted,
+		Response: response,
+	}, nil
+}
+
+type fileWriter struct {
+	lock   sync.Mutex
+	dir    string
+	format string
+	name   string
+}
+
+// NewFileWriter returns a PayloadWriter storing admission reviews in dir, depending on the format
+// reviews are written to <name>-<uid>.json files or appended to the <name>.jsonl file
+func NewFileWriter(dir, format, name string) PayloadWriter {
+	return &fileWriter{
+		dir:    dir,
+		format: format,
+		name:   name,
+	}
+}
+
+func (w *fileWriter) Write(review *admissionv1.AdmissionReview) error {
+	data, err := json.Marshal(review)
+	if err != nil {
+		return err
+	}
+	if w.format == DumpFormatJSONLines {
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		file, err := os.OpenFile(filepath.Join(w.dir, w.name+".jsonl"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(append(data, '\n')); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	}
+	return os.WriteFile(filepath.Join(w.dir, fmt.Sprintf("%s-%s.json", w.name, review.Request.UID)), data, 0o600)
+}
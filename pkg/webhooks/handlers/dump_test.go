@@ -2,11 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	"gotest.tools/assert"
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func Test_RedactPayload(t *testing.T) {
@@ -174,3 +178,28 @@ func Test_RedactPayload(t *testing.T) {
 		})
 	}
 }
+
+func Test_FileWriter(t *testing.T) {
+	newReview := func(uid types.UID) *admissionv1.AdmissionReview {
+		return &admissionv1.AdmissionReview{
+			Request:  &admissionv1.AdmissionRequest{UID: uid},
+			Response: &admissionv1.AdmissionResponse{UID: uid, Allowed: true},
+		}
+	}
+	dir := t.TempDir()
+	reviews := NewFileWriter(dir, DumpFormatReview, "validate")
+	assert.NilError(t, reviews.Write(newReview("1")))
+	assert.NilError(t, reviews.Write(newReview("2")))
+	data, err := os.ReadFile(filepath.Join(dir, "validate-2.json"))
+	assert.NilError(t, err)
+	var review admissionv1.AdmissionReview
+	assert.NilError(t, json.Unmarshal(data, &review))
+	assert.Equal(t, review.Response.UID, types.UID("2"))
+
+	lines := NewFileWriter(dir, DumpFormatJSONLines, "mutate")
+	assert.NilError(t, lines.Write(newReview("1")))
+	assert.NilError(t, lines.Write(newReview("2")))
+	data, err = os.ReadFile(filepath.Join(dir, "mutate.jsonl"))
+	assert.NilError(t, err)
+	assert.Equal(t, len(strings.Split(strings.TrimSpace(string(data)), "\n")), 2)
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"github.com/kyverno/kyverno/pkg/utils/wildcard"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PriorityHigh is the priority class of requests in priority namespaces, they are admitted first
+	PriorityHigh = "high"
+	// PriorityDefault is the priority class of all other requests
+	PriorityDefault = "default"
+)
+
+// Limiter bounds the number of admission requests processed concurrently
+type Limiter interface {
+	// Acquire waits until the request can be processed, the returned function must be called when the
+	// request has been processed, false is returned if the request was shed
+	Acquire(ctx context.Context, request *admissionv1.AdmissionRequest) (func(), bool)
+}
+
+type waiter struct {
+	granted chan bool
+}
+
+type limiter struct {
+	logger             logr.Logger
+	concurrency        int
+	queueSize          int
+	priorityNamespaces []string
+
+	lock     sync.Mutex
+	inFlight int
+	// queues holds the waiting requests of each priority class, high priority first
+	queues [2]*list.List
+
+	// instruments
+	shedCounter syncint64.Counter
+}
+
+// NewLimiter returns a limiter processing at most concurrency requests at a time, at most queueSize requests
+// wait for processing and requests in excess are shed. Requests in namespaces matching priorityNamespaces are
+// processed before other waiting requests and take the place of waiting requests of lower priority when the
+// queue is full. A nil limiter is returned if concurrency is not positive.
+func NewLimiter(logger logr.Logger, meterProvider metric.MeterProvider, concurrency int, queueSize int, priorityNamespaces ...string) Limiter {
+	if concurrency <= 0 {
+		return nil
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	l := &limiter{
+		logger:             logger,
+		concurrency:        concurrency,
+		queueSize:          queueSize,
+		priorityNamespaces: priorityNamespaces,
+		queues:             [2]*list.List{list.New(), list.New()},
+	}
+	meter := meterProvider.Meter(metrics.MeterName)
+	shedCounter, err := meter.SyncInt64().Counter(
+		"kyverno_admission_requests_shed",
+		instrument.WithDescription("can be used to track the number of admission requests answered without evaluating policies because the maximum number of concurrent and queued requests was reached"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_admission_requests_shed")
+	}
+	l.shedCounter = shedCounter
+	inFlightMetric, err := meter.AsyncInt64().Gauge(
+		"kyverno_admission_requests_in_flight",
+		instrument.WithDescription("can be used to track the number of admission requests being processed"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_admission_requests_in_flight")
+	}
+	queuedMetric, err := meter.AsyncInt64().Gauge(
+		"kyverno_admission_requests_queued",
+		instrument.WithDescription("can be used to track the number of admission requests waiting to be processed"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_admission_requests_queued")
+	}
+	if inFlightMetric != nil && queuedMetric != nil {
+		if err := meter.RegisterCallback([]instrument.Asynchronous{inFlightMetric, queuedMetric}, func(ctx context.Context) {
+			inFlight, high, low := l.stats()
+			inFlightMetric.Observe(ctx, int64(inFlight))
+			queuedMetric.Observe(ctx, int64(high), attribute.String("priority", PriorityHigh))
+			queuedMetric.Observe(ctx, int64(low), attribute.String("priority", PriorityDefault))
+		}); err != nil {
+			logger.Error(err, "Failed to register callback")
+		}
+	}
+	return l
+}
+
+func (l *limiter) Acquire(ctx context.Context, request *admissionv1.AdmissionRequest) (func(), bool) {
+	priority := l.priority(request)
+	w, ok := l.enqueue(priority)
+	if !ok {
+		l.shed(ctx, request, priority)
+		return nil, false
+	}
+	if w == nil {
+		return l.release, true
+	}
+	select {
+	case granted := <-w.granted:
+		if !granted {
+			l.shed(ctx, request, priority)
+			return nil, false
+		}
+		return l.release, true
+	case <-ctx.Done():
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		if l.remove(w) {
+			return nil, false
+		}
+		// the slot was granted while the request was cancelled, give it back
+		if <-w.granted {
+			l.releaseLocked()
+		}
+		return nil, false
+	}
+}
+
+// enqueue takes a slot if one is available, otherwise returns a waiter if the request can wait
+func (l *limiter) enqueue(priority string) (*waiter, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.inFlight < l.concurrency {
+		l.inFlight++
+		return nil, true
+	}
+	queue := l.queue(priority)
+	if l.queues[0].Len()+l.queues[1].Len() >= l.queueSize {
+		// requests of high priority take the place of the latest waiting request of default priority
+		if priority != PriorityHigh || l.queues[1].Len() == 0 {
+			return nil, false
+		}
+		evicted := l.queues[1].Remove(l.queues[1].Back()).(*waiter)
+		evicted.granted <- false
+	}
+	w := &waiter{granted: make(chan bool, 1)}
+	queue.PushBack(w)
+	return w, true
+}
+
+func (l *limiter) release() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.releaseLocked()
+}
+
+// releaseLocked hands the slot over to the first waiting request of the highest priority
+func (l *limiter) releaseLocked() {
+	for _, queue := range l.queues {
+		if front := queue.Front(); front != nil {
+			queue.Remove(front).(*waiter).granted <- true
+			return
+		}
+	}
+	l.inFlight--
+}
+
+func (l *limiter) remove(w *waiter) bool {
+	for _, queue := range l.queues {
+		for e := queue.Front(); e != nil; e = e.Next() {
+			if e.Value == w {
+				queue.Remove(e)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (l *limiter) queue(priority string) *list.List {
+	if priority == PriorityHigh {
+		return l.queues[0]
+	}
+	return l.queues[1]
+}
+
+func (l *limiter) stats() (int, int, int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.inFlight, l.queues[0].Len(), l.queues[1].Len()
+}
+
+func (l *limiter) priority(request *admissionv1.AdmissionRequest) string {
+	if request.Namespace != "" && wildcard.CheckPatterns(l.priorityNamespaces, request.Namespace) {
+		return PriorityHigh
+	}
+	return PriorityDefault
+}
+
+func (l *limiter) shed(ctx context.Context, request *admissionv1.AdmissionRequest, priority string) {
+	l.logger.V(2).Info("too many admission requests, shedding request", "kind", request.Kind.Kind, "namespace", request.Namespace, "name", request.Name, "priority", priority)
+	if l.shedCounter != nil {
+		l.shedCounter.Add(
+			ctx,
+			1,
+			attribute.String("resource_kind", request.Kind.Kind),
+			attribute.String("resource_namespace", request.Namespace),
+			attribute.String("resource_request_operation", strings.ToLower(string(request.Operation))),
+			attribute.String("priority", priority),
+		)
+	}
+}
+
+// WithLoadShedding bounds the number of requests processed concurrently with the given limiter, requests
+// that are shed are answered immediately according to the failure policy returned for them ("ignore" admits
+// them, other values reject them) instead of waiting for the api server to time out
+func (inner AdmissionHandler) WithLoadShedding(limiter Limiter, failurePolicy func(*admissionv1.AdmissionRequest) string) AdmissionHandler {
+	if limiter == nil {
+		return inner
+	}
+	return inner.withLoadShedding(limiter, failurePolicy).WithTrace("LIMIT")
+}
+
+func (inner AdmissionHandler) withLoadShedding(limiter Limiter, failurePolicy func(*admissionv1.AdmissionRequest) string) AdmissionHandler {
+	return func(ctx context.Context, logger logr.Logger, request *admissionv1.AdmissionRequest, startTime time.Time) *admissionv1.AdmissionResponse {
+		release, ok := limiter.Acquire(ctx, request)
+		if !ok {
+			return shedResponse(request, failurePolicy(request))
+		}
+		defer release()
+		return inner(ctx, logger, request, startTime)
+	}
+}
+
+func shedResponse(request *admissionv1.AdmissionRequest, failurePolicy string) *admissionv1.AdmissionResponse {
+	if failurePolicy == "ignore" {
+		return admissionutils.ResponseSuccess(request.UID, "kyverno is overloaded, policies were not applied")
+	}
+	response := admissionutils.Response(request.UID, errors.New("kyverno is overloaded, request rejected"))
+	response.Result.Code = http.StatusTooManyRequests
+	response.Result.Reason = metav1.StatusReasonTooManyRequests
+	return response
+}
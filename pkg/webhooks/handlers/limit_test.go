@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/metric"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+func newLimitedRequest(namespace string) *admissionv1.AdmissionRequest {
+	return &admissionv1.AdmissionRequest{UID: "uid", Namespace: namespace, Operation: admissionv1.Create}
+}
+
+func acquireAsync(l Limiter, ctx context.Context, request *admissionv1.AdmissionRequest) chan bool {
+	result := make(chan bool, 1)
+	go func() {
+		release, ok := l.Acquire(ctx, request)
+		if ok {
+			release()
+		}
+		result <- ok
+	}()
+	return result
+}
+
+func waitQueued(t *testing.T, l Limiter, high, low int) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		_, h, d := l.(*limiter).stats()
+		if h == high && d == low {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected %d high and %d default priority requests to be queued", high, low)
+}
+
+func Test_NewLimiter(t *testing.T) {
+	assert.Assert(t, NewLimiter(logr.Discard(), metric.NewNoopMeterProvider(), 0, 10) == nil)
+	assert.Assert(t, NewLimiter(logr.Discard(), metric.NewNoopMeterProvider(), 1, 10) != nil)
+}
+
+func Test_Limiter(t *testing.T) {
+	l := NewLimiter(logr.Discard(), metric.NewNoopMeterProvider(), 1, 1, "kube-*")
+	release, ok := l.Acquire(context.TODO(), newLimitedRequest("default"))
+	assert.Assert(t, ok)
+	// waits for the slot
+	waiting := acquireAsync(l, context.TODO(), newLimitedRequest("default"))
+	waitQueued(t, l, 0, 1)
+	// the queue is full
+	_, ok = l.Acquire(context.TODO(), newLimitedRequest("default"))
+	assert.Assert(t, !ok)
+	// high priority requests take the place of waiting default priority requests
+	priority := acquireAsync(l, context.TODO(), newLimitedRequest("kube-system"))
+	assert.Equal(t, <-waiting, false)
+	waitQueued(t, l, 1, 0)
+	release()
+	assert.Equal(t, <-priority, true)
+	inFlight, high, low := l.(*limiter).stats()
+	assert.Equal(t, inFlight, 0)
+	assert.Equal(t, high, 0)
+	assert.Equal(t, low, 0)
+}
+
+func Test_Limiter_Cancel(t *testing.T) {
+	l := NewLimiter(logr.Discard(), metric.NewNoopMeterProvider(), 1, 1)
+	release, ok := l.Acquire(context.TODO(), newLimitedRequest("default"))
+	assert.Assert(t, ok)
+	ctx, cancel := context.WithCancel(context.TODO())
+	waiting := acquireAsync(l, ctx, newLimitedRequest("default"))
+	waitQueued(t, l, 0, 1)
+	cancel()
+	assert.Equal(t, <-waiting, false)
+	waitQueued(t, l, 0, 0)
+	release()
+	inFlight, _, _ := l.(*limiter).stats()
+	assert.Equal(t, inFlight, 0)
+}
+
+func Test_WithLoadShedding(t *testing.T) {
+	l := NewLimiter(logr.Discard(), metric.NewNoopMeterProvider(), 1, 0)
+	var inner AdmissionHandler = func(context.Context, logr.Logger, *admissionv1.AdmissionRequest, time.Time) *admissionv1.AdmissionResponse {
+		return nil
+	}
+	release, ok := l.Acquire(context.TODO(), newLimitedRequest("default"))
+	assert.Assert(t, ok)
+	response := inner.withLoadShedding(l, func(*admissionv1.AdmissionRequest) string { return "ignore" })(context.TODO(), logr.Discard(), newLimitedRequest("default"), time.Now())
+	assert.Assert(t, response.Allowed)
+	assert.Equal(t, len(response.Warnings), 1)
+	response = inner.withLoadShedding(l, func(*admissionv1.AdmissionRequest) string { return "fail" })(context.TODO(), logr.Discard(), newLimitedRequest("default"), time.Now())
+	assert.Assert(t, !response.Allowed)
+	assert.Equal(t, response.Result.Code, int32(http.StatusTooManyRequests))
+	release()
+	response = inner.withLoadShedding(l, func(*admissionv1.AdmissionRequest) string { return "fail" })(context.TODO(), logr.Discard(), newLimitedRequest("default"), time.Now())
+	assert.Assert(t, response == nil)
+}
@@ -0,0 +1,160 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+)
+
+const (
+	ControllerName = "audit-queue"
+	// DefaultWorkers is the default number of workers processing audit work
+	DefaultWorkers = 4
+	// DefaultQueueSize is the default maximum number of pending audit work items
+	DefaultQueueSize = 1000
+)
+
+// Queue runs audit work asynchronously with a bounded number of pending items
+type Queue interface {
+	// Enqueue adds work to the queue, if work with the same key is already pending it is replaced
+	// and if the queue is full the work is dropped
+	Enqueue(ctx context.Context, key string, work func())
+}
+
+type Controller interface {
+	controllers.Controller
+	Queue
+}
+
+type item struct {
+	work   func()
+	queued time.Time
+}
+
+type queue struct {
+	logger logr.Logger
+
+	lock    sync.Mutex
+	keys    chan string
+	pending map[string]item
+
+	// instruments
+	requestsCounter syncint64.Counter
+	latencyMetric   syncfloat64.Histogram
+}
+
+// NewController returns a queue running audit work in a pool of workers when the controller runs,
+// at most size work items can be pending, pending work is coalesced by key
+func NewController(logger logr.Logger, meterProvider metric.MeterProvider, size int) Controller {
+	if size <= 0 {
+		size = DefaultQueueSize
+	}
+	q := &queue{
+		logger:  logger,
+		keys:    make(chan string, size),
+		pending: map[string]item{},
+	}
+	meter := meterProvider.Meter(metrics.MeterName)
+	requestsCounter, err := meter.SyncInt64().Counter(
+		"kyverno_audit_queue_requests",
+		instrument.WithDescription("can be used to track the number of audit work items queued, coalesced with pending work for the same resource or dropped because the queue is full"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_audit_queue_requests")
+	}
+	q.requestsCounter = requestsCounter
+	latencyMetric, err := meter.SyncFloat64().Histogram(
+		"kyverno_audit_queue_latency_seconds",
+		instrument.WithDescription("can be used to track the time (in seconds) audit work items wait in the queue before being processed"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_audit_queue_latency_seconds")
+	}
+	q.latencyMetric = latencyMetric
+	depthMetric, err := meter.AsyncInt64().Gauge(
+		"kyverno_audit_queue_depth",
+		instrument.WithDescription("can be used to track the number of audit work items waiting in the queue"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_audit_queue_depth")
+	} else if err := meter.RegisterCallback([]instrument.Asynchronous{depthMetric}, func(ctx context.Context) {
+		depthMetric.Observe(ctx, int64(q.depth()))
+	}); err != nil {
+		logger.Error(err, "Failed to register callback")
+	}
+	return q
+}
+
+func (q *queue) Enqueue(ctx context.Context, key string, work func()) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if _, ok := q.pending[key]; ok {
+		// the pending work has not started yet, the latest work supersedes it
+		q.pending[key] = item{work: work, queued: q.pending[key].queued}
+		q.count(ctx, "coalesced")
+		return
+	}
+	select {
+	case q.keys <- key:
+		q.pending[key] = item{work: work, queued: time.Now()}
+		q.count(ctx, "queued")
+	default:
+		q.logger.V(3).Info("audit queue is full, dropping work", "key", key)
+		q.count(ctx, "dropped")
+	}
+}
+
+func (q *queue) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			q.worker(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (q *queue) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case key := <-q.keys:
+			q.process(ctx, key)
+		}
+	}
+}
+
+func (q *queue) process(ctx context.Context, key string) {
+	q.lock.Lock()
+	item := q.pending[key]
+	delete(q.pending, key)
+	q.lock.Unlock()
+	if q.latencyMetric != nil {
+		q.latencyMetric.Record(ctx, time.Since(item.queued).Seconds())
+	}
+	item.work()
+}
+
+func (q *queue) depth() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.pending)
+}
+
+func (q *queue) count(ctx context.Context, status string) {
+	if q.requestsCounter != nil {
+		q.requestsCounter.Add(ctx, 1, attribute.String("status", status))
+	}
+}
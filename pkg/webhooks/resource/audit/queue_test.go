@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/metric"
+	"gotest.tools/assert"
+)
+
+func Test_queue(t *testing.T) {
+	q := NewController(logr.Discard(), metric.NewNoopMeterProvider(), 2).(*queue)
+	var lock sync.Mutex
+	var done []string
+	record := func(value string) func() {
+		return func() {
+			lock.Lock()
+			defer lock.Unlock()
+			done = append(done, value)
+		}
+	}
+	q.Enqueue(context.TODO(), "a", record("a1"))
+	// pending work for the same key is replaced
+	q.Enqueue(context.TODO(), "a", record("a2"))
+	q.Enqueue(context.TODO(), "b", record("b1"))
+	// the queue is full
+	q.Enqueue(context.TODO(), "c", record("c1"))
+	assert.Equal(t, q.depth(), 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		q.Run(ctx, 1)
+	}()
+	deadline := time.Now().Add(10 * time.Second)
+	for q.depth() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-stopped
+	assert.DeepEqual(t, done, []string{"a2", "b1"})
+	// keys can be queued again once processed
+	q.Enqueue(context.TODO(), "a", record("a3"))
+	assert.Equal(t, q.depth(), 1)
+}
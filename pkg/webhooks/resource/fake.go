@@ -43,7 +43,6 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) webhook
 
 	return &handlers{
 		client:         dclient,
-		rclient:        rclient,
 		configuration:  configuration,
 		metricsConfig:  metricsConfig,
 		pCache:         policyCache,
@@ -54,8 +53,14 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) webhook
 		urGenerator:    updaterequest.NewFake(),
 		eventGen:       event.NewFake(),
 		openApiManager: openapi.NewFake(),
-		pcBuilder:      webhookutils.NewPolicyContextBuilder(configuration, dclient, rbLister, crbLister, configMapResolver, peLister),
+		pcBuilder:      webhookutils.NewPolicyContextBuilder(configuration, dclient, rbLister, crbLister, configMapResolver),
 		urUpdater:      webhookutils.NewUpdateRequestUpdater(kyvernoclient, urLister),
-		contextLoader:  engine.LegacyContextLoaderFactory(rclient),
+		engine: engine.NewEngine(
+			configuration,
+			rclient,
+			engine.LegacyContextLoaderFactory(rclient),
+			engine.NewExceptionSelector(peLister),
+			nil,
+		),
 	}
 }
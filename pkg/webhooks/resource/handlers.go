@@ -12,19 +12,20 @@ import (
 	kyvernov1beta1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1beta1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
-	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/kyverno/kyverno/pkg/controllers/exceptionusage"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginectx "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/openapi"
 	"github.com/kyverno/kyverno/pkg/policycache"
-	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/sinks"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"github.com/kyverno/kyverno/pkg/webhooks"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource/audit"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/generation"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/imageverification"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/mutation"
@@ -40,8 +41,7 @@ type handlers struct {
 	// clients
 	client        dclient.Interface
 	kyvernoClient versioned.Interface
-	rclient       registryclient.Client
-	contextLoader engine.ContextLoaderFactory
+	engine        engineapi.Engine
 
 	// config
 	configuration config.Configuration
@@ -51,11 +51,10 @@ type handlers struct {
 	pCache policycache.Cache
 
 	// listers
-	nsLister    corev1listers.NamespaceLister
-	rbLister    rbacv1listers.RoleBindingLister
-	crbLister   rbacv1listers.ClusterRoleBindingLister
-	urLister    kyvernov1beta1listers.UpdateRequestNamespaceLister
-	polexLister engine.PolicyExceptionLister
+	nsLister  corev1listers.NamespaceLister
+	rbLister  rbacv1listers.RoleBindingLister
+	crbLister rbacv1listers.ClusterRoleBindingLister
+	urLister  kyvernov1beta1listers.UpdateRequestNamespaceLister
 
 	urGenerator    webhookgenerate.Generator
 	eventGen       event.Interface
@@ -64,13 +63,21 @@ type handlers struct {
 	urUpdater      webhookutils.UpdateRequestUpdater
 
 	admissionReports bool
+
+	exceptionUsage exceptionusage.Recorder
+	resultSink     sinks.Publisher
+
+	// evaluationWorkers is the maximum number of validation and image verification
+	// policies evaluated concurrently for a single admission request
+	evaluationWorkers int
+
+	auditQueue audit.Queue
 }
 
 func NewHandlers(
-	contextLoader engine.ContextLoaderFactory,
+	engine engineapi.Engine,
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
-	rclient registryclient.Client,
 	configuration config.Configuration,
 	metricsConfig metrics.MetricsConfigManager,
 	pCache policycache.Cache,
@@ -79,31 +86,36 @@ func NewHandlers(
 	rbLister rbacv1listers.RoleBindingLister,
 	crbLister rbacv1listers.ClusterRoleBindingLister,
 	urLister kyvernov1beta1listers.UpdateRequestNamespaceLister,
-	polexLister engine.PolicyExceptionLister,
 	urGenerator webhookgenerate.Generator,
 	eventGen event.Interface,
 	openApiManager openapi.ValidateInterface,
 	admissionReports bool,
+	exceptionUsage exceptionusage.Recorder,
+	resultSink sinks.Publisher,
+	evaluationWorkers int,
+	auditQueue audit.Queue,
 ) webhooks.ResourceHandlers {
 	return &handlers{
-		contextLoader:    contextLoader,
-		client:           client,
-		kyvernoClient:    kyvernoClient,
-		rclient:          rclient,
-		configuration:    configuration,
-		metricsConfig:    metricsConfig,
-		pCache:           pCache,
-		nsLister:         nsLister,
-		rbLister:         rbLister,
-		crbLister:        crbLister,
-		urLister:         urLister,
-		polexLister:      polexLister,
-		urGenerator:      urGenerator,
-		eventGen:         eventGen,
-		openApiManager:   openApiManager,
-		pcBuilder:        webhookutils.NewPolicyContextBuilder(configuration, client, rbLister, crbLister, informerCacheResolvers, polexLister),
-		urUpdater:        webhookutils.NewUpdateRequestUpdater(kyvernoClient, urLister),
-		admissionReports: admissionReports,
+		engine:            engine,
+		client:            client,
+		kyvernoClient:     kyvernoClient,
+		configuration:     configuration,
+		metricsConfig:     metricsConfig,
+		pCache:            pCache,
+		nsLister:          nsLister,
+		rbLister:          rbLister,
+		crbLister:         crbLister,
+		urLister:          urLister,
+		urGenerator:       urGenerator,
+		eventGen:          eventGen,
+		openApiManager:    openApiManager,
+		pcBuilder:         webhookutils.NewPolicyContextBuilder(configuration, client, rbLister, crbLister, informerCacheResolvers),
+		urUpdater:         webhookutils.NewUpdateRequestUpdater(kyvernoClient, urLister),
+		admissionReports:  admissionReports,
+		exceptionUsage:    exceptionUsage,
+		resultSink:        resultSink,
+		evaluationWorkers: evaluationWorkers,
+		auditQueue:        auditQueue,
 	}
 }
 
@@ -124,7 +136,7 @@ func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *ad
 	}
 	if len(generatePolicies) == 0 && request.Operation == admissionv1.Update {
 		// handle generate source resource updates
-		gh := generation.NewGenerationHandler(logger, h.client, h.kyvernoClient, h.contextLoader, h.nsLister, h.urLister, h.urGenerator, h.urUpdater, h.eventGen, h.metricsConfig)
+		gh := generation.NewGenerationHandler(logger, h.client, h.kyvernoClient, h.engine, h.nsLister, h.urLister, h.urGenerator, h.urUpdater, h.eventGen, h.metricsConfig)
 		go gh.HandleUpdatesForGenerateRules(context.TODO(), request, []kyvernov1.PolicyInterface{})
 	}
 
@@ -140,12 +152,12 @@ func (h *handlers) Validate(ctx context.Context, logger logr.Logger, request *ad
 		namespaceLabels = engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger)
 	}
 
-	vh := validation.NewValidationHandler(logger, h.kyvernoClient, h.contextLoader, h.pCache, h.pcBuilder, h.eventGen, h.admissionReports, h.metricsConfig, h.configuration)
+	vh := validation.NewValidationHandler(logger, h.kyvernoClient, h.engine, h.pCache, h.pcBuilder, h.eventGen, h.admissionReports, h.metricsConfig, h.configuration, h.exceptionUsage, h.resultSink, h.evaluationWorkers, h.auditQueue)
 
-	ok, msg, warnings := vh.HandleValidation(ctx, request, policies, policyContext, namespaceLabels, startTime)
+	ok, msg, causes, warnings := vh.HandleValidation(ctx, request, policies, policyContext, namespaceLabels, startTime)
 	if !ok {
 		logger.Info("admission request denied")
-		return admissionutils.Response(request.UID, errors.New(msg), warnings...)
+		return admissionutils.ResponseWithCauses(request.UID, errors.New(msg), causes, warnings...)
 	}
 
 	defer h.handleDelete(logger, request)
@@ -174,7 +186,7 @@ func (h *handlers) Mutate(ctx context.Context, logger logr.Logger, request *admi
 	if err := enginectx.MutateResourceWithImageInfo(request.Object.Raw, policyContext.JSONContext()); err != nil {
 		logger.Error(err, "failed to patch images info to resource, policies that mutate images may be impacted")
 	}
-	mh := mutation.NewMutationHandler(logger, h.contextLoader, h.eventGen, h.openApiManager, h.nsLister, h.metricsConfig)
+	mh := mutation.NewMutationHandler(logger, h.engine, h.eventGen, h.openApiManager, h.nsLister, h.metricsConfig, h.exceptionUsage, h.resultSink)
 	mutatePatches, mutateWarnings, err := mh.HandleMutation(ctx, request, mutatePolicies, policyContext, startTime)
 	if err != nil {
 		logger.Error(err, "mutation failed")
@@ -187,11 +199,11 @@ func (h *handlers) Mutate(ctx context.Context, logger logr.Logger, request *admi
 		logger.Error(err, "failed to build policy context")
 		return admissionutils.Response(request.UID, err)
 	}
-	ivh := imageverification.NewImageVerificationHandler(logger, h.kyvernoClient, h.contextLoader, h.rclient, h.eventGen, h.admissionReports, h.configuration)
-	imagePatches, imageVerifyWarnings, err := ivh.Handle(ctx, newRequest, verifyImagesPolicies, policyContext)
+	ivh := imageverification.NewImageVerificationHandler(logger, h.kyvernoClient, h.engine, h.eventGen, h.admissionReports, h.configuration, h.exceptionUsage, h.resultSink, h.evaluationWorkers)
+	imagePatches, imageVerifyWarnings, causes, err := ivh.Handle(ctx, newRequest, verifyImagesPolicies, policyContext)
 	if err != nil {
 		logger.Error(err, "image verification failed")
-		return admissionutils.Response(request.UID, err)
+		return admissionutils.ResponseWithCauses(request.UID, err, causes)
 	}
 	patch := jsonutils.JoinPatches(mutatePatches, imagePatches)
 	var warnings []string
@@ -224,6 +236,25 @@ func (h *handlers) handleDelete(logger logr.Logger, request *admissionv1.Admissi
 	}
 }
 
+func (h *handlers) MutateFailurePolicy(request *admissionv1.AdmissionRequest) string {
+	return h.failurePolicy(request, policycache.Mutate, policycache.VerifyImagesMutate)
+}
+
+func (h *handlers) ValidateFailurePolicy(request *admissionv1.AdmissionRequest) string {
+	return h.failurePolicy(request, policycache.ValidateEnforce, policycache.Mutate, policycache.Generate, policycache.VerifyImagesValidate)
+}
+
+// failurePolicy returns "fail" if a cached policy of the given types matching the request has the Fail failure policy,
+// "ignore" otherwise
+func (h *handlers) failurePolicy(request *admissionv1.AdmissionRequest, policyTypes ...policycache.PolicyType) string {
+	for _, policyType := range policyTypes {
+		if len(filterPolicies("fail", h.pCache.GetPolicies(policyType, request.Kind.Kind, request.Namespace)...)) > 0 {
+			return "fail"
+		}
+	}
+	return "ignore"
+}
+
 func filterPolicies(failurePolicy string, policies ...kyvernov1.PolicyInterface) []kyvernov1.PolicyInterface {
 	var results []kyvernov1.PolicyInterface
 	for _, policy := range policies {
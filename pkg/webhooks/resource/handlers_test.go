@@ -302,6 +302,38 @@ func Test_AdmissionResponseValid(t *testing.T) {
 	policyCache.Unset(key)
 }
 
+func Test_FailurePolicy(t *testing.T) {
+	policyCache := policycache.NewCache()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handlers := NewFakeHandlers(ctx, policyCache)
+
+	var policy kyverno.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(policyCheckLabel), &policy))
+	policy.Spec.ValidationFailureAction = "Enforce"
+	ignore := kyverno.Ignore
+	policy.Spec.FailurePolicy = &ignore
+	policyCache.Set(makeKey(&policy), &policy, map[string]string{})
+
+	request := &v1.AdmissionRequest{
+		Operation: v1.Create,
+		Kind:      metav1.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"},
+		Namespace: "default",
+	}
+	// requests are only rejected if a policy matching them has the Fail failure policy
+	assert.Equal(t, handlers.ValidateFailurePolicy(request), "ignore")
+	assert.Equal(t, handlers.MutateFailurePolicy(request), "ignore")
+
+	fail := kyverno.Fail
+	policy.Spec.FailurePolicy = &fail
+	policyCache.Set(makeKey(&policy), &policy, map[string]string{})
+	assert.Equal(t, handlers.ValidateFailurePolicy(request), "fail")
+	assert.Equal(t, handlers.MutateFailurePolicy(request), "ignore")
+
+	request.Kind.Kind = "ConfigMap"
+	assert.Equal(t, handlers.ValidateFailurePolicy(request), "ignore")
+}
+
 func Test_AdmissionResponseInvalid(t *testing.T) {
 	policyCache := policycache.NewCache()
 	logger := log.WithName("Test_AdmissionResponseInvalid")
@@ -376,6 +408,8 @@ func Test_ImageVerify(t *testing.T) {
 	response := handlers.Mutate(ctx, logger, request, "", time.Now())
 	assert.Equal(t, response.Allowed, false)
 	assert.Equal(t, len(response.Warnings), 0)
+	assert.Assert(t, response.Result.Details != nil)
+	assert.Assert(t, len(response.Result.Details.Causes) > 0)
 
 	var ignore kyverno.FailurePolicyType = kyverno.Ignore
 	policy.Spec.FailurePolicy = &ignore
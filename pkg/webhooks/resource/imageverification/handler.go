@@ -10,10 +10,12 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers/exceptionusage"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/event"
-	"github.com/kyverno/kyverno/pkg/registryclient"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/sinks"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
@@ -27,36 +29,43 @@ import (
 )
 
 type ImageVerificationHandler interface {
-	Handle(context.Context, *admissionv1.AdmissionRequest, []kyvernov1.PolicyInterface, *engine.PolicyContext) ([]byte, []string, error)
+	// Handle verifies the images of the resource, when the request is denied the causes of the denial are returned with the error
+	Handle(context.Context, *admissionv1.AdmissionRequest, []kyvernov1.PolicyInterface, *engine.PolicyContext) ([]byte, []string, []metav1.StatusCause, error)
 }
 
 type imageVerificationHandler struct {
-	kyvernoClient    versioned.Interface
-	contextLoader    engine.ContextLoaderFactory
-	rclient          registryclient.Client
-	log              logr.Logger
-	eventGen         event.Interface
-	admissionReports bool
-	cfg              config.Configuration
+	kyvernoClient     versioned.Interface
+	engine            engineapi.Engine
+	log               logr.Logger
+	eventGen          event.Interface
+	admissionReports  bool
+	cfg               config.Configuration
+	exceptionUsage    exceptionusage.Recorder
+	resultSink        sinks.Publisher
+	evaluationWorkers int
 }
 
 func NewImageVerificationHandler(
 	log logr.Logger,
 	kyvernoClient versioned.Interface,
-	contextLoader engine.ContextLoaderFactory,
-	rclient registryclient.Client,
+	engine engineapi.Engine,
 	eventGen event.Interface,
 	admissionReports bool,
 	cfg config.Configuration,
+	exceptionUsage exceptionusage.Recorder,
+	resultSink sinks.Publisher,
+	evaluationWorkers int,
 ) ImageVerificationHandler {
 	return &imageVerificationHandler{
-		kyvernoClient:    kyvernoClient,
-		contextLoader:    contextLoader,
-		rclient:          rclient,
-		log:              log,
-		eventGen:         eventGen,
-		admissionReports: admissionReports,
-		cfg:              cfg,
+		kyvernoClient:     kyvernoClient,
+		engine:            engine,
+		log:               log,
+		eventGen:          eventGen,
+		admissionReports:  admissionReports,
+		cfg:               cfg,
+		exceptionUsage:    exceptionUsage,
+		resultSink:        resultSink,
+		evaluationWorkers: evaluationWorkers,
 	}
 }
 
@@ -65,13 +74,13 @@ func (h *imageVerificationHandler) Handle(
 	request *admissionv1.AdmissionRequest,
 	policies []kyvernov1.PolicyInterface,
 	policyContext *engine.PolicyContext,
-) ([]byte, []string, error) {
-	ok, message, imagePatches, warnings := h.handleVerifyImages(ctx, h.log, request, policyContext, policies)
+) ([]byte, []string, []metav1.StatusCause, error) {
+	ok, message, causes, imagePatches, warnings := h.handleVerifyImages(ctx, h.log, request, policyContext, policies)
 	if !ok {
-		return nil, nil, errors.New(message)
+		return nil, nil, causes, errors.New(message)
 	}
 	h.log.V(6).Info("images verified", "patches", string(imagePatches), "warnings", warnings)
-	return imagePatches, warnings, nil
+	return imagePatches, warnings, nil, nil
 }
 
 func (h *imageVerificationHandler) handleVerifyImages(
@@ -80,27 +89,48 @@ func (h *imageVerificationHandler) handleVerifyImages(
 	request *admissionv1.AdmissionRequest,
 	policyContext *engine.PolicyContext,
 	policies []kyvernov1.PolicyInterface,
-) (bool, string, []byte, []string) {
+) (bool, string, []metav1.StatusCause, []byte, []string) {
 	if len(policies) == 0 {
-		return true, "", nil, nil
+		return true, "", nil, nil, nil
 	}
+	type result struct {
+		response *engineapi.EngineResponse
+		metadata *engineapi.ImageVerificationMetadata
+	}
+	// policies are verified independently against the same resource, they can be evaluated concurrently
+	results := webhookutils.EvaluatePolicies(
+		ctx,
+		h.evaluationWorkers,
+		policyContext,
+		policies,
+		func(ctx context.Context, policy kyvernov1.PolicyInterface, policyContext *engine.PolicyContext) result {
+			var r result
+			tracing.ChildSpan(
+				ctx,
+				"",
+				fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
+				func(ctx context.Context, span trace.Span) {
+					policyContext := policyContext.WithPolicy(policy)
+					r.response, r.metadata = h.engine.VerifyAndPatchImages(ctx, policyContext)
+				},
+			)
+			return r
+		},
+	)
 	var engineResponses []*engineapi.EngineResponse
 	var patches [][]byte
-	verifiedImageData := &engine.ImageVerificationMetadata{}
-	for _, policy := range policies {
-		tracing.ChildSpan(
-			ctx,
-			"",
-			fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
-			func(ctx context.Context, span trace.Span) {
-				policyContext := policyContext.WithPolicy(policy)
-				resp, ivm := engine.VerifyAndPatchImages(ctx, h.contextLoader, h.rclient, policyContext, h.cfg)
-
-				engineResponses = append(engineResponses, resp)
-				patches = append(patches, resp.GetPatches()...)
-				verifiedImageData.Merge(ivm)
-			},
-		)
+	verifiedImageData := &engineapi.ImageVerificationMetadata{}
+	for _, r := range results {
+		engineResponses = append(engineResponses, r.response)
+		patches = append(patches, r.response.GetPatches()...)
+		verifiedImageData.Merge(r.metadata)
+	}
+
+	if h.exceptionUsage != nil {
+		h.exceptionUsage.Record(ctx, metrics.AdmissionRequest, engineResponses...)
+	}
+	if h.resultSink != nil {
+		h.resultSink.Record(ctx, metrics.AdmissionRequest, engineResponses...)
 	}
 
 	failurePolicy := policies[0].GetSpec().GetFailurePolicy()
@@ -112,7 +142,7 @@ func (h *imageVerificationHandler) handleVerifyImages(
 
 	if blocked {
 		logger.V(4).Info("admission request blocked")
-		return false, webhookutils.GetBlockedMessages(engineResponses), nil, nil
+		return false, webhookutils.GetBlockedMessages(engineResponses), webhookutils.GetBlockedCauses(engineResponses), nil, nil
 	}
 
 	if !verifiedImageData.IsEmpty() {
@@ -129,7 +159,7 @@ func (h *imageVerificationHandler) handleVerifyImages(
 	go h.handleAudit(ctx, policyContext.NewResource(), request, nil, engineResponses...)
 
 	warnings := webhookutils.GetWarningMessages(engineResponses)
-	return true, "", jsonutils.JoinPatches(patches...), warnings
+	return true, "", nil, jsonutils.JoinPatches(patches...), warnings
 }
 
 func hasAnnotations(context *engine.PolicyContext) bool {
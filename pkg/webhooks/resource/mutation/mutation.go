@@ -8,11 +8,13 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/controllers/exceptionusage"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/openapi"
+	"github.com/kyverno/kyverno/pkg/sinks"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	"github.com/kyverno/kyverno/pkg/utils"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
@@ -35,29 +37,35 @@ type MutationHandler interface {
 
 func NewMutationHandler(
 	log logr.Logger,
-	contextLoader engine.ContextLoaderFactory,
+	engine engineapi.Engine,
 	eventGen event.Interface,
 	openApiManager openapi.ValidateInterface,
 	nsLister corev1listers.NamespaceLister,
 	metrics metrics.MetricsConfigManager,
+	exceptionUsage exceptionusage.Recorder,
+	resultSink sinks.Publisher,
 ) MutationHandler {
 	return &mutationHandler{
 		log:            log,
-		contextLoader:  contextLoader,
+		engine:         engine,
 		eventGen:       eventGen,
 		openApiManager: openApiManager,
 		nsLister:       nsLister,
 		metrics:        metrics,
+		exceptionUsage: exceptionUsage,
+		resultSink:     resultSink,
 	}
 }
 
 type mutationHandler struct {
 	log            logr.Logger
-	contextLoader  engine.ContextLoaderFactory
+	engine         engineapi.Engine
 	eventGen       event.Interface
 	openApiManager openapi.ValidateInterface
 	nsLister       corev1listers.NamespaceLister
 	metrics        metrics.MetricsConfigManager
+	exceptionUsage exceptionusage.Recorder
+	resultSink     sinks.Publisher
 }
 
 func (h *mutationHandler) HandleMutation(
@@ -136,6 +144,13 @@ func (v *mutationHandler) applyMutations(
 		}
 	}
 
+	if v.exceptionUsage != nil {
+		v.exceptionUsage.Record(ctx, metrics.AdmissionRequest, engineResponses...)
+	}
+	if v.resultSink != nil {
+		v.resultSink.Record(ctx, metrics.AdmissionRequest, engineResponses...)
+	}
+
 	// generate annotations
 	if annPatches := utils.GenerateAnnotationPatches(engineResponses, v.log); annPatches != nil {
 		patches = append(patches, annPatches...)
@@ -157,7 +172,7 @@ func (h *mutationHandler) applyMutation(ctx context.Context, request *admissionv
 		policyContext = policyContext.WithNamespaceLabels(engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, h.log))
 	}
 
-	engineResponse := engine.Mutate(ctx, h.contextLoader, policyContext)
+	engineResponse := h.engine.Mutate(ctx, policyContext)
 	policyPatches := engineResponse.GetPatches()
 
 	if !engineResponse.IsSuccessful() {
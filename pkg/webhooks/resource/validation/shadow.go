@@ -0,0 +1,146 @@
+package validation
+
+import (
+	"context"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+// shadowResult holds the response of a shadow policy and how it compares with the policy it shadows
+type shadowResult struct {
+	response     *engineapi.EngineResponse
+	liveResult   string
+	shadowResult string
+}
+
+func (r shadowResult) disagrees() bool {
+	return r.liveResult != r.shadowResult
+}
+
+func (v *validationHandler) hasShadowPolicies(request *admissionv1.AdmissionRequest) bool {
+	return len(v.pCache.GetPolicies(policycache.ValidateShadow, request.Kind.Kind, request.Namespace)) != 0
+}
+
+// evaluateShadows evaluates the shadow policies matching the request and compares their results with
+// the responses of the live policies, live policies without a response are evaluated if needed
+func (v *validationHandler) evaluateShadows(
+	ctx context.Context,
+	request *admissionv1.AdmissionRequest,
+	namespaceLabels map[string]string,
+	liveResponses []*engineapi.EngineResponse,
+) []shadowResult {
+	shadows := v.pCache.GetPolicies(policycache.ValidateShadow, request.Kind.Kind, request.Namespace)
+	if len(shadows) == 0 {
+		return nil
+	}
+	policyContext, err := v.pcBuilder.Build(request)
+	if err != nil {
+		v.log.Error(err, "failed to build policy context for shadow policies")
+		return nil
+	}
+	var missing []kyvernov1.PolicyInterface
+	for _, live := range v.pCache.GetPolicies(policycache.ValidateAudit, request.Kind.Kind, request.Namespace) {
+		for _, shadow := range shadows {
+			if isShadowOf(shadow, live) && findResponse(liveResponses, live) == nil {
+				missing = append(missing, live)
+				break
+			}
+		}
+	}
+	liveResponses = append(liveResponses, v.validate(ctx, policyContext, missing, namespaceLabels)...)
+	var results []shadowResult
+	for _, response := range v.validate(ctx, policyContext, shadows, namespaceLabels) {
+		if response == nil {
+			continue
+		}
+		shadow := response.Policy
+		var live *engineapi.EngineResponse
+		for _, candidate := range liveResponses {
+			if candidate != nil && candidate.Policy != nil && isShadowOf(shadow, candidate.Policy) {
+				live = candidate
+				break
+			}
+		}
+		result := shadowResult{
+			response:     response,
+			liveResult:   policyResult(live),
+			shadowResult: policyResult(response),
+		}
+		if result.disagrees() {
+			v.log.V(2).Info("shadow policy disagrees with live policy", "policy", shadow.GetName(), "shadowOf", shadow.GetSpec().ShadowOf, "liveResult", result.liveResult, "shadowResult", result.shadowResult)
+		}
+		if v.metrics != nil {
+			operation, _ := metrics.ParseResourceRequestOperation(string(request.Operation))
+			v.metrics.RecordShadowPolicyResults(ctx, shadow.GetNamespace(), shadow.GetName(), shadow.GetSpec().ShadowOf, request.Kind.Kind, request.Namespace, operation, result.liveResult, result.shadowResult)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// addShadowResults adds the results of shadow policies disagreeing with their live policy to the report
+func addShadowResults(report kyvernov1alpha2.ReportInterface, shadowResults ...shadowResult) {
+	results := report.GetResults()
+	for _, shadow := range shadowResults {
+		if !shadow.disagrees() {
+			continue
+		}
+		reportutils.SetPolicyLabel(report, shadow.response.Policy)
+		for _, result := range reportutils.EngineResponseToReportResults(shadow.response) {
+			// results of shadow policies always have the shadowOf property
+			result.Properties["liveResult"] = shadow.liveResult
+			result.Properties["shadowResult"] = shadow.shadowResult
+			results = append(results, result)
+		}
+	}
+	reportutils.SetResults(report, results...)
+}
+
+func isShadowOf(shadow, live kyvernov1.PolicyInterface) bool {
+	return !live.GetSpec().IsShadow() &&
+		live.GetName() == shadow.GetSpec().ShadowOf &&
+		live.GetNamespace() == shadow.GetNamespace() &&
+		live.IsNamespaced() == shadow.IsNamespaced()
+}
+
+func findResponse(responses []*engineapi.EngineResponse, policy kyvernov1.PolicyInterface) *engineapi.EngineResponse {
+	for _, response := range responses {
+		if response != nil && response.Policy != nil &&
+			response.Policy.GetName() == policy.GetName() &&
+			response.Policy.GetNamespace() == policy.GetNamespace() {
+			return response
+		}
+	}
+	return nil
+}
+
+// policyResult summarizes the outcome of a policy evaluation, a missing response is considered skipped
+func policyResult(response *engineapi.EngineResponse) string {
+	if response == nil {
+		return string(engineapi.RuleStatusSkip)
+	}
+	result := engineapi.RuleStatusSkip
+	for _, rule := range response.PolicyResponse.Rules {
+		switch rule.Status {
+		case engineapi.RuleStatusFail:
+			return string(engineapi.RuleStatusFail)
+		case engineapi.RuleStatusError, engineapi.RuleStatusTimeout:
+			result = engineapi.RuleStatusError
+		case engineapi.RuleStatusWarn:
+			if result != engineapi.RuleStatusError {
+				result = engineapi.RuleStatusWarn
+			}
+		case engineapi.RuleStatusPass:
+			if result == engineapi.RuleStatusSkip {
+				result = engineapi.RuleStatusPass
+			}
+		}
+	}
+	return string(result)
+}
@@ -0,0 +1,177 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov1alpha2 "github.com/kyverno/kyverno/api/kyverno/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/registryclient"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newResponse(policy kyvernov1.PolicyInterface, statuses ...engineapi.RuleStatus) *engineapi.EngineResponse {
+	response := &engineapi.EngineResponse{Policy: policy}
+	for _, status := range statuses {
+		response.PolicyResponse.Rules = append(response.PolicyResponse.Rules, engineapi.RuleResponse{Name: "rule", Status: status})
+	}
+	return response
+}
+
+func Test_policyResult(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{}
+	assert.Equal(t, policyResult(nil), "skip")
+	assert.Equal(t, policyResult(newResponse(policy)), "skip")
+	assert.Equal(t, policyResult(newResponse(policy, engineapi.RuleStatusSkip, engineapi.RuleStatusPass)), "pass")
+	assert.Equal(t, policyResult(newResponse(policy, engineapi.RuleStatusPass, engineapi.RuleStatusError)), "error")
+	assert.Equal(t, policyResult(newResponse(policy, engineapi.RuleStatusError, engineapi.RuleStatusFail, engineapi.RuleStatusPass)), "fail")
+}
+
+func Test_isShadowOf(t *testing.T) {
+	live := &kyvernov1.ClusterPolicy{}
+	live.SetName("live")
+	shadow := &kyvernov1.ClusterPolicy{}
+	shadow.SetName("shadow")
+	shadow.Spec.ShadowOf = "live"
+	assert.Assert(t, isShadowOf(shadow, live))
+	assert.Assert(t, !isShadowOf(live, shadow))
+	namespaced := &kyvernov1.Policy{}
+	namespaced.SetName("live")
+	namespaced.SetNamespace("default")
+	assert.Assert(t, !isShadowOf(shadow, namespaced))
+}
+
+func Test_addShadowResults(t *testing.T) {
+	shadow := &kyvernov1.ClusterPolicy{}
+	shadow.SetName("shadow")
+	shadow.Spec.ShadowOf = "live"
+	report := &kyvernov1alpha2.AdmissionReport{}
+	addShadowResults(report,
+		shadowResult{response: newResponse(shadow, engineapi.RuleStatusPass), liveResult: "pass", shadowResult: "pass"},
+		shadowResult{response: newResponse(shadow, engineapi.RuleStatusFail), liveResult: "pass", shadowResult: "fail"},
+	)
+	results := report.GetResults()
+	assert.Equal(t, len(results), 1)
+	assert.Equal(t, string(results[0].Result), "fail")
+	assert.Equal(t, results[0].Properties["shadowOf"], "live")
+	assert.Equal(t, results[0].Properties["liveResult"], "pass")
+	assert.Equal(t, results[0].Properties["shadowResult"], "fail")
+}
+
+// syncQueue runs the audit work immediately
+type syncQueue struct{}
+
+func (syncQueue) Enqueue(_ context.Context, _ string, work func()) { work() }
+
+// shadowMetrics records the results of shadow policies
+type shadowMetrics struct {
+	*metrics.MetricsConfig
+	lock    sync.Mutex
+	results []string
+}
+
+func (m *shadowMetrics) RecordShadowPolicyResults(_ context.Context, _ string, policyName string, shadowOf string, _ string, _ string, _ metrics.ResourceRequestOperation, liveResult string, shadowResult string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.results = append(m.results, policyName+"/"+shadowOf+"/"+liveResult+"/"+shadowResult)
+}
+
+func newPolicy(t *testing.T, raw string) *kyvernov1.ClusterPolicy {
+	var policy kyvernov1.ClusterPolicy
+	assert.NilError(t, json.Unmarshal([]byte(raw), &policy))
+	return &policy
+}
+
+func Test_HandleValidation_shadowOfDeniedRequest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	live := newPolicy(t, `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "require-team"},
+		"spec": {
+			"validationFailureAction": "Enforce",
+			"rules": [{
+				"name": "require-team",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {"message": "label team is required", "pattern": {"metadata": {"labels": {"team": "?*"}}}}
+			}]
+		}
+	}`)
+	shadow := newPolicy(t, `{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "require-name"},
+		"spec": {
+			"shadowOf": "require-team",
+			"rules": [{
+				"name": "require-name",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {"message": "a name is required", "pattern": {"metadata": {"name": "?*"}}}
+			}]
+		}
+	}`)
+	pCache := policycache.NewCache()
+	pCache.Set(live.GetName(), live, map[string]string{})
+	pCache.Set(shadow.GetName(), shadow, map[string]string{})
+	client := fake.NewSimpleClientset()
+	informers := kubeinformers.NewSharedInformerFactory(client, 0)
+	rbLister := informers.Rbac().V1().RoleBindings().Lister()
+	crbLister := informers.Rbac().V1().ClusterRoleBindings().Lister()
+	informers.Start(ctx.Done())
+	configuration := config.NewDefaultConfiguration()
+	rclient := registryclient.NewOrDie()
+	pcBuilder := webhookutils.NewPolicyContextBuilder(configuration, dclient.NewEmptyFakeClient(), rbLister, crbLister, nil)
+	recorder := &shadowMetrics{MetricsConfig: metrics.NewFakeMetricsConfig()}
+	handler := NewValidationHandler(
+		logr.Discard(),
+		nil,
+		engine.NewEngine(configuration, rclient, engine.LegacyContextLoaderFactory(rclient), nil, nil),
+		pCache,
+		pcBuilder,
+		event.NewFake(),
+		false,
+		recorder,
+		configuration,
+		nil,
+		nil,
+		0,
+		syncQueue{},
+	)
+	request := &admissionv1.AdmissionRequest{
+		UID:       "uid",
+		Operation: admissionv1.Create,
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "pods"},
+		Object: runtime.RawExtension{
+			Raw: []byte(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "nginx"}, "spec": {"containers": [{"name": "nginx", "image": "nginx"}]}}`),
+		},
+		RequestResource: &metav1.GroupVersionResource{Version: "v1", Resource: "pods"},
+	}
+	policyContext, err := pcBuilder.Build(request)
+	assert.NilError(t, err)
+	policies := pCache.GetPolicies(policycache.ValidateEnforce, "Pod", "")
+	assert.Equal(t, len(policies), 1)
+	allowed, _, _, _ := handler.HandleValidation(ctx, request, policies, policyContext, nil, time.Now())
+	assert.Assert(t, !allowed)
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	assert.DeepEqual(t, recorder.results, []string{"require-name/require-team/fail/pass"})
+}
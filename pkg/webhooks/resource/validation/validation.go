@@ -10,15 +10,18 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers/exceptionusage"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/sinks"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"github.com/kyverno/kyverno/pkg/webhooks/resource/audit"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	"go.opentelemetry.io/otel/trace"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -31,43 +34,55 @@ type ValidationHandler interface {
 	// HandleValidation handles validating webhook admission request
 	// If there are no errors in validating rule we apply generation rules
 	// patchedResource is the (resource + patches) after applying mutation rules
-	HandleValidation(context.Context, *admissionv1.AdmissionRequest, []kyvernov1.PolicyInterface, *engine.PolicyContext, map[string]string, time.Time) (bool, string, []string)
+	HandleValidation(context.Context, *admissionv1.AdmissionRequest, []kyvernov1.PolicyInterface, *engine.PolicyContext, map[string]string, time.Time) (bool, string, []metav1.StatusCause, []string)
 }
 
 func NewValidationHandler(
 	log logr.Logger,
 	kyvernoClient versioned.Interface,
-	contextLoader engine.ContextLoaderFactory,
+	engine engineapi.Engine,
 	pCache policycache.Cache,
 	pcBuilder webhookutils.PolicyContextBuilder,
 	eventGen event.Interface,
 	admissionReports bool,
 	metrics metrics.MetricsConfigManager,
 	cfg config.Configuration,
+	exceptionUsage exceptionusage.Recorder,
+	resultSink sinks.Publisher,
+	evaluationWorkers int,
+	auditQueue audit.Queue,
 ) ValidationHandler {
 	return &validationHandler{
-		log:              log,
-		kyvernoClient:    kyvernoClient,
-		contextLoader:    contextLoader,
-		pCache:           pCache,
-		pcBuilder:        pcBuilder,
-		eventGen:         eventGen,
-		admissionReports: admissionReports,
-		metrics:          metrics,
-		cfg:              cfg,
+		log:               log,
+		kyvernoClient:     kyvernoClient,
+		engine:            engine,
+		pCache:            pCache,
+		pcBuilder:         pcBuilder,
+		eventGen:          eventGen,
+		admissionReports:  admissionReports,
+		metrics:           metrics,
+		cfg:               cfg,
+		exceptionUsage:    exceptionUsage,
+		resultSink:        resultSink,
+		evaluationWorkers: evaluationWorkers,
+		auditQueue:        auditQueue,
 	}
 }
 
 type validationHandler struct {
-	log              logr.Logger
-	kyvernoClient    versioned.Interface
-	contextLoader    engine.ContextLoaderFactory
-	pCache           policycache.Cache
-	pcBuilder        webhookutils.PolicyContextBuilder
-	eventGen         event.Interface
-	admissionReports bool
-	metrics          metrics.MetricsConfigManager
-	cfg              config.Configuration
+	log               logr.Logger
+	kyvernoClient     versioned.Interface
+	engine            engineapi.Engine
+	pCache            policycache.Cache
+	pcBuilder         webhookutils.PolicyContextBuilder
+	eventGen          event.Interface
+	admissionReports  bool
+	metrics           metrics.MetricsConfigManager
+	cfg               config.Configuration
+	exceptionUsage    exceptionusage.Recorder
+	resultSink        sinks.Publisher
+	evaluationWorkers int
+	auditQueue        audit.Queue
 }
 
 func (v *validationHandler) HandleValidation(
@@ -77,11 +92,11 @@ func (v *validationHandler) HandleValidation(
 	policyContext *engine.PolicyContext,
 	namespaceLabels map[string]string,
 	admissionRequestTimestamp time.Time,
-) (bool, string, []string) {
+) (bool, string, []metav1.StatusCause, []string) {
 	if len(policies) == 0 {
-		// invoke handleAudit as we may have some policies in audit mode to consider
-		go v.handleAudit(ctx, policyContext.NewResource(), request, namespaceLabels)
-		return true, "", nil
+		// queue the audit as we may have some policies in audit mode to consider
+		v.enqueueAudit(ctx, policyContext.NewResource(), request, namespaceLabels)
+		return true, "", nil, nil
 	}
 
 	resourceName := admissionutils.GetResourceName(request)
@@ -97,43 +112,44 @@ func (v *validationHandler) HandleValidation(
 	}
 
 	if deletionTimeStamp != nil && request.Operation == admissionv1.Update {
-		return true, "", nil
+		return true, "", nil, nil
 	}
 
-	var engineResponses []*engineapi.EngineResponse
 	failurePolicy := kyvernov1.Ignore
 	for _, policy := range policies {
-		tracing.ChildSpan(
-			ctx,
-			"pkg/webhooks/resource/validate",
-			fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
-			func(ctx context.Context, span trace.Span) {
-				policyContext := policyContext.WithPolicy(policy).WithNamespaceLabels(namespaceLabels)
-				if policy.GetSpec().GetFailurePolicy() == kyvernov1.Fail {
-					failurePolicy = kyvernov1.Fail
-				}
+		if policy.GetSpec().GetFailurePolicy() == kyvernov1.Fail {
+			failurePolicy = kyvernov1.Fail
+		}
+	}
 
-				engineResponse := engine.Validate(ctx, v.contextLoader, policyContext, v.cfg)
-				if engineResponse.IsNil() {
-					// we get an empty response if old and new resources created the same response
-					// allow updates if resource update doesnt change the policy evaluation
-					return
-				}
+	var engineResponses []*engineapi.EngineResponse
+	for _, engineResponse := range v.validate(ctx, policyContext, policies, namespaceLabels) {
+		if engineResponse.IsNil() {
+			// we get an empty response if old and new resources created the same response
+			// allow updates if resource update doesnt change the policy evaluation
+			continue
+		}
+		policy := engineResponse.Policy
 
-				go webhookutils.RegisterPolicyResultsMetricValidation(ctx, logger, v.metrics, string(request.Operation), policyContext.Policy(), *engineResponse)
-				go webhookutils.RegisterPolicyExecutionDurationMetricValidate(ctx, logger, v.metrics, string(request.Operation), policyContext.Policy(), *engineResponse)
+		go webhookutils.RegisterPolicyResultsMetricValidation(ctx, logger, v.metrics, string(request.Operation), policy, *engineResponse)
+		go webhookutils.RegisterPolicyExecutionDurationMetricValidate(ctx, logger, v.metrics, string(request.Operation), policy, *engineResponse)
 
-				engineResponses = append(engineResponses, engineResponse)
-				if !engineResponse.IsSuccessful() {
-					logger.V(2).Info("validation failed", "action", policy.GetSpec().ValidationFailureAction, "policy", policy.GetName(), "failed rules", engineResponse.GetFailedRules())
-					return
-				}
+		engineResponses = append(engineResponses, engineResponse)
+		if !engineResponse.IsSuccessful() {
+			logger.V(2).Info("validation failed", "action", policy.GetSpec().ValidationFailureAction, "policy", policy.GetName(), "failed rules", engineResponse.GetFailedRules())
+			continue
+		}
 
-				if len(engineResponse.GetSuccessRules()) > 0 {
-					logger.V(2).Info("validation passed", "policy", policy.GetName())
-				}
-			},
-		)
+		if len(engineResponse.GetSuccessRules()) > 0 {
+			logger.V(2).Info("validation passed", "policy", policy.GetName())
+		}
+	}
+
+	if v.exceptionUsage != nil {
+		v.exceptionUsage.Record(ctx, metrics.AdmissionRequest, engineResponses...)
+	}
+	if v.resultSink != nil {
+		v.resultSink.Record(ctx, metrics.AdmissionRequest, engineResponses...)
 	}
 
 	blocked := webhookutils.BlockRequest(engineResponses, failurePolicy, logger)
@@ -144,13 +160,14 @@ func (v *validationHandler) HandleValidation(
 
 	if blocked {
 		logger.V(4).Info("admission request blocked")
-		return false, webhookutils.GetBlockedMessages(engineResponses), nil
+		v.enqueueShadows(ctx, request, namespaceLabels, engineResponses...)
+		return false, webhookutils.GetBlockedMessages(engineResponses), webhookutils.GetBlockedCauses(engineResponses), nil
 	}
 
-	go v.handleAudit(ctx, policyContext.NewResource(), request, namespaceLabels, engineResponses...)
+	v.enqueueAudit(ctx, policyContext.NewResource(), request, namespaceLabels, engineResponses...)
 
 	warnings := webhookutils.GetWarningMessages(engineResponses)
-	return true, "", warnings
+	return true, "", nil, warnings
 }
 
 func (v *validationHandler) buildAuditResponses(
@@ -164,62 +181,149 @@ func (v *validationHandler) buildAuditResponses(
 	if err != nil {
 		return nil, err
 	}
-	var responses []*engineapi.EngineResponse
-	for _, policy := range policies {
-		tracing.ChildSpan(
-			ctx,
-			"pkg/webhooks/resource/validate",
-			fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
-			func(ctx context.Context, span trace.Span) {
-				policyContext := policyContext.WithPolicy(policy).WithNamespaceLabels(namespaceLabels)
-				responses = append(responses, engine.Validate(ctx, v.contextLoader, policyContext, v.cfg))
-			},
-		)
-	}
-	return responses, nil
+	return v.validate(ctx, policyContext, policies, namespaceLabels), nil
 }
 
-func (v *validationHandler) handleAudit(
+// validate evaluates the policies, concurrently when enabled, the responses are returned in the order of the policies
+func (v *validationHandler) validate(
+	ctx context.Context,
+	policyContext *engine.PolicyContext,
+	policies []kyvernov1.PolicyInterface,
+	namespaceLabels map[string]string,
+) []*engineapi.EngineResponse {
+	return webhookutils.EvaluatePolicies(
+		ctx,
+		v.evaluationWorkers,
+		policyContext,
+		policies,
+		func(ctx context.Context, policy kyvernov1.PolicyInterface, policyContext *engine.PolicyContext) *engineapi.EngineResponse {
+			var response *engineapi.EngineResponse
+			tracing.ChildSpan(
+				ctx,
+				"pkg/webhooks/resource/validate",
+				fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
+				func(ctx context.Context, span trace.Span) {
+					policyContext := policyContext.WithPolicy(policy).WithNamespaceLabels(namespaceLabels)
+					response = v.engine.Validate(ctx, policyContext)
+				},
+			)
+			return response
+		},
+	)
+}
+
+// enqueueAudit queues the audit of the admission request, pending audits of the same resource are coalesced
+func (v *validationHandler) enqueueAudit(
 	ctx context.Context,
 	resource unstructured.Unstructured,
 	request *admissionv1.AdmissionRequest,
 	namespaceLabels map[string]string,
 	engineResponses ...*engineapi.EngineResponse,
 ) {
-	if !v.admissionReports {
+	// shadow policies are evaluated with the audit, even when the request doesn't need to be audited
+	if !v.needsAudit(request) && !v.hasShadowPolicies(request) {
 		return
 	}
-	if request.DryRun != nil && *request.DryRun {
+	if v.auditQueue == nil {
+		go v.handleAudit(ctx, resource, request, namespaceLabels, engineResponses...)
 		return
 	}
-	// we don't need reports for deletions
-	if request.Operation == admissionv1.Delete {
+	key := string(resource.GetUID())
+	if key == "" {
+		key = string(request.UID)
+	}
+	v.auditQueue.Enqueue(ctx, key, func() {
+		v.handleAudit(ctx, resource, request, namespaceLabels, engineResponses...)
+	})
+}
+
+// enqueueShadows queues the evaluation of the shadow policies of a denied request, the resource is not
+// admitted so no report is created, only the results of the shadow policies are recorded
+func (v *validationHandler) enqueueShadows(
+	ctx context.Context,
+	request *admissionv1.AdmissionRequest,
+	namespaceLabels map[string]string,
+	engineResponses ...*engineapi.EngineResponse,
+) {
+	if !v.hasShadowPolicies(request) {
 		return
 	}
-	// check if the resource supports reporting
-	if !reportutils.IsGvkSupported(schema.GroupVersionKind(request.Kind)) {
+	work := func() {
+		tracing.Span(
+			context.Background(),
+			"",
+			fmt.Sprintf("SHADOW %s %s", request.Operation, request.Kind),
+			func(ctx context.Context, span trace.Span) {
+				v.evaluateShadows(ctx, request, namespaceLabels, engineResponses)
+			},
+			trace.WithLinks(trace.LinkFromContext(ctx)),
+		)
+	}
+	if v.auditQueue == nil {
+		go work()
 		return
 	}
+	v.auditQueue.Enqueue(ctx, string(request.UID), work)
+}
+
+func (v *validationHandler) needsAudit(request *admissionv1.AdmissionRequest) bool {
+	if !v.admissionReports {
+		return false
+	}
+	if request.DryRun != nil && *request.DryRun {
+		return false
+	}
+	// we don't need reports for deletions
+	if request.Operation == admissionv1.Delete {
+		return false
+	}
+	// check if the resource supports reporting
+	return reportutils.IsGvkSupported(schema.GroupVersionKind(request.Kind))
+}
+
+func (v *validationHandler) handleAudit(
+	ctx context.Context,
+	resource unstructured.Unstructured,
+	request *admissionv1.AdmissionRequest,
+	namespaceLabels map[string]string,
+	engineResponses ...*engineapi.EngineResponse,
+) {
 	tracing.Span(
 		context.Background(),
 		"",
 		fmt.Sprintf("AUDIT %s %s", request.Operation, request.Kind),
 		func(ctx context.Context, span trace.Span) {
-			responses, err := v.buildAuditResponses(ctx, resource, request, namespaceLabels)
-			if err != nil {
-				v.log.Error(err, "failed to build audit responses")
+			audit := v.needsAudit(request)
+			var responses []*engineapi.EngineResponse
+			if audit {
+				var err error
+				responses, err = v.buildAuditResponses(ctx, resource, request, namespaceLabels)
+				if err != nil {
+					v.log.Error(err, "failed to build audit responses")
+				}
+				if v.exceptionUsage != nil {
+					v.exceptionUsage.Record(ctx, metrics.AdmissionRequest, responses...)
+				}
+				if v.resultSink != nil {
+					v.resultSink.Record(ctx, metrics.AdmissionRequest, responses...)
+				}
+				events := webhookutils.GenerateEvents(responses, false)
+				v.eventGen.Add(events...)
 			}
-			events := webhookutils.GenerateEvents(responses, false)
-			v.eventGen.Add(events...)
 			responses = append(responses, engineResponses...)
+			shadowResults := v.evaluateShadows(ctx, request, namespaceLabels, responses)
+			if !audit {
+				return
+			}
 			report := reportutils.BuildAdmissionReport(resource, request, request.Kind, responses...)
+			addShadowResults(report, shadowResults...)
 			// if it's not a creation, the resource already exists, we can set the owner
 			if request.Operation != admissionv1.Create {
 				gv := metav1.GroupVersion{Group: request.Kind.Group, Version: request.Kind.Version}
 				controllerutils.SetOwner(report, gv.String(), request.Kind.Kind, resource.GetName(), resource.GetUID())
 			}
 			if len(report.GetResults()) > 0 {
-				_, err = reportutils.CreateReport(ctx, report, v.kyvernoClient)
+				_, err := reportutils.CreateReport(ctx, report, v.kyvernoClient)
 				if err != nil {
 					v.log.Error(err, "failed to create report")
 				}
@@ -1049,7 +1049,13 @@ func TestValidate_failure_action_overrides(t *testing.T) {
 		},
 	}
 
-	cfg := config.NewDefaultConfiguration()
+	eng := engine.NewEngine(
+		config.NewDefaultConfiguration(),
+		registryclient.NewOrDie(),
+		engine.LegacyContextLoaderFactory(registryclient.NewOrDie()),
+		nil,
+		nil,
+	)
 	for i, tc := range testcases {
 		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
 			var policy kyvernov1.ClusterPolicy
@@ -1059,11 +1065,9 @@ func TestValidate_failure_action_overrides(t *testing.T) {
 			assert.NilError(t, err)
 
 			ctx := engine.NewPolicyContext().WithPolicy(&policy).WithNewResource(*resourceUnstructured).WithNamespaceLabels(tc.rawResourceNamespaceLabels)
-			er := engine.Validate(
+			er := eng.Validate(
 				context.TODO(),
-				engine.LegacyContextLoaderFactory(registryclient.NewOrDie()),
 				ctx,
-				cfg,
 			)
 			if tc.blocked && tc.messages != nil {
 				for _, r := range er.PolicyResponse.Rules {
@@ -1124,12 +1128,16 @@ func Test_RuleSelector(t *testing.T) {
 
 	ctx := engine.NewPolicyContext().WithPolicy(&policy).WithNewResource(*resourceUnstructured)
 
-	cfg := config.NewDefaultConfiguration()
-	resp := engine.Validate(
-		context.TODO(),
+	eng := engine.NewEngine(
+		config.NewDefaultConfiguration(),
+		registryclient.NewOrDie(),
 		engine.LegacyContextLoaderFactory(registryclient.NewOrDie()),
+		nil,
+		nil,
+	)
+	resp := eng.Validate(
+		context.TODO(),
 		ctx,
-		cfg,
 	)
 	assert.Assert(t, resp.PolicyResponse.RulesAppliedCount == 2)
 	assert.Assert(t, resp.PolicyResponse.RulesErrorCount == 0)
@@ -1141,11 +1149,9 @@ func Test_RuleSelector(t *testing.T) {
 	applyOne := kyvernov1.ApplyOne
 	policy.Spec.ApplyRules = &applyOne
 
-	resp = engine.Validate(
+	resp = eng.Validate(
 		context.TODO(),
-		engine.LegacyContextLoaderFactory(registryclient.NewOrDie()),
 		ctx,
-		cfg,
 	)
 	assert.Assert(t, resp.PolicyResponse.RulesAppliedCount == 1)
 	assert.Assert(t, resp.PolicyResponse.RulesErrorCount == 0)
@@ -27,6 +27,17 @@ import (
 type DebugModeOptions struct {
 	// DumpPayload is used to activate/deactivate debug mode.
 	DumpPayload bool
+	// DumpPath is the directory where admission reviews of resource requests are written, nothing is written if empty.
+	DumpPath string
+	// DumpFormat is the format of the written admission reviews, one of handlers.DumpFormatReview or handlers.DumpFormatJSONLines.
+	DumpFormat string
+}
+
+func (o DebugModeOptions) payloadWriter(name string) handlers.PayloadWriter {
+	if o.DumpPath == "" {
+		return nil
+	}
+	return handlers.NewFileWriter(o.DumpPath, o.DumpFormat, name)
 }
 
 type Server interface {
@@ -53,6 +64,10 @@ type ResourceHandlers interface {
 	Mutate(context.Context, logr.Logger, *admissionv1.AdmissionRequest, string, time.Time) *admissionv1.AdmissionResponse
 	// Validate performs the validation check on kube resources
 	Validate(context.Context, logr.Logger, *admissionv1.AdmissionRequest, string, time.Time) *admissionv1.AdmissionResponse
+	// MutateFailurePolicy returns "fail" if a policy mutating the kube resource has the Fail failure policy, "ignore" otherwise
+	MutateFailurePolicy(*admissionv1.AdmissionRequest) string
+	// ValidateFailurePolicy returns "fail" if a policy validating the kube resource has the Fail failure policy, "ignore" otherwise
+	ValidateFailurePolicy(*admissionv1.AdmissionRequest) string
 }
 
 type server struct {
@@ -73,6 +88,7 @@ func NewServer(
 	configuration config.Configuration,
 	metricsConfig metrics.MetricsConfigManager,
 	debugModeOpts DebugModeOptions,
+	limiter handlers.Limiter,
 	tlsProvider TlsProvider,
 	mwcClient controllerutils.DeleteCollectionClient[*admissionregistrationv1.MutatingWebhookConfiguration],
 	vwcClient controllerutils.DeleteCollectionClient[*admissionregistrationv1.ValidatingWebhookConfiguration],
@@ -89,11 +105,13 @@ func NewServer(
 		"MUTATE",
 		config.MutatingWebhookServicePath,
 		resourceHandlers.Mutate,
-		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
+		func(handler handlers.AdmissionHandler, failurePolicy string) handlers.HttpHandler {
 			return handler.
+				WithLoadShedding(limiter, shedFailurePolicy(failurePolicy, resourceHandlers.MutateFailurePolicy)).
 				WithFilter(configuration).
 				WithProtection(toggle.ProtectManagedResources.Enabled()).
 				WithDump(debugModeOpts.DumpPayload).
+				WithPayloadWriter(debugModeOpts.payloadWriter("mutate")).
 				WithOperationFilter(admissionv1.Create, admissionv1.Update, admissionv1.Connect).
 				WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookMutating).
 				WithAdmission(resourceLogger.WithName("mutate"))
@@ -104,11 +122,13 @@ func NewServer(
 		"VALIDATE",
 		config.ValidatingWebhookServicePath,
 		resourceHandlers.Validate,
-		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
+		func(handler handlers.AdmissionHandler, failurePolicy string) handlers.HttpHandler {
 			return handler.
+				WithLoadShedding(limiter, shedFailurePolicy(failurePolicy, resourceHandlers.ValidateFailurePolicy)).
 				WithFilter(configuration).
 				WithProtection(toggle.ProtectManagedResources.Enabled()).
 				WithDump(debugModeOpts.DumpPayload).
+				WithPayloadWriter(debugModeOpts.payloadWriter("validate")).
 				WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookValidating).
 				WithAdmission(resourceLogger.WithName("validate"))
 		},
@@ -238,12 +258,23 @@ func (s *server) cleanup(ctx context.Context) {
 	}
 }
 
+// shedFailurePolicy returns the failure policy of the requests shed on a webhook path, the path serving the policies
+// of both failure policies uses the failure policies of the policies matching the request
+func shedFailurePolicy(failurePolicy string, matching func(*admissionv1.AdmissionRequest) string) func(*admissionv1.AdmissionRequest) string {
+	if failurePolicy == "all" {
+		return matching
+	}
+	return func(*admissionv1.AdmissionRequest) string {
+		return failurePolicy
+	}
+}
+
 func registerWebhookHandlers(
 	mux *httprouter.Router,
 	name string,
 	basePath string,
 	handlerFunc func(context.Context, logr.Logger, *admissionv1.AdmissionRequest, string, time.Time) *admissionv1.AdmissionResponse,
-	builder func(handler handlers.AdmissionHandler) handlers.HttpHandler,
+	builder func(handler handlers.AdmissionHandler, failurePolicy string) handlers.HttpHandler,
 ) {
 	all := handlers.FromAdmissionFunc(
 		name,
@@ -263,7 +294,7 @@ func registerWebhookHandlers(
 			return handlerFunc(ctx, logger, request, "fail", startTime)
 		},
 	)
-	mux.HandlerFunc("POST", basePath, builder(all).ToHandlerFunc())
-	mux.HandlerFunc("POST", basePath+"/ignore", builder(ignore).ToHandlerFunc())
-	mux.HandlerFunc("POST", basePath+"/fail", builder(fail).ToHandlerFunc())
+	mux.HandlerFunc("POST", basePath, builder(all, "all").ToHandlerFunc())
+	mux.HandlerFunc("POST", basePath+"/ignore", builder(ignore, "ignore").ToHandlerFunc())
+	mux.HandlerFunc("POST", basePath+"/fail", builder(fail, "fail").ToHandlerFunc())
 }
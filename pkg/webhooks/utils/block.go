@@ -1,15 +1,37 @@
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// CauseTypePolicyViolation is the type of the status causes of rules failing validation
+	CauseTypePolicyViolation metav1.CauseType = "PolicyViolation"
+	// CauseTypePolicyError is the type of the status causes of rules that could not be evaluated
+	CauseTypePolicyError metav1.CauseType = "PolicyError"
+)
+
+// BlockedCause describes a rule blocking an admission request, it is encoded in JSON in the message of the
+// status causes of the admission response so that tools don't have to parse the human readable message
+type BlockedCause struct {
+	Policy    string `json:"policy"`
+	Namespace string `json:"namespace,omitempty"`
+	Rule      string `json:"rule"`
+	Severity  string `json:"severity,omitempty"`
+	Category  string `json:"category,omitempty"`
+	Message   string `json:"message"`
+}
+
 func getAction(hasViolations bool, i int) string {
 	action := "error"
 	if hasViolations {
@@ -65,3 +87,57 @@ func GetBlockedMessages(engineResponses []*engineapi.EngineResponse) string {
 	msg := fmt.Sprintf("\n\npolicy %s for resource %s: \n\n%s", resourceName, action, results)
 	return msg
 }
+
+// GetBlockedCauses returns a status cause for each rule with error or fail status, the field of the cause
+// is set when the path of the resource field failing validation is known
+func GetBlockedCauses(engineResponses []*engineapi.EngineResponse) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	for _, er := range engineResponses {
+		var annotations map[string]string
+		if er.Policy != nil {
+			annotations = er.Policy.GetAnnotations()
+		}
+		for _, rule := range er.PolicyResponse.Rules {
+			if !rule.HasStatus(engineapi.RuleStatusFail, engineapi.RuleStatusError, engineapi.RuleStatusTimeout) {
+				continue
+			}
+			cause := metav1.StatusCause{
+				Type:  CauseTypePolicyError,
+				Field: fieldPath(rule.Path),
+			}
+			if rule.Status == engineapi.RuleStatusFail {
+				cause.Type = CauseTypePolicyViolation
+			}
+			message, _ := json.Marshal(BlockedCause{
+				Policy:    er.PolicyResponse.Policy.Name,
+				Namespace: er.PolicyResponse.Policy.Namespace,
+				Rule:      rule.Name,
+				Severity:  annotations[kyvernov1.AnnotationPolicySeverity],
+				Category:  annotations[kyvernov1.AnnotationPolicyCategory],
+				Message:   rule.Message,
+			})
+			cause.Message = string(message)
+			causes = append(causes, cause)
+		}
+	}
+	return causes
+}
+
+// fieldPath converts a JSON path like /spec/containers/0/image/ to a field path like spec.containers[0].image
+func fieldPath(path string) string {
+	var field strings.Builder
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(segment); err == nil {
+			field.WriteString("[" + segment + "]")
+			continue
+		}
+		if field.Len() != 0 {
+			field.WriteString(".")
+		}
+		field.WriteString(segment)
+	}
+	return field.String()
+}
@@ -7,6 +7,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_getAction(t *testing.T) {
@@ -290,3 +291,65 @@ func TestGetBlockedMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlockedCauses(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			Annotations: map[string]string{
+				kyvernov1.AnnotationPolicySeverity: "high",
+				kyvernov1.AnnotationPolicyCategory: "Pod Security",
+			},
+		},
+	}
+	engineResponses := []*engineapi.EngineResponse{{
+		Policy: policy,
+		PolicyResponse: engineapi.PolicyResponse{
+			Policy: engineapi.PolicySpec{
+				Name: "test",
+			},
+			Rules: []engineapi.RuleResponse{{
+				Name:    "rule-pass",
+				Status:  engineapi.RuleStatusPass,
+				Message: "message pass",
+			}, {
+				Name:    "rule-fail",
+				Status:  engineapi.RuleStatusFail,
+				Message: "message fail",
+				Path:    "/spec/containers/0/image/",
+			}, {
+				Name:    "rule-error",
+				Status:  engineapi.RuleStatusError,
+				Message: "message error",
+			}},
+		},
+	}}
+	want := []metav1.StatusCause{{
+		Type:    CauseTypePolicyViolation,
+		Field:   "spec.containers[0].image",
+		Message: `{"policy":"test","rule":"rule-fail","severity":"high","category":"Pod Security","message":"message fail"}`,
+	}, {
+		Type:    CauseTypePolicyError,
+		Message: `{"policy":"test","rule":"rule-error","severity":"high","category":"Pod Security","message":"message error"}`,
+	}}
+	assert.Equal(t, want, GetBlockedCauses(engineResponses))
+	assert.Nil(t, GetBlockedCauses(nil))
+}
+
+func Test_fieldPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"", ""},
+		{"/", ""},
+		{"/metadata/labels/team/", "metadata.labels.team"},
+		{"/spec/containers/0/image/", "spec.containers[0].image"},
+		{"/spec/template/spec/volumes/1/", "spec.template.spec.volumes[1]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, fieldPath(tt.path))
+		})
+	}
+}
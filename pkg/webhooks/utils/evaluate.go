@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"context"
+	"sync"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine"
+)
+
+// EvaluatePolicies calls evaluate for every policy and returns the results in the same order as the policies.
+// When workers is greater than one, up to workers policies are evaluated concurrently and every evaluation
+// receives its own fork of the policy context, otherwise policies are evaluated sequentially with the given
+// policy context.
+func EvaluatePolicies[T any](
+	ctx context.Context,
+	workers int,
+	policyContext *engine.PolicyContext,
+	policies []kyvernov1.PolicyInterface,
+	evaluate func(context.Context, kyvernov1.PolicyInterface, *engine.PolicyContext) T,
+) []T {
+	results := make([]T, len(policies))
+	if workers < 2 || len(policies) < 2 {
+		for i, policy := range policies {
+			results[i] = evaluate(ctx, policy, policyContext)
+		}
+		return results
+	}
+	if workers > len(policies) {
+		workers = len(policies)
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				results[index] = evaluate(ctx, policies[index], policyContext.Fork())
+			}
+		}()
+	}
+	for i := range policies {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_EvaluatePolicies(t *testing.T) {
+	var policies []kyvernov1.PolicyInterface
+	for i := 0; i < 10; i++ {
+		policies = append(policies, &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("policy-%d", i)}})
+	}
+	var want []string
+	for _, policy := range policies {
+		want = append(want, policy.GetName())
+	}
+	for _, workers := range []int{0, 1, 3, 20} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			policyContext := engine.NewPolicyContext()
+			var running, maxRunning int32
+			got := EvaluatePolicies(context.TODO(), workers, policyContext, policies, func(_ context.Context, policy kyvernov1.PolicyInterface, pc *engine.PolicyContext) string {
+				current := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					max := atomic.LoadInt32(&maxRunning)
+					if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+						break
+					}
+				}
+				// every concurrent evaluation gets its own json context
+				assert.NoError(t, pc.JSONContext().AddVariable("policy", policy.GetName()))
+				time.Sleep(time.Millisecond)
+				value, err := pc.JSONContext().Query("policy")
+				assert.NoError(t, err)
+				if workers > 1 {
+					assert.Equal(t, policy.GetName(), value)
+				}
+				return policy.GetName()
+			})
+			assert.Equal(t, want, got)
+			limit := int32(workers)
+			if limit < 1 {
+				limit = 1
+			}
+			assert.LessOrEqual(t, maxRunning, limit)
+		})
+	}
+}
@@ -23,7 +23,7 @@ func GenerateEvents(engineResponses []*engineapi.EngineResponse, blocked bool) [
 		}
 		if !er.IsSuccessful() {
 			for i, ruleResp := range er.PolicyResponse.Rules {
-				if ruleResp.Status == engineapi.RuleStatusFail || ruleResp.Status == engineapi.RuleStatusError {
+				if ruleResp.HasStatus(engineapi.RuleStatusFail, engineapi.RuleStatusError, engineapi.RuleStatusTimeout) {
 					e := event.NewPolicyFailEvent(event.AdmissionController, event.PolicyViolation, er, &er.PolicyResponse.Rules[i], blocked)
 					events = append(events, e)
 				}